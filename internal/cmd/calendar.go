@@ -13,11 +13,12 @@ import (
 )
 
 type CalendarCmd struct {
-	Calendars       CalendarCalendarsCmd       `cmd:"" name:"calendars" help:"List calendars"`
+	Calendars       CalendarCalendarsCmd       `cmd:"" name:"calendars" help:"List and manage calendars"`
 	ACL             CalendarAclCmd             `cmd:"" name:"acl" aliases:"permissions,perms" help:"List calendar ACL"`
 	Events          CalendarEventsCmd          `cmd:"" name:"events" aliases:"list,ls" help:"List events from a calendar or all calendars"`
 	Event           CalendarEventCmd           `cmd:"" name:"event" aliases:"get,info,show" help:"Get event"`
 	Create          CalendarCreateCmd          `cmd:"" name:"create" aliases:"add,new" help:"Create an event"`
+	Quick           CalendarQuickCmd           `cmd:"" name:"quick" aliases:"quick-add,quickadd" help:"Create an event from natural-language text"`
 	Update          CalendarUpdateCmd          `cmd:"" name:"update" aliases:"edit,set" help:"Update an event"`
 	Delete          CalendarDeleteCmd          `cmd:"" name:"delete" aliases:"rm,del,remove" help:"Delete an event"`
 	FreeBusy        CalendarFreeBusyCmd        `cmd:"" name:"freebusy" help:"Get free/busy"`
@@ -25,6 +26,11 @@ type CalendarCmd struct {
 	ProposeTime     CalendarProposeTimeCmd     `cmd:"" name:"propose-time" help:"Generate URL to propose a new meeting time (browser-only feature)"`
 	Colors          CalendarColorsCmd          `cmd:"" name:"colors" help:"Show calendar colors"`
 	Conflicts       CalendarConflictsCmd       `cmd:"" name:"conflicts" help:"Find conflicts"`
+	Rooms           CalendarRoomsCmd           `cmd:"" name:"rooms" aliases:"resources" help:"List meeting rooms and find free ones"`
+	Bulk            CalendarBulkCmd            `cmd:"" name:"bulk" help:"Move, copy, shift or delete events matching a query"`
+	Stats           CalendarStatsCmd           `cmd:"" name:"stats" help:"Meeting-time analytics per person and week"`
+	Agenda          CalendarAgendaCmd          `cmd:"" name:"agenda" aliases:"grid" help:"Draw a day, week or month grid in the terminal"`
+	FindTime        CalendarFindTimeCmd        `cmd:"" name:"find-time" aliases:"findtime,slots" help:"Find a meeting slot when all attendees are free"`
 	Search          CalendarSearchCmd          `cmd:"" name:"search" aliases:"find,query" help:"Search events"`
 	Time            CalendarTimeCmd            `cmd:"" name:"time" help:"Show server time"`
 	Users           CalendarUsersCmd           `cmd:"" name:"users" help:"List workspace users (use their email as calendar ID)"`
@@ -32,16 +38,20 @@ type CalendarCmd struct {
 	FocusTime       CalendarFocusTimeCmd       `cmd:"" name:"focus-time" aliases:"focus" help:"Create a Focus Time block"`
 	OOO             CalendarOOOCmd             `cmd:"" name:"out-of-office" aliases:"ooo" help:"Create an Out of Office event"`
 	WorkingLocation CalendarWorkingLocationCmd `cmd:"" name:"working-location" aliases:"wl" help:"Set working location (home/office/custom)"`
+	Export          CalendarExportCmd          `cmd:"" name:"export" help:"Export events to an iCalendar (.ics) file"`
+	Import          CalendarImportCmd          `cmd:"" name:"import" help:"Import events from an iCalendar (.ics) file"`
+	Sync            CalendarSyncCmd            `cmd:"" name:"sync" help:"Incrementally mirror a calendar into a local directory"`
+	Watch           CalendarWatchCmd           `cmd:"" name:"watch" help:"Deliver event changes to hooks via push channels or polling"`
 }
 
-type CalendarCalendarsCmd struct {
+type CalendarCalendarsListCmd struct {
 	Max       int64  `name:"max" aliases:"limit" help:"Max results" default:"100"`
 	Page      string `name:"page" aliases:"cursor" help:"Page token"`
 	All       bool   `name:"all" aliases:"all-pages,allpages" help:"Fetch all pages"`
 	FailEmpty bool   `name:"fail-empty" aliases:"non-empty,require-results" help:"Exit with code 3 if no results"`
 }
 
-func (c *CalendarCalendarsCmd) Run(ctx context.Context, flags *RootFlags) error {
+func (c *CalendarCalendarsListCmd) Run(ctx context.Context, flags *RootFlags) error {
 	u := ui.FromContext(ctx)
 	account, err := requireAccount(flags)
 	if err != nil {
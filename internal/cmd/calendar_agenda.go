@@ -0,0 +1,312 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const (
+	agendaViewDay   = "day"
+	agendaViewWeek  = "week"
+	agendaViewMonth = "month"
+)
+
+type CalendarAgendaCmd struct {
+	View      string `name:"view" help:"Grid to draw: day, week or month" default:"day" enum:"day,week,month"`
+	Date      string `name:"date" help:"Any date inside the period (date or relative; default: today)"`
+	Calendars string `name:"calendars" help:"Comma-separated calendar IDs to overlay" default:"primary"`
+	Group     string `name:"group" help:"Google Group email; overlay members' calendars instead of --calendars"`
+	Hours     string `name:"hours" help:"Visible hours for day/week grids, widened to fit events" default:"08:00-19:00"`
+	Slot      string `name:"slot" help:"Row size for day/week grids" default:"30m"`
+	WeekStart string `name:"week-start" help:"First day of week rows (sun, mon, ...)" default:""`
+	Width     int    `name:"width" help:"Grid width in columns (default: terminal width)"`
+	Timezone  string `name:"timezone" short:"z" help:"Display timezone (IANA name; default: primary calendar timezone)"`
+}
+
+// agendaEvent is an event normalized for grid layout. Timed events that
+// cross midnight are split per day, so Lane/Lanes are per-day values.
+type agendaEvent struct {
+	CalendarID string    `json:"calendarId"`
+	ID         string    `json:"id"`
+	Summary    string    `json:"summary"`
+	Start      time.Time `json:"start"`
+	End        time.Time `json:"end"`
+	AllDay     bool      `json:"allDay,omitempty"`
+	Declined   bool      `json:"declined,omitempty"`
+	Conflict   bool      `json:"conflict,omitempty"`
+	Color      string    `json:"color,omitempty"`
+	Foreground string    `json:"-"`
+	Lane       int       `json:"lane"`
+	Lanes      int       `json:"lanes"`
+}
+
+type agendaDay struct {
+	Date   string         `json:"date"`
+	AllDay []*agendaEvent `json:"allDay"`
+	Events []*agendaEvent `json:"events"`
+	day    time.Time
+}
+
+func (c *CalendarAgendaCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	hours, err := parseWorkingHours(c.Hours)
+	if err != nil {
+		return usagef("invalid --hours %q (expected HH:MM-HH:MM)", c.Hours)
+	}
+	slot, err := time.ParseDuration(strings.TrimSpace(c.Slot))
+	if err != nil || slot < 5*time.Minute || slot > 2*time.Hour {
+		return usagef("invalid --slot %q (between 5m and 2h)", c.Slot)
+	}
+	weekStart, err := resolveWeekStart(c.WeekStart)
+	if err != nil {
+		return usage(err.Error())
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	loc, err := getConfiguredTimezone(c.Timezone)
+	if err != nil {
+		return err
+	}
+	if loc == nil {
+		if loc, err = getUserTimezone(ctx, svc); err != nil {
+			return err
+		}
+	}
+	now := time.Now().In(loc)
+	anchor := startOfDay(now)
+	if strings.TrimSpace(c.Date) != "" {
+		t, parseErr := parseTimeExpr(c.Date, now, loc)
+		if parseErr != nil {
+			return usagef("invalid --date: %v", parseErr)
+		}
+		anchor = startOfDay(t.In(loc))
+	}
+	from, to := agendaPeriod(c.View, anchor, weekStart)
+
+	calendarIDs := splitCSV(c.Calendars)
+	if group := strings.TrimSpace(c.Group); group != "" {
+		cloudSvc, cloudErr := newCloudIdentityService(ctx, account)
+		if cloudErr != nil {
+			return wrapCloudIdentityError(cloudErr, account)
+		}
+		calendarIDs, err = collectGroupMemberEmails(ctx, cloudSvc, group)
+		if err != nil {
+			return fmt.Errorf("failed to list group members: %w", err)
+		}
+	}
+	if len(calendarIDs) == 0 {
+		return usage("no calendars to show")
+	}
+
+	events, err := fetchAgendaEvents(ctx, svc, calendarIDs, from, to, loc)
+	if err != nil {
+		return err
+	}
+	markAgendaConflicts(events)
+	days := layoutAgendaDays(events, from, to, loc, slot)
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"view":      c.View,
+			"from":      from.Format(time.RFC3339),
+			"to":        to.Format(time.RFC3339),
+			"timezone":  loc.String(),
+			"calendars": calendarIDs,
+			"days":      days,
+		})
+	}
+
+	width := c.Width
+	if width <= 0 {
+		width = guessColumns(os.Stdout)
+	}
+	var lines []string
+	if c.View == agendaViewMonth {
+		lines = renderAgendaMonth(u.Out(), days, anchor, now, width)
+	} else {
+		lines = renderAgendaTimeGrid(u.Out(), days, now, hours, slot, width)
+	}
+	for _, line := range lines {
+		u.Out().Println(line)
+	}
+	return nil
+}
+
+// agendaPeriod returns the fetch range. Month views cover whole weeks so the
+// grid has no holes at either end.
+func agendaPeriod(view string, anchor time.Time, weekStart time.Weekday) (time.Time, time.Time) {
+	switch view {
+	case agendaViewWeek:
+		start := startOfWeek(anchor, weekStart)
+		return start, start.AddDate(0, 0, 7)
+	case agendaViewMonth:
+		first := time.Date(anchor.Year(), anchor.Month(), 1, 0, 0, 0, 0, anchor.Location())
+		last := first.AddDate(0, 1, -1)
+		start := startOfWeek(first, weekStart)
+		end := startOfWeek(last, weekStart).AddDate(0, 0, 7)
+		return start, end
+	default:
+		return anchor, anchor.AddDate(0, 0, 1)
+	}
+}
+
+func fetchAgendaEvents(ctx context.Context, svc *calendar.Service, calendarIDs []string, from, to time.Time, loc *time.Location) ([]*agendaEvent, error) {
+	var palette agendaPalette
+	if colors, err := svc.Colors.Get().Context(ctx).Do(); err == nil {
+		palette.eventColors = colors.Event
+		palette.calendarColors = sortedColorDefinitions(colors.Calendar)
+	}
+
+	var out []*agendaEvent
+	seen := map[string]bool{}
+	for i, calendarID := range calendarIDs {
+		calBG, calFG := palette.calendarColor(i)
+		if entry, err := svc.CalendarList.Get(calendarID).Context(ctx).Do(); err == nil && entry.BackgroundColor != "" {
+			calBG, calFG = entry.BackgroundColor, entry.ForegroundColor
+		}
+
+		pageToken := ""
+		for {
+			call := svc.Events.List(calendarID).
+				SingleEvents(true).
+				OrderBy("startTime").
+				TimeMin(from.Format(time.RFC3339)).
+				TimeMax(to.Format(time.RFC3339)).
+				MaxResults(2500).
+				Context(ctx)
+			if pageToken != "" {
+				call = call.PageToken(pageToken)
+			}
+			resp, err := call.Do()
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", calendarID, err)
+			}
+			for _, ev := range resp.Items {
+				ae := newAgendaEvent(ev, calendarID, loc)
+				if ae == nil {
+					continue
+				}
+				// A meeting shared by several overlaid calendars is drawn once.
+				key := firstNonEmpty(ev.ICalUID, ev.Id) + "|" + ae.Start.String()
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				ae.Color, ae.Foreground = calBG, calFG
+				if def, ok := palette.eventColors[ev.ColorId]; ok {
+					ae.Color, ae.Foreground = def.Background, def.Foreground
+				}
+				out = append(out, ae)
+			}
+			if resp.NextPageToken == "" {
+				break
+			}
+			pageToken = resp.NextPageToken
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Start.Before(out[j].Start) })
+	return out, nil
+}
+
+func newAgendaEvent(ev *calendar.Event, calendarID string, loc *time.Location) *agendaEvent {
+	if ev == nil || ev.Status == "cancelled" || ev.Start == nil || ev.End == nil {
+		return nil
+	}
+	ae := &agendaEvent{CalendarID: calendarID, ID: ev.Id, Summary: strings.Join(strings.Fields(ev.Summary), " ")}
+	if ae.Summary == "" {
+		// Other people's private events come back without details.
+		ae.Summary = "(no title)"
+		if ev.Visibility == "private" || ev.Visibility == "confidential" {
+			ae.Summary = "(busy)"
+		}
+	}
+	for _, att := range ev.Attendees {
+		if att != nil && att.Self && att.ResponseStatus == "declined" {
+			ae.Declined = true
+		}
+	}
+	if ev.Start.Date != "" {
+		start, err1 := time.ParseInLocation("2006-01-02", ev.Start.Date, loc)
+		end, err2 := time.ParseInLocation("2006-01-02", ev.End.Date, loc)
+		if err1 != nil || err2 != nil {
+			return nil
+		}
+		ae.Start, ae.End, ae.AllDay = start, end, true
+		return ae
+	}
+	start, ok1 := parseEventTime(ev.Start.DateTime, ev.Start.TimeZone)
+	end, ok2 := parseEventTime(ev.End.DateTime, ev.End.TimeZone)
+	if !ok1 || !ok2 {
+		return nil
+	}
+	ae.Start, ae.End = start.In(loc), end.In(loc)
+	return ae
+}
+
+// markAgendaConflicts flags timed events that overlap another accepted event.
+func markAgendaConflicts(events []*agendaEvent) {
+	var timed []*agendaEvent
+	for _, ev := range events {
+		if !ev.AllDay && !ev.Declined {
+			timed = append(timed, ev)
+		}
+	}
+	sort.SliceStable(timed, func(i, j int) bool { return timed[i].Start.Before(timed[j].Start) })
+	for i, a := range timed {
+		for _, b := range timed[i+1:] {
+			if !b.Start.Before(a.End) {
+				break
+			}
+			a.Conflict, b.Conflict = true, true
+		}
+	}
+}
+
+type agendaPalette struct {
+	eventColors    map[string]calendar.ColorDefinition
+	calendarColors []*calendar.ColorDefinition
+}
+
+// calendarColor cycles through the calendar palette so overlaid calendars
+// without a list entry (e.g. group members) still get distinct colors.
+func (p agendaPalette) calendarColor(i int) (string, string) {
+	if len(p.calendarColors) == 0 {
+		return "", ""
+	}
+	def := p.calendarColors[i%len(p.calendarColors)]
+	return def.Background, def.Foreground
+}
+
+func sortedColorDefinitions(defs map[string]calendar.ColorDefinition) []*calendar.ColorDefinition {
+	ids := make([]int, 0, len(defs))
+	for id := range defs {
+		if n, err := strconv.Atoi(id); err == nil {
+			ids = append(ids, n)
+		}
+	}
+	sort.Ints(ids)
+	out := make([]*calendar.ColorDefinition, 0, len(ids))
+	for _, n := range ids {
+		def := defs[strconv.Itoa(n)]
+		out = append(out, &def)
+	}
+	return out
+}
@@ -0,0 +1,470 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	agendaGutterWidth   = 6
+	agendaMinColumn     = 8
+	agendaMonthMaxItems = 4
+	agendaNowColor      = "#ef4444"
+)
+
+// agendaStyler is the subset of *ui.Printer the grid needs; it degrades to
+// plain text when color is disabled.
+type agendaStyler interface {
+	ColorEnabled() bool
+	Colorize(s, fg, bg string) string
+	Faint(s string) string
+}
+
+// layoutAgendaDays buckets events per day (timed events clipped to the day)
+// and assigns side-by-side lanes to overlapping timed events.
+func layoutAgendaDays(events []*agendaEvent, from, to time.Time, loc *time.Location, slot time.Duration) []*agendaDay {
+	var days []*agendaDay
+	for d := from.In(loc); d.Before(to); d = d.AddDate(0, 0, 1) {
+		next := d.AddDate(0, 0, 1)
+		day := &agendaDay{Date: d.Format("2006-01-02"), AllDay: []*agendaEvent{}, Events: []*agendaEvent{}, day: d}
+		for _, ev := range events {
+			if !ev.Start.Before(next) || !ev.End.After(d) && !(ev.Start.Equal(ev.End) && !ev.Start.Before(d)) {
+				continue
+			}
+			if ev.AllDay {
+				day.AllDay = append(day.AllDay, ev)
+				continue
+			}
+			clipped := *ev
+			if clipped.Start.Before(d) {
+				clipped.Start = d
+			}
+			if clipped.End.After(next) {
+				clipped.End = next
+			}
+			day.Events = append(day.Events, &clipped)
+		}
+		assignAgendaLanes(day.Events, d, slot)
+		days = append(days, day)
+	}
+	return days
+}
+
+// assignAgendaLanes works on slot-rounded intervals so two events never share
+// a grid cell; each overlap cluster gets as many lanes as it needs.
+func assignAgendaLanes(events []*agendaEvent, dayStart time.Time, slot time.Duration) {
+	type span struct {
+		ev         *agendaEvent
+		start, end time.Time
+	}
+	spans := make([]span, 0, len(events))
+	for _, ev := range events {
+		start, end := agendaDisplayInterval(ev, dayStart, slot)
+		spans = append(spans, span{ev: ev, start: start, end: end})
+	}
+	sort.SliceStable(spans, func(i, j int) bool {
+		if !spans[i].start.Equal(spans[j].start) {
+			return spans[i].start.Before(spans[j].start)
+		}
+		return spans[i].end.After(spans[j].end)
+	})
+
+	var cluster []span
+	var laneEnds []time.Time
+	var clusterEnd time.Time
+	flush := func() {
+		for _, s := range cluster {
+			s.ev.Lanes = len(laneEnds)
+		}
+		cluster, laneEnds = nil, nil
+	}
+	for _, s := range spans {
+		if len(cluster) > 0 && !s.start.Before(clusterEnd) {
+			flush()
+		}
+		lane := -1
+		for i, end := range laneEnds {
+			if !s.start.Before(end) {
+				lane = i
+				break
+			}
+		}
+		if lane < 0 {
+			lane = len(laneEnds)
+			laneEnds = append(laneEnds, s.end)
+		} else {
+			laneEnds[lane] = s.end
+		}
+		s.ev.Lane = lane
+		cluster = append(cluster, s)
+		if s.end.After(clusterEnd) {
+			clusterEnd = s.end
+		}
+	}
+	flush()
+}
+
+func agendaDisplayInterval(ev *agendaEvent, dayStart time.Time, slot time.Duration) (time.Time, time.Time) {
+	start := dayStart.Add(ev.Start.Sub(dayStart) / slot * slot)
+	end := dayStart.Add((ev.End.Sub(dayStart) + slot - 1) / slot * slot)
+	if !end.After(start) {
+		end = start.Add(slot)
+	}
+	return start, end
+}
+
+// agendaVisibleMinutes widens the requested hours to fit every timed event and
+// aligns both ends to the slot size.
+func agendaVisibleMinutes(days []*agendaDay, hours workingHours, slot time.Duration) (int, int) {
+	startMin, endMin := hours.Start, hours.End
+	for _, day := range days {
+		for _, ev := range day.Events {
+			startMin = min(startMin, int(ev.Start.Sub(day.day).Minutes()))
+			endMin = max(endMin, int(ev.End.Sub(day.day).Minutes()))
+		}
+	}
+	step := int(slot.Minutes())
+	startMin = max(startMin/step*step, 0)
+	endMin = min((endMin+step-1)/step*step, 24*60)
+	return startMin, endMin
+}
+
+func renderAgendaTimeGrid(st agendaStyler, days []*agendaDay, now time.Time, hours workingHours, slot time.Duration, width int) []string {
+	if len(days) == 0 {
+		return nil
+	}
+	colW := max((width-agendaGutterWidth)/len(days)-1, agendaMinColumn)
+	rule := strings.Repeat("─", agendaGutterWidth) + strings.Repeat("┼"+strings.Repeat("─", colW), len(days))
+
+	var header strings.Builder
+	header.WriteString(strings.Repeat(" ", agendaGutterWidth))
+	for _, day := range days {
+		header.WriteString("│")
+		label := day.day.Format("Mon 01-02")
+		if sameDate(day.day, now) {
+			header.WriteString(st.Colorize(fitRunes("["+label+"]", colW), agendaNowColor, ""))
+			continue
+		}
+		header.WriteString(fitRunes(label, colW))
+	}
+	lines := []string{header.String(), rule}
+
+	if allDay := renderAgendaAllDayRows(st, days, colW); len(allDay) > 0 {
+		lines = append(lines, allDay...)
+		lines = append(lines, rule)
+	}
+
+	startMin, endMin := agendaVisibleMinutes(days, hours, slot)
+	step := int(slot.Minutes())
+	for m := startMin; m < endMin; m += step {
+		nowRow := false
+		var row strings.Builder
+		cells := make([]string, 0, len(days))
+		for _, day := range days {
+			rowStart := time.Date(day.day.Year(), day.day.Month(), day.day.Day(), 0, m, 0, 0, day.day.Location())
+			rowEnd := rowStart.Add(slot)
+			isNow := !now.Before(rowStart) && now.Before(rowEnd)
+			nowRow = nowRow || isNow
+			gridStart := m == startMin
+			cells = append(cells, renderAgendaCell(st, day.Events, rowStart, rowEnd, gridStart, colW, isNow))
+		}
+		switch {
+		case nowRow:
+			row.WriteString(st.Colorize("▶"+now.Format("15:04"), agendaNowColor, ""))
+		case m%60 == 0:
+			row.WriteString(fmt.Sprintf("%02d:%02d ", m/60, m%60))
+		default:
+			row.WriteString(strings.Repeat(" ", agendaGutterWidth))
+		}
+		for _, cell := range cells {
+			row.WriteString("│")
+			row.WriteString(cell)
+		}
+		lines = append(lines, row.String())
+	}
+	return lines
+}
+
+type agendaSegment struct {
+	offset int
+	width  int
+	text   string
+}
+
+func renderAgendaCell(st agendaStyler, events []*agendaEvent, rowStart, rowEnd time.Time, gridStart bool, colW int, nowLine bool) string {
+	var segs []agendaSegment
+	for _, ev := range events {
+		touches := ev.Start.Before(rowEnd) && ev.End.After(rowStart)
+		if ev.Start.Equal(ev.End) {
+			touches = !ev.Start.Before(rowStart) && ev.Start.Before(rowEnd)
+		}
+		if !touches {
+			continue
+		}
+		lanes := max(ev.Lanes, 1)
+		laneW := colW / lanes
+		offset := ev.Lane * laneW
+		w := laneW
+		if ev.Lane == lanes-1 {
+			w = colW - offset
+		}
+		first := !ev.Start.Before(rowStart) || gridStart
+		segs = append(segs, agendaSegment{offset: offset, width: w, text: agendaEventCell(st, ev, w, first)})
+	}
+	sort.Slice(segs, func(i, j int) bool { return segs[i].offset < segs[j].offset })
+
+	fill := " "
+	if nowLine {
+		fill = "─"
+	}
+	gap := func(n int) string {
+		if n <= 0 {
+			return ""
+		}
+		s := strings.Repeat(fill, n)
+		if nowLine {
+			return st.Colorize(s, agendaNowColor, "")
+		}
+		return s
+	}
+
+	var b strings.Builder
+	pos := 0
+	for _, seg := range segs {
+		if seg.offset < pos {
+			continue
+		}
+		b.WriteString(gap(seg.offset - pos))
+		b.WriteString(seg.text)
+		pos = seg.offset + seg.width
+	}
+	b.WriteString(gap(colW - pos))
+	return b.String()
+}
+
+// agendaEventCell renders one lane-wide slice of an event. The first row
+// carries the title; later rows only continue the block.
+func agendaEventCell(st agendaStyler, ev *agendaEvent, w int, first bool) string {
+	title := ""
+	if first {
+		title = ev.Summary
+		if ev.Conflict {
+			title = "!" + title
+		}
+	}
+	var out string
+	switch {
+	case w <= 1:
+		out = agendaBar(ev)
+	case st.ColorEnabled() && ev.Color != "":
+		out = st.Colorize(fitRunes(" "+title, w-1), ev.Foreground, ev.Color) + " "
+	default:
+		out = fitRunes(agendaBar(ev)+title, w-1) + " "
+	}
+	if ev.Declined {
+		return st.Faint(out)
+	}
+	return out
+}
+
+func agendaBar(ev *agendaEvent) string {
+	if ev.Declined {
+		return "┆"
+	}
+	return "┃"
+}
+
+// renderAgendaAllDayRows draws all-day and multi-day events as bars spanning
+// their day columns, packing non-overlapping bars into shared rows.
+func renderAgendaAllDayRows(st agendaStyler, days []*agendaDay, colW int) []string {
+	type bar struct {
+		ev          *agendaEvent
+		first, last int
+	}
+	var bars []bar
+	seen := map[*agendaEvent]bool{}
+	for i, day := range days {
+		for _, ev := range day.AllDay {
+			if seen[ev] {
+				continue
+			}
+			seen[ev] = true
+			last := i
+			for j := i + 1; j < len(days) && ev.End.After(days[j].day); j++ {
+				last = j
+			}
+			bars = append(bars, bar{ev: ev, first: i, last: last})
+		}
+	}
+
+	var rows [][]bar
+	for _, b := range bars {
+		placed := false
+		for r, row := range rows {
+			free := true
+			for _, other := range row {
+				if b.first <= other.last && other.first <= b.last {
+					free = false
+					break
+				}
+			}
+			if free {
+				rows[r] = append(rows[r], b)
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			rows = append(rows, []bar{b})
+		}
+	}
+
+	lines := make([]string, 0, len(rows))
+	for r, row := range rows {
+		var line strings.Builder
+		if r == 0 {
+			line.WriteString(fitRunes("all", agendaGutterWidth))
+		} else {
+			line.WriteString(strings.Repeat(" ", agendaGutterWidth))
+		}
+		for i := range days {
+			line.WriteString("│")
+			cell := strings.Repeat(" ", colW)
+			for _, b := range row {
+				if i < b.first || i > b.last {
+					continue
+				}
+				cell = agendaBarCell(st, b.ev, colW, i == b.first)
+			}
+			line.WriteString(cell)
+		}
+		lines = append(lines, line.String())
+	}
+	return lines
+}
+
+func agendaBarCell(st agendaStyler, ev *agendaEvent, colW int, first bool) string {
+	title := ""
+	if first {
+		title = ev.Summary
+	}
+	var out string
+	if st.ColorEnabled() && ev.Color != "" {
+		out = st.Colorize(fitRunes(" "+title, colW), ev.Foreground, ev.Color)
+	} else {
+		text := strings.Repeat("═", colW)
+		if first {
+			text = "■ " + title + " " + text
+		}
+		out = fitRunes(text, colW)
+	}
+	if ev.Declined {
+		return st.Faint(out)
+	}
+	return out
+}
+
+func renderAgendaMonth(st agendaStyler, days []*agendaDay, anchor, now time.Time, width int) []string {
+	if len(days) == 0 {
+		return nil
+	}
+	colW := max((width-1)/7-1, agendaMinColumn+2)
+	rule := "├" + strings.Repeat(strings.Repeat("─", colW)+"┼", 6) + strings.Repeat("─", colW) + "┤"
+
+	var header strings.Builder
+	for _, day := range days[:min(7, len(days))] {
+		header.WriteString("│")
+		header.WriteString(fitRunes(day.day.Format("Mon"), colW))
+	}
+	header.WriteString("│")
+	lines := []string{anchor.Format("January 2006"), header.String(), rule}
+
+	for w := 0; w < len(days); w += 7 {
+		week := days[w:min(w+7, len(days))]
+		itemLines := 1
+		for _, day := range week {
+			itemLines = max(itemLines, min(len(day.AllDay)+len(day.Events), agendaMonthMaxItems))
+		}
+
+		var dates strings.Builder
+		for _, day := range week {
+			dates.WriteString("│")
+			label := fitRunes(fmt.Sprintf("%2d", day.day.Day()), colW)
+			switch {
+			case sameDate(day.day, now):
+				label = st.Colorize(fitRunes(fmt.Sprintf("[%d]", day.day.Day()), colW), agendaNowColor, "")
+			case day.day.Month() != anchor.Month():
+				label = st.Faint(label)
+			}
+			dates.WriteString(label)
+		}
+		dates.WriteString("│")
+		lines = append(lines, dates.String())
+
+		for i := 0; i < itemLines; i++ {
+			var line strings.Builder
+			for _, day := range week {
+				line.WriteString("│")
+				line.WriteString(agendaMonthItem(st, day, i, colW))
+			}
+			line.WriteString("│")
+			lines = append(lines, line.String())
+		}
+		lines = append(lines, rule)
+	}
+	return lines
+}
+
+// agendaMonthItem returns the i-th line of a month cell: all-day events first,
+// then timed events, with the last line replaced by "+N more" on overflow.
+func agendaMonthItem(st agendaStyler, day *agendaDay, i, colW int) string {
+	items := make([]*agendaEvent, 0, len(day.AllDay)+len(day.Events))
+	items = append(items, day.AllDay...)
+	items = append(items, day.Events...)
+	if i >= len(items) {
+		return strings.Repeat(" ", colW)
+	}
+	if i == agendaMonthMaxItems-1 && len(items) > agendaMonthMaxItems {
+		return fitRunes(fmt.Sprintf("+%d more", len(items)-i), colW)
+	}
+	ev := items[i]
+	text := ev.Summary
+	if !ev.AllDay {
+		text = ev.Start.Format("15:04") + " " + text
+	}
+	if ev.Conflict {
+		text = "!" + text
+	}
+	marker := "•"
+	if st.ColorEnabled() && ev.Color != "" {
+		marker = st.Colorize("●", ev.Color, "")
+	}
+	out := marker + fitRunes(text, colW-1)
+	if ev.Declined {
+		return st.Faint(out)
+	}
+	return out
+}
+
+func sameDate(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.In(a.Location()).Date()
+	return ay == by && am == bm && ad == bd
+}
+
+// fitRunes truncates s with an ellipsis or pads it with spaces to exactly w runes.
+func fitRunes(s string, w int) string {
+	if w <= 0 {
+		return ""
+	}
+	r := []rune(s)
+	if len(r) > w {
+		if w == 1 {
+			return string(r[:1])
+		}
+		return string(r[:w-1]) + "…"
+	}
+	return s + strings.Repeat(" ", w-len(r))
+}
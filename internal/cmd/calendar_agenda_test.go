@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/option"
+)
+
+type plainAgendaStyler struct{}
+
+func (plainAgendaStyler) ColorEnabled() bool             { return false }
+func (plainAgendaStyler) Colorize(s, _, _ string) string { return s }
+func (plainAgendaStyler) Faint(s string) string          { return s }
+
+func agendaTestEvent(summary string, start, end time.Time) *agendaEvent {
+	return &agendaEvent{ID: summary, Summary: summary, Start: start, End: end}
+}
+
+func TestLayoutAgendaDays_LanesAndAllDay(t *testing.T) {
+	day := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	at := func(h, m int) time.Time { return day.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute) }
+
+	a := agendaTestEvent("A", at(9, 0), at(10, 0))
+	b := agendaTestEvent("B", at(9, 30), at(10, 30))
+	c := agendaTestEvent("C", at(10, 0), at(11, 0))
+	d := agendaTestEvent("D", at(14, 0), at(14, 10))
+	late := agendaTestEvent("Late", at(23, 0), at(25, 0))
+	off := &agendaEvent{Summary: "Off", Start: day, End: day.AddDate(0, 0, 2), AllDay: true}
+	events := []*agendaEvent{off, a, b, c, d, late}
+	markAgendaConflicts(events)
+
+	days := layoutAgendaDays(events, day, day.AddDate(0, 0, 2), time.UTC, 30*time.Minute)
+	if len(days) != 2 {
+		t.Fatalf("expected 2 days, got %d", len(days))
+	}
+	first := days[0]
+	if len(first.AllDay) != 1 || len(days[1].AllDay) != 1 {
+		t.Fatalf("all-day event should cover both days: %#v", days)
+	}
+	lanes := map[string][2]int{}
+	for _, ev := range first.Events {
+		lanes[ev.Summary] = [2]int{ev.Lane, ev.Lanes}
+	}
+	if lanes["A"] != [2]int{0, 2} || lanes["B"] != [2]int{1, 2} || lanes["C"] != [2]int{0, 2} || lanes["D"] != [2]int{0, 1} {
+		t.Fatalf("unexpected lanes: %v", lanes)
+	}
+	if !a.Conflict || !b.Conflict || !c.Conflict || d.Conflict {
+		t.Fatalf("unexpected conflicts: A=%v B=%v C=%v D=%v", a.Conflict, b.Conflict, c.Conflict, d.Conflict)
+	}
+	if len(days[1].Events) != 1 || !days[1].Events[0].Start.Equal(days[1].day) || !days[1].Events[0].End.Equal(at(25, 0)) {
+		t.Fatalf("overnight event should be clipped into the next day: %#v", days[1].Events)
+	}
+}
+
+func TestRenderAgendaTimeGrid(t *testing.T) {
+	day := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	at := func(h, m int) time.Time { return day.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute) }
+	declined := agendaTestEvent("Skip", at(13, 0), at(14, 0))
+	declined.Declined = true
+	events := []*agendaEvent{
+		{Summary: "Holiday", Start: day, End: day.AddDate(0, 0, 1), AllDay: true},
+		agendaTestEvent("Standup", at(9, 0), at(10, 0)),
+		agendaTestEvent("Review", at(9, 0), at(9, 30)),
+		declined,
+	}
+	markAgendaConflicts(events)
+	days := layoutAgendaDays(events, day, day.AddDate(0, 0, 1), time.UTC, 30*time.Minute)
+
+	lines := renderAgendaTimeGrid(plainAgendaStyler{}, days, at(9, 40), workingHours{Start: 9 * 60, End: 14 * 60}, 30*time.Minute, 46)
+	out := strings.Join(lines, "\n")
+	for _, want := range []string{"[Mon 01-05]", "■ Holiday", "┃!Standup", "┃!Review", "▶09:40", "┆Skip", "13:00 "} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("missing %q in grid:\n%s", want, out)
+		}
+	}
+	for _, line := range lines {
+		if got := len([]rune(line)); got != 46 {
+			t.Fatalf("expected width 46, got %d: %q", got, line)
+		}
+	}
+}
+
+func TestRenderAgendaMonth_Overflow(t *testing.T) {
+	anchor := time.Date(2026, 1, 14, 0, 0, 0, 0, time.UTC)
+	from, to := agendaPeriod(agendaViewMonth, anchor, time.Monday)
+	if from.Format("2006-01-02") != "2025-12-29" || to.Format("2006-01-02") != "2026-02-02" {
+		t.Fatalf("unexpected month period: %s - %s", from, to)
+	}
+	var events []*agendaEvent
+	for i := 0; i < 6; i++ {
+		start := anchor.Add(time.Duration(8+i) * time.Hour)
+		events = append(events, agendaTestEvent("Meeting", start, start.Add(30*time.Minute)))
+	}
+	days := layoutAgendaDays(events, from, to, time.UTC, 30*time.Minute)
+	out := strings.Join(renderAgendaMonth(plainAgendaStyler{}, days, anchor, anchor, 120), "\n")
+	for _, want := range []string{"January 2026", "[14]", "•08:00 Meeting", "+3 more"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("missing %q in month:\n%s", want, out)
+		}
+	}
+}
+
+func TestCalendarAgendaCmd_JSON(t *testing.T) {
+	origNew := newCalendarService
+	t.Cleanup(func() { newCalendarService = origNew })
+
+	srv := httptest.NewServer(withPrimaryCalendar(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/colors"):
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"calendar": map[string]any{"1": map[string]any{"background": "#ac725e", "foreground": "#1d1d1d"}},
+				"event":    map[string]any{"11": map[string]any{"background": "#dc2127", "foreground": "#ffffff"}},
+			})
+		case strings.HasSuffix(r.URL.Path, "/calendars/primary/events"):
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"items": []map[string]any{
+					{
+						"id":      "e1",
+						"summary": "Planning",
+						"colorId": "11",
+						"start":   map[string]any{"dateTime": "2026-01-05T09:00:00Z"},
+						"end":     map[string]any{"dateTime": "2026-01-05T10:00:00Z"},
+					},
+					{
+						"id":        "e2",
+						"summary":   "Optional",
+						"start":     map[string]any{"dateTime": "2026-01-05T09:30:00Z"},
+						"end":       map[string]any{"dateTime": "2026-01-05T10:30:00Z"},
+						"attendees": []map[string]any{{"email": "a@b.com", "self": true, "responseStatus": "declined"}},
+					},
+					{"id": "gone", "status": "cancelled"},
+				},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	})))
+	defer srv.Close()
+
+	svc, err := calendar.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return svc, nil }
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "calendar", "agenda", "--date", "2026-01-05", "--timezone", "UTC"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	var parsed struct {
+		View string      `json:"view"`
+		Days []agendaDay `json:"days"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if parsed.View != agendaViewDay || len(parsed.Days) != 1 || len(parsed.Days[0].Events) != 2 {
+		t.Fatalf("unexpected agenda: %s", out)
+	}
+	planning, optional := parsed.Days[0].Events[0], parsed.Days[0].Events[1]
+	if planning.Color != "#dc2127" || planning.Conflict || planning.Lanes != 2 {
+		t.Fatalf("unexpected planning event: %#v", planning)
+	}
+	if !optional.Declined || optional.Color != "#ac725e" || optional.Lane != 1 {
+		t.Fatalf("unexpected declined event: %#v", optional)
+	}
+}
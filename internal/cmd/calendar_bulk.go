@@ -0,0 +1,545 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type CalendarBulkCmd struct {
+	Move   CalendarBulkMoveCmd   `cmd:"" name:"move" help:"Move matching events to another calendar (events.move)"`
+	Copy   CalendarBulkCopyCmd   `cmd:"" name:"copy" help:"Copy matching events into a calendar"`
+	Shift  CalendarBulkShiftCmd  `cmd:"" name:"shift" aliases:"reschedule" help:"Move matching events earlier or later"`
+	Delete CalendarBulkDeleteCmd `cmd:"" name:"delete" aliases:"rm" help:"Delete matching events"`
+}
+
+// calendarBulkSelector picks events with the same filters as `calendar events`.
+// With --scope future/all, recurring instances collapse to one target per series.
+type calendarBulkSelector struct {
+	CalendarID        string `arg:"" name:"calendarId" optional:"" help:"Calendar ID (default: primary)"`
+	Query             string `name:"query" help:"Free text search"`
+	PrivatePropFilter string `name:"private-prop-filter" help:"Filter by private extended property (key=value)"`
+	SharedPropFilter  string `name:"shared-prop-filter" help:"Filter by shared extended property (key=value)"`
+	Scope             string `name:"scope" help:"Recurring events: single (matched instances), future (series from first match), all (whole series)" default:"single" enum:"single,future,all"`
+	Max               int    `name:"max" aliases:"limit" help:"Refuse to touch more than N events" default:"250"`
+	SendUpdates       string `name:"send-updates" help:"Notification mode: all, externalOnly, none (default: none)"`
+	TimeRangeFlags
+}
+
+type calendarBulkTarget struct {
+	EventID       string `json:"eventId"`
+	Summary       string `json:"summary,omitempty"`
+	Start         string `json:"start,omitempty"`
+	Series        bool   `json:"series,omitempty"`
+	OriginalStart string `json:"originalStart,omitempty"`
+	event         *calendar.Event
+}
+
+type calendarBulkResult struct {
+	calendarBulkTarget
+	NewEventID string `json:"newEventId,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// calendarBulkOp describes one bulk operation; apply returns the ID of the
+// resulting event when it differs from the source.
+type calendarBulkOp struct {
+	Name        string
+	Verb        string
+	Destructive bool
+	Request     map[string]any
+	Apply       func(ctx context.Context, svc *calendar.Service, calendarID string, t calendarBulkTarget) (string, error)
+}
+
+type CalendarBulkMoveCmd struct {
+	calendarBulkSelector
+	TargetCalendar string `name:"target-calendar" required:"" help:"Destination calendar ID"`
+}
+
+func (c *CalendarBulkMoveCmd) Run(ctx context.Context, flags *RootFlags) error {
+	if c.Scope == scopeFuture {
+		return usage("--scope future is not supported for move (use single or all)")
+	}
+	var dest string
+	return c.run(ctx, flags, calendarBulkOp{
+		Name:        "move",
+		Verb:        "move",
+		Destructive: true,
+		Request:     map[string]any{"target_calendar": c.TargetCalendar},
+		Apply: func(ctx context.Context, svc *calendar.Service, calendarID string, t calendarBulkTarget) (string, error) {
+			if dest == "" {
+				resolved, err := resolveCalendarID(ctx, svc, c.TargetCalendar)
+				if err != nil {
+					return "", err
+				}
+				dest = resolved
+			}
+			if !t.Series && t.event != nil && t.event.RecurringEventId != "" {
+				return "", fmt.Errorf("single instances of a recurring event cannot be moved; use --scope all")
+			}
+			call := svc.Events.Move(calendarID, t.EventID, dest).Context(ctx)
+			if su := c.sendUpdates(); su != "" {
+				call = call.SendUpdates(su)
+			}
+			moved, err := call.Do()
+			if err != nil {
+				return "", err
+			}
+			return moved.Id, nil
+		},
+	})
+}
+
+type CalendarBulkCopyCmd struct {
+	calendarBulkSelector
+	TargetCalendar string `name:"target-calendar" help:"Destination calendar ID (default: source calendar)"`
+	KeepAttendees  bool   `name:"keep-attendees" help:"Copy attendees too (sends invitations with --send-updates)"`
+}
+
+func (c *CalendarBulkCopyCmd) Run(ctx context.Context, flags *RootFlags) error {
+	if c.Scope == scopeFuture {
+		return usage("--scope future is not supported for copy (use single or all)")
+	}
+	var dest string
+	return c.run(ctx, flags, calendarBulkOp{
+		Name:    "copy",
+		Verb:    "copy",
+		Request: map[string]any{"target_calendar": c.TargetCalendar, "keep_attendees": c.KeepAttendees},
+		Apply: func(ctx context.Context, svc *calendar.Service, calendarID string, t calendarBulkTarget) (string, error) {
+			if dest == "" {
+				dest = calendarID
+				if strings.TrimSpace(c.TargetCalendar) != "" {
+					resolved, err := resolveCalendarID(ctx, svc, c.TargetCalendar)
+					if err != nil {
+						return "", err
+					}
+					dest = resolved
+				}
+			}
+			src, err := bulkSourceEvent(ctx, svc, calendarID, c.Scope, t)
+			if err != nil {
+				return "", err
+			}
+			call := svc.Events.Insert(dest, copyCalendarEvent(src, t.Series, c.KeepAttendees)).Context(ctx)
+			if su := c.sendUpdates(); su != "" {
+				call = call.SendUpdates(su)
+			}
+			created, err := call.Do()
+			if err != nil {
+				return "", err
+			}
+			return created.Id, nil
+		},
+	})
+}
+
+type CalendarBulkShiftCmd struct {
+	calendarBulkSelector
+	Shift string `name:"shift" aliases:"by" required:"" help:"Offset to apply, e.g. +1h, -30m, +2d (days and weeks keep the local time across DST; all-day events need whole days)"`
+}
+
+func (c *CalendarBulkShiftCmd) Run(ctx context.Context, flags *RootFlags) error {
+	shift, err := parseShiftOffset(c.Shift)
+	if err != nil {
+		return err
+	}
+	var calendarLoc *time.Location
+	return c.run(ctx, flags, calendarBulkOp{
+		Name:        "shift",
+		Verb:        "shift",
+		Destructive: true,
+		Request:     map[string]any{"shift": shift.String()},
+		Apply: func(ctx context.Context, svc *calendar.Service, calendarID string, t calendarBulkTarget) (string, error) {
+			src, err := bulkSourceEvent(ctx, svc, calendarID, c.Scope, t)
+			if err != nil {
+				return "", err
+			}
+			if calendarLoc == nil && shift.Days != 0 {
+				// Day shifts of events without their own zone follow the
+				// calendar's wall clock.
+				if _, calendarLoc, err = getCalendarLocation(ctx, svc, calendarID); err != nil {
+					return "", err
+				}
+			}
+			patch, err := shiftedEventPatch(src, shift, calendarLoc)
+			if err != nil {
+				return "", err
+			}
+			targetID := t.EventID
+			var parentRecurrence []string
+			if c.Scope == scopeFuture && t.Series {
+				// Split the series like `calendar update --scope future`.
+				targetID, parentRecurrence, err = applyUpdateScope(ctx, svc, calendarID, t.EventID, scopeFuture, t.OriginalStart, patch)
+				if err != nil {
+					return "", err
+				}
+			}
+			call := svc.Events.Patch(calendarID, targetID, patch).Context(ctx)
+			if su := c.sendUpdates(); su != "" {
+				call = call.SendUpdates(su)
+			}
+			updated, err := call.Do()
+			if err != nil {
+				return "", err
+			}
+			if parentRecurrence != nil {
+				if err := truncateParentRecurrence(ctx, svc, calendarID, t.EventID, parentRecurrence, t.OriginalStart); err != nil {
+					return "", err
+				}
+			}
+			if updated.Id != t.EventID {
+				return updated.Id, nil
+			}
+			return "", nil
+		},
+	})
+}
+
+type CalendarBulkDeleteCmd struct {
+	calendarBulkSelector
+}
+
+func (c *CalendarBulkDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
+	return c.run(ctx, flags, calendarBulkOp{
+		Name:        "delete",
+		Verb:        "delete",
+		Destructive: true,
+		Apply: func(ctx context.Context, svc *calendar.Service, calendarID string, t calendarBulkTarget) (string, error) {
+			if c.Scope == scopeFuture && t.Series {
+				parent, err := svc.Events.Get(calendarID, t.EventID).Context(ctx).Do()
+				if err != nil {
+					return "", err
+				}
+				// Truncating at the first instance would leave an empty series.
+				if !matchesOriginalStart(parent, t.OriginalStart) {
+					return "", truncateParentRecurrence(ctx, svc, calendarID, t.EventID, parent.Recurrence, t.OriginalStart)
+				}
+			}
+			call := svc.Events.Delete(calendarID, t.EventID).Context(ctx)
+			if su := c.sendUpdates(); su != "" {
+				call = call.SendUpdates(su)
+			}
+			return "", call.Do()
+		},
+	})
+}
+
+func (s *calendarBulkSelector) sendUpdates() string {
+	su, _ := validateSendUpdates(s.SendUpdates)
+	return su
+}
+
+func (s *calendarBulkSelector) hasTimeRange() bool {
+	return strings.TrimSpace(s.From) != "" || strings.TrimSpace(s.To) != "" || s.Today || s.Tomorrow || s.Week || s.Days > 0
+}
+
+func (s *calendarBulkSelector) run(ctx context.Context, flags *RootFlags, op calendarBulkOp) error {
+	u := ui.FromContext(ctx)
+	if !s.hasTimeRange() {
+		return usage("a time range is required (--from/--to, --today, --tomorrow, --week or --days)")
+	}
+	if _, err := validateSendUpdates(s.SendUpdates); err != nil {
+		return usage(err.Error())
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+	calendarID := strings.TrimSpace(s.CalendarID)
+	if calendarID == "" {
+		calendarID = primaryCalendarID
+	}
+	calendarID, err = resolveCalendarID(ctx, svc, calendarID)
+	if err != nil {
+		return err
+	}
+	tr, err := ResolveTimeRange(ctx, svc, s.TimeRangeFlags)
+	if err != nil {
+		return err
+	}
+
+	events, err := s.list(ctx, svc, calendarID, tr)
+	if err != nil {
+		return err
+	}
+	targets := selectCalendarBulkTargets(events, s.Scope)
+	if len(targets) == 0 {
+		if outfmt.IsJSON(ctx) {
+			return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+				"operation":  op.Name,
+				"calendarId": calendarID,
+				"matched":    0,
+				"results":    []calendarBulkResult{},
+			})
+		}
+		u.Err().Println("No matching events")
+		return nil
+	}
+	if s.Max > 0 && len(targets) > s.Max {
+		return usagef("%d events match; refusing to %s more than --max %d", len(targets), op.Verb, s.Max)
+	}
+
+	request := map[string]any{
+		"calendar_id":  calendarID,
+		"scope":        s.Scope,
+		"send_updates": s.sendUpdates(),
+		"events":       targets,
+	}
+	for k, v := range op.Request {
+		request[k] = v
+	}
+	if err := dryRunExit(ctx, flags, "calendar.bulk."+op.Name, request); err != nil {
+		return err
+	}
+	if op.Destructive {
+		if err := confirmDestructive(ctx, flags, fmt.Sprintf("%s %d event(s) in calendar %s", op.Verb, len(targets), calendarID)); err != nil {
+			return err
+		}
+	}
+
+	results := make([]calendarBulkResult, 0, len(targets))
+	failed := 0
+	for _, t := range targets {
+		res := calendarBulkResult{calendarBulkTarget: t}
+		newID, applyErr := op.Apply(ctx, svc, calendarID, t)
+		if applyErr != nil {
+			res.Error = applyErr.Error()
+			failed++
+		}
+		res.NewEventID = newID
+		results = append(results, res)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"operation":  op.Name,
+			"calendarId": calendarID,
+			"matched":    len(targets),
+			"succeeded":  len(targets) - failed,
+			"failed":     failed,
+			"results":    results,
+		}); err != nil {
+			return err
+		}
+	} else {
+		w, flush := tableWriter(ctx)
+		fmt.Fprintln(w, "ID\tSTART\tSUMMARY\tRESULT")
+		for _, r := range results {
+			result := "ok"
+			switch {
+			case r.Error != "":
+				result = "error: " + r.Error
+			case r.NewEventID != "":
+				result = "ok -> " + r.NewEventID
+			}
+			id := r.EventID
+			if r.Series {
+				id += " (series)"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", id, r.Start, sanitizeTab(r.Summary), sanitizeTab(result))
+		}
+		flush()
+		u.Err().Printf("%s: %d succeeded, %d failed", op.Verb, len(targets)-failed, failed)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d events failed", failed, len(targets))
+	}
+	return nil
+}
+
+func (s *calendarBulkSelector) list(ctx context.Context, svc *calendar.Service, calendarID string, tr *TimeRange) ([]*calendar.Event, error) {
+	from, to := tr.FormatRFC3339()
+	return collectAllPages("", func(pageToken string) ([]*calendar.Event, string, error) {
+		call := svc.Events.List(calendarID).
+			TimeMin(from).
+			TimeMax(to).
+			MaxResults(250).
+			SingleEvents(true).
+			OrderBy("startTime")
+		if strings.TrimSpace(pageToken) != "" {
+			call = call.PageToken(pageToken)
+		}
+		if strings.TrimSpace(s.Query) != "" {
+			call = call.Q(s.Query)
+		}
+		if strings.TrimSpace(s.PrivatePropFilter) != "" {
+			call = call.PrivateExtendedProperty(s.PrivatePropFilter)
+		}
+		if strings.TrimSpace(s.SharedPropFilter) != "" {
+			call = call.SharedExtendedProperty(s.SharedPropFilter)
+		}
+		resp, err := call.Context(ctx).Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Items, resp.NextPageToken, nil
+	})
+}
+
+// selectCalendarBulkTargets turns listed instances into operation targets.
+// Events arrive ordered by start, so a series' OriginalStart is its first
+// matched instance.
+func selectCalendarBulkTargets(events []*calendar.Event, scope string) []calendarBulkTarget {
+	var out []calendarBulkTarget
+	seenSeries := map[string]bool{}
+	for _, ev := range events {
+		if ev == nil || ev.Status == "cancelled" {
+			continue
+		}
+		t := calendarBulkTarget{EventID: ev.Id, Summary: ev.Summary, event: ev}
+		if ev.Start != nil {
+			t.Start = firstNonEmpty(ev.Start.DateTime, ev.Start.Date)
+		}
+		if ev.RecurringEventId != "" && scope != scopeSingle {
+			if seenSeries[ev.RecurringEventId] {
+				continue
+			}
+			seenSeries[ev.RecurringEventId] = true
+			t.EventID = ev.RecurringEventId
+			t.Series = true
+			if ev.OriginalStartTime != nil {
+				t.OriginalStart = firstNonEmpty(ev.OriginalStartTime.DateTime, ev.OriginalStartTime.Date)
+			}
+		}
+		out = append(out, t)
+	}
+	return out
+}
+
+// bulkSourceEvent returns the event to copy or shift: the listed instance, or
+// the series parent when the whole series is targeted. --scope future works
+// from the first matched instance, so it uses the instance too.
+func bulkSourceEvent(ctx context.Context, svc *calendar.Service, calendarID, scope string, t calendarBulkTarget) (*calendar.Event, error) {
+	if t.event != nil && (!t.Series || scope == scopeFuture) {
+		return t.event, nil
+	}
+	return svc.Events.Get(calendarID, t.EventID).Context(ctx).Do()
+}
+
+func copyCalendarEvent(src *calendar.Event, series, keepAttendees bool) *calendar.Event {
+	ev := &calendar.Event{
+		Summary:            src.Summary,
+		Description:        src.Description,
+		Location:           src.Location,
+		Start:              src.Start,
+		End:                src.End,
+		ColorId:            src.ColorId,
+		Transparency:       src.Transparency,
+		Visibility:         src.Visibility,
+		Reminders:          src.Reminders,
+		ExtendedProperties: src.ExtendedProperties,
+	}
+	if series {
+		ev.Recurrence = src.Recurrence
+	}
+	if keepAttendees {
+		ev.Attendees = src.Attendees
+	}
+	return ev
+}
+
+// calendarShift is a --shift offset. Days and weeks stay calendar units so
+// timed events keep their wall-clock time across DST changes.
+type calendarShift struct {
+	Days     int
+	Duration time.Duration
+}
+
+func (s calendarShift) String() string {
+	if s.Days != 0 {
+		return fmt.Sprintf("%+dd", s.Days)
+	}
+	return s.Duration.String()
+}
+
+func shiftedEventPatch(src *calendar.Event, shift calendarShift, calendarLoc *time.Location) (*calendar.Event, error) {
+	start, err := offsetEventDateTime(src.Start, shift, calendarLoc)
+	if err != nil {
+		return nil, err
+	}
+	end, err := offsetEventDateTime(src.End, shift, calendarLoc)
+	if err != nil {
+		return nil, err
+	}
+	return &calendar.Event{Start: start, End: end}, nil
+}
+
+// offsetEventDateTime applies shift to a start or end. Days are added in the
+// event's own time zone, falling back to calendarLoc, then to the offset the
+// time was written with.
+func offsetEventDateTime(dt *calendar.EventDateTime, shift calendarShift, calendarLoc *time.Location) (*calendar.EventDateTime, error) {
+	if dt == nil {
+		return nil, fmt.Errorf("event has no start/end")
+	}
+	if dt.Date != "" {
+		if shift.Duration%(24*time.Hour) != 0 {
+			return nil, fmt.Errorf("all-day events can only be shifted by whole days")
+		}
+		d, err := time.Parse("2006-01-02", dt.Date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q", dt.Date)
+		}
+		days := shift.Days + int(shift.Duration/(24*time.Hour))
+		return &calendar.EventDateTime{Date: d.AddDate(0, 0, days).Format("2006-01-02")}, nil
+	}
+	t, err := time.Parse(time.RFC3339, dt.DateTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time %q", dt.DateTime)
+	}
+	if shift.Days != 0 {
+		loc := calendarLoc
+		if dt.TimeZone != "" {
+			if eventLoc, locErr := time.LoadLocation(dt.TimeZone); locErr == nil {
+				loc = eventLoc
+			}
+		}
+		if loc != nil {
+			t = t.In(loc)
+		}
+		t = t.AddDate(0, 0, shift.Days)
+	}
+	return &calendar.EventDateTime{DateTime: t.Add(shift.Duration).Format(time.RFC3339), TimeZone: dt.TimeZone}, nil
+}
+
+// parseShiftOffset accepts a signed Go duration or a whole number of days or
+// weeks (2d, 1w).
+func parseShiftOffset(expr string) (calendarShift, error) {
+	raw := strings.TrimSpace(expr)
+	expr = strings.ToLower(raw)
+	sign := 1
+	switch {
+	case strings.HasPrefix(expr, "-"):
+		sign = -1
+		expr = expr[1:]
+	case strings.HasPrefix(expr, "+"):
+		expr = expr[1:]
+	}
+	if strings.HasSuffix(expr, "d") || strings.HasSuffix(expr, "w") {
+		n, err := strconv.Atoi(expr[:len(expr)-1])
+		if err != nil || n <= 0 {
+			return calendarShift{}, usagef("invalid --shift %q (e.g. +1h, -30m, +2d)", raw)
+		}
+		if strings.HasSuffix(expr, "w") {
+			n *= 7
+		}
+		return calendarShift{Days: sign * n}, nil
+	}
+	d, err := time.ParseDuration(expr)
+	if err != nil || d <= 0 {
+		return calendarShift{}, usagef("invalid --shift %q (e.g. +1h, -30m, +2d)", raw)
+	}
+	return calendarShift{Duration: time.Duration(sign) * d}, nil
+}
@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/option"
+)
+
+func TestSelectCalendarBulkTargets(t *testing.T) {
+	events := []*calendar.Event{
+		{Id: "one", Summary: "One-off", Start: &calendar.EventDateTime{DateTime: "2026-01-05T09:00:00Z"}},
+		{Id: "s_1", RecurringEventId: "s", Start: &calendar.EventDateTime{DateTime: "2026-01-06T09:00:00Z"}, OriginalStartTime: &calendar.EventDateTime{DateTime: "2026-01-06T09:00:00Z"}},
+		{Id: "s_2", RecurringEventId: "s", Start: &calendar.EventDateTime{DateTime: "2026-01-07T09:00:00Z"}, OriginalStartTime: &calendar.EventDateTime{DateTime: "2026-01-07T09:00:00Z"}},
+		{Id: "gone", Status: "cancelled"},
+	}
+	if got := selectCalendarBulkTargets(events, scopeSingle); len(got) != 3 || got[1].EventID != "s_1" || got[1].Series {
+		t.Fatalf("unexpected single targets: %#v", got)
+	}
+	got := selectCalendarBulkTargets(events, scopeFuture)
+	if len(got) != 2 || got[1].EventID != "s" || !got[1].Series || got[1].OriginalStart != "2026-01-06T09:00:00Z" {
+		t.Fatalf("unexpected series targets: %#v", got)
+	}
+}
+
+func TestParseShiftOffsetAndOffsetEventDateTime(t *testing.T) {
+	for in, want := range map[string]calendarShift{
+		"+1h":  {Duration: time.Hour},
+		"-30m": {Duration: -30 * time.Minute},
+		"2d":   {Days: 2},
+		"-1w":  {Days: -7},
+	} {
+		got, err := parseShiftOffset(in)
+		if err != nil || got != want {
+			t.Fatalf("parseShiftOffset(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"soon", "0d", "-0h"} {
+		if _, err := parseShiftOffset(in); err == nil {
+			t.Fatalf("expected error for invalid offset %q", in)
+		}
+	}
+
+	dt, err := offsetEventDateTime(&calendar.EventDateTime{DateTime: "2026-01-05T09:00:00+01:00", TimeZone: "Europe/Berlin"}, calendarShift{Duration: -90 * time.Minute}, nil)
+	if err != nil || dt.DateTime != "2026-01-05T07:30:00+01:00" || dt.TimeZone != "Europe/Berlin" {
+		t.Fatalf("unexpected shifted time: %#v %v", dt, err)
+	}
+	dt, err = offsetEventDateTime(&calendar.EventDateTime{Date: "2026-01-30"}, calendarShift{Days: 2}, nil)
+	if err != nil || dt.Date != "2026-02-01" {
+		t.Fatalf("unexpected shifted date: %#v %v", dt, err)
+	}
+	if _, err := offsetEventDateTime(&calendar.EventDateTime{Date: "2026-01-30"}, calendarShift{Duration: time.Hour}, nil); err == nil {
+		t.Fatalf("expected error shifting all-day event by hours")
+	}
+}
+
+func TestOffsetEventDateTime_AcrossDST(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("tzdata: %v", err)
+	}
+	// Clocks in Berlin go forward on 2026-03-29; a 09:00 meeting moved two days
+	// must stay at 09:00 local.
+	for _, tc := range []struct {
+		name string
+		dt   *calendar.EventDateTime
+		loc  *time.Location
+	}{
+		{"event zone", &calendar.EventDateTime{DateTime: "2026-03-27T08:00:00Z", TimeZone: "Europe/Berlin"}, nil},
+		{"calendar zone", &calendar.EventDateTime{DateTime: "2026-03-27T09:00:00+01:00"}, berlin},
+	} {
+		dt, err := offsetEventDateTime(tc.dt, calendarShift{Days: 2}, tc.loc)
+		if err != nil || dt.DateTime != "2026-03-29T09:00:00+02:00" {
+			t.Fatalf("%s: unexpected shifted time: %#v %v", tc.name, dt, err)
+		}
+	}
+	dt, err := offsetEventDateTime(&calendar.EventDateTime{DateTime: "2026-03-27T09:00:00+01:00", TimeZone: "Europe/Berlin"}, calendarShift{Duration: 48 * time.Hour}, nil)
+	if err != nil || dt.DateTime != "2026-03-29T09:00:00+01:00" {
+		t.Fatalf("hour shifts stay elapsed time: %#v %v", dt, err)
+	}
+}
+
+type bulkTestCall struct {
+	Method string
+	Path   string
+	Query  string
+	Body   map[string]any
+}
+
+func calendarBulkTestService(t *testing.T) (*calendar.Service, func() []bulkTestCall) {
+	t.Helper()
+	var mu sync.Mutex
+	var calls []bulkTestCall
+	srv := httptest.NewServer(withPrimaryCalendar(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/calendars/primary/events") {
+			if r.URL.Query().Get("q") != "sprint" || r.URL.Query().Get("singleEvents") != "true" {
+				t.Errorf("unexpected list query: %s", r.URL.RawQuery)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"items": []map[string]any{
+					{
+						"id":      "e1",
+						"summary": "Sprint review",
+						"start":   map[string]any{"dateTime": "2026-01-05T09:00:00Z"},
+						"end":     map[string]any{"dateTime": "2026-01-05T10:00:00Z"},
+					},
+					{
+						"id":                "s_20260106",
+						"recurringEventId":  "s",
+						"summary":           "Sprint standup",
+						"start":             map[string]any{"dateTime": "2026-01-06T09:00:00Z"},
+						"end":               map[string]any{"dateTime": "2026-01-06T09:15:00Z"},
+						"originalStartTime": map[string]any{"dateTime": "2026-01-06T09:00:00Z"},
+					},
+				},
+			})
+			return
+		}
+		call := bulkTestCall{Method: r.Method, Path: strings.TrimPrefix(r.URL.Path, "/calendars/primary/events/"), Query: r.URL.RawQuery}
+		_ = json.NewDecoder(r.Body).Decode(&call.Body)
+		mu.Lock()
+		calls = append(calls, call)
+		mu.Unlock()
+		switch r.Method {
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"id":         "s",
+				"start":      map[string]any{"dateTime": "2026-01-01T09:00:00Z"},
+				"end":        map[string]any{"dateTime": "2026-01-01T09:15:00Z"},
+				"recurrence": []string{"RRULE:FREQ=DAILY"},
+			})
+		default:
+			id := strings.TrimSuffix(call.Path, "/move")
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": id})
+		}
+	})))
+	t.Cleanup(srv.Close)
+
+	svc, err := calendar.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	return svc, func() []bulkTestCall {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]bulkTestCall(nil), calls...)
+	}
+}
+
+func TestCalendarBulkShift_Instances(t *testing.T) {
+	origNew := newCalendarService
+	t.Cleanup(func() { newCalendarService = origNew })
+	svc, calls := calendarBulkTestService(t)
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return svc, nil }
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--force", "--account", "a@b.com", "calendar", "bulk", "shift",
+			"--query", "sprint", "--from", "2026-01-05", "--to", "2026-01-09", "--shift", "+1h"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	var parsed struct {
+		Matched   int `json:"matched"`
+		Succeeded int `json:"succeeded"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if parsed.Matched != 2 || parsed.Succeeded != 2 {
+		t.Fatalf("unexpected summary: %s", out)
+	}
+	got := calls()
+	if len(got) != 2 || got[0].Method != http.MethodPatch || got[1].Path != "s_20260106" {
+		t.Fatalf("unexpected calls: %#v", got)
+	}
+	start, _ := got[0].Body["start"].(map[string]any)
+	if start["dateTime"] != "2026-01-05T10:00:00Z" {
+		t.Fatalf("unexpected patch body: %#v", got[0].Body)
+	}
+}
+
+func TestCalendarBulkMoveAndDelete_Series(t *testing.T) {
+	origNew := newCalendarService
+	t.Cleanup(func() { newCalendarService = origNew })
+	svc, calls := calendarBulkTestService(t)
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return svc, nil }
+
+	_ = captureStdout(t, func() {
+		_ = captureStderr(t, func() {
+			if err := Execute([]string{"--force", "--account", "a@b.com", "calendar", "bulk", "move",
+				"--query", "sprint", "--from", "2026-01-05", "--to", "2026-01-09",
+				"--scope", "all", "--target-calendar", "team@group.calendar.google.com"}); err != nil {
+				t.Fatalf("move: %v", err)
+			}
+		})
+	})
+	got := calls()
+	if len(got) != 2 || got[1].Path != "s/move" || !strings.Contains(got[1].Query, "destination=team%40group.calendar.google.com") {
+		t.Fatalf("unexpected move calls: %#v", got)
+	}
+
+	_ = captureStdout(t, func() {
+		_ = captureStderr(t, func() {
+			if err := Execute([]string{"--force", "--account", "a@b.com", "calendar", "bulk", "delete",
+				"--query", "sprint", "--from", "2026-01-05", "--to", "2026-01-09", "--scope", "future"}); err != nil {
+				t.Fatalf("delete: %v", err)
+			}
+		})
+	})
+	got = calls()[2:]
+	if len(got) != 3 || got[0].Method != http.MethodDelete || got[0].Path != "e1" || got[2].Method != http.MethodPatch {
+		t.Fatalf("unexpected delete calls: %#v", got)
+	}
+	if rec, _ := got[2].Body["recurrence"].([]any); len(rec) != 1 || rec[0] != "RRULE:FREQ=DAILY;UNTIL=20260106T085959Z" {
+		t.Fatalf("series should be truncated before the first match: %#v", got[2].Body)
+	}
+}
+
+func TestCalendarBulkDelete_RequiresRange(t *testing.T) {
+	var err error
+	_ = captureStderr(t, func() {
+		err = Execute([]string{"--force", "--account", "a@b.com", "calendar", "bulk", "delete", "--query", "x"})
+	})
+	if err == nil || !strings.Contains(err.Error(), "time range is required") {
+		t.Fatalf("expected range error, got %v", err)
+	}
+}
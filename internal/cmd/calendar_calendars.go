@@ -0,0 +1,499 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/kong"
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+var calendarHexColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+type CalendarCalendarsCmd struct {
+	List        CalendarCalendarsListCmd        `cmd:"" default:"withargs" help:"List calendars"`
+	Create      CalendarCalendarsCreateCmd      `cmd:"" aliases:"add,new" help:"Create a secondary calendar"`
+	Update      CalendarCalendarsUpdateCmd      `cmd:"" aliases:"edit,set" help:"Update calendar metadata and your list settings"`
+	Delete      CalendarCalendarsDeleteCmd      `cmd:"" aliases:"rm,del,remove" help:"Delete a secondary calendar"`
+	Clear       CalendarCalendarsClearCmd       `cmd:"" help:"Delete all events from a calendar (primary only)"`
+	Subscribe   CalendarCalendarsSubscribeCmd   `cmd:"" aliases:"sub" help:"Add an existing calendar to your calendar list"`
+	Unsubscribe CalendarCalendarsUnsubscribeCmd `cmd:"" aliases:"unsub" help:"Remove a calendar from your calendar list"`
+	Hide        CalendarCalendarsHideCmd        `cmd:"" help:"Hide a calendar in the Google Calendar UI"`
+	Show        CalendarCalendarsShowCmd        `cmd:"" aliases:"unhide" help:"Show a hidden calendar in the Google Calendar UI"`
+}
+
+// calendarListSettings are per-user settings stored on the calendarList
+// entry rather than on the calendar itself.
+type calendarListSettings struct {
+	Color      string   `name:"calendar-color" help:"Calendar color ID (see 'calendar colors') or #rrggbb"`
+	Foreground string   `name:"calendar-foreground" help:"Text color (#rrggbb) when --calendar-color is hex"`
+	Reminders  []string `name:"reminder" help:"Default reminders as method:duration (e.g., popup:10m, email:1d). Can be repeated (max 5). Set empty to clear."`
+}
+
+// apply fills entry from the flags and reports whether anything changed and
+// whether hex colors require colorRgbFormat.
+func (s calendarListSettings) apply(kctx *kong.Context, entry *calendar.CalendarListEntry) (bool, bool, error) {
+	changed, rgb := false, false
+	color := strings.TrimSpace(s.Color)
+	fg := strings.TrimSpace(s.Foreground)
+	if fg != "" && !calendarHexColorPattern.MatchString(color) {
+		return false, false, usage("--calendar-foreground needs a hex --calendar-color (#rrggbb)")
+	}
+	if color != "" {
+		switch {
+		case calendarHexColorPattern.MatchString(color):
+			entry.BackgroundColor = strings.ToLower(color)
+			entry.ForegroundColor = "#000000"
+			if fg != "" {
+				if !calendarHexColorPattern.MatchString(fg) {
+					return false, false, usagef("invalid --calendar-foreground %q (expected #rrggbb)", fg)
+				}
+				entry.ForegroundColor = strings.ToLower(fg)
+			}
+			rgb = true
+		case isDigits(color):
+			entry.ColorId = color
+		default:
+			return false, false, usagef("invalid --calendar-color %q (expected color ID or #rrggbb)", color)
+		}
+		changed = true
+	}
+	if flagProvided(kctx, "reminder") {
+		entry.DefaultReminders = []*calendar.EventReminder{}
+		for _, r := range s.Reminders {
+			if strings.TrimSpace(r) == "" {
+				continue
+			}
+			method, minutes, err := parseReminder(r)
+			if err != nil {
+				return false, false, usage(err.Error())
+			}
+			entry.DefaultReminders = append(entry.DefaultReminders, &calendar.EventReminder{Method: method, Minutes: minutes})
+		}
+		if len(entry.DefaultReminders) > 5 {
+			return false, false, usagef("maximum 5 reminders allowed (got %d)", len(entry.DefaultReminders))
+		}
+		entry.ForceSendFields = append(entry.ForceSendFields, "DefaultReminders")
+		changed = true
+	}
+	return changed, rgb, nil
+}
+
+type CalendarCalendarsCreateCmd struct {
+	Summary     string `arg:"" name:"summary" help:"Calendar name"`
+	Description string `name:"description" help:"Calendar description"`
+	Location    string `name:"location" help:"Geographic location"`
+	Timezone    string `name:"timezone" help:"IANA timezone (default: your primary calendar's)"`
+	calendarListSettings
+}
+
+func (c *CalendarCalendarsCreateCmd) Run(ctx context.Context, kctx *kong.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	summary := strings.TrimSpace(c.Summary)
+	if summary == "" {
+		return usage("empty summary")
+	}
+	tz := strings.TrimSpace(c.Timezone)
+	if tz != "" {
+		if _, err := time.LoadLocation(tz); err != nil {
+			return usagef("invalid --timezone %q", tz)
+		}
+	}
+	cal := &calendar.Calendar{
+		Summary:     summary,
+		Description: strings.TrimSpace(c.Description),
+		Location:    strings.TrimSpace(c.Location),
+		TimeZone:    tz,
+	}
+	entry := &calendar.CalendarListEntry{}
+	entryChanged, rgb, err := c.apply(kctx, entry)
+	if err != nil {
+		return err
+	}
+
+	if err := dryRunExit(ctx, flags, "calendar.calendars.create", map[string]any{
+		"calendar":   cal,
+		"list_entry": entry,
+	}); err != nil {
+		return err
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	created, err := svc.Calendars.Insert(cal).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	var listEntry *calendar.CalendarListEntry
+	if entryChanged {
+		listEntry, err = patchCalendarListEntry(ctx, svc, created.Id, entry, rgb)
+		if err != nil {
+			return fmt.Errorf("calendar %s created, but applying list settings failed: %w", created.Id, err)
+		}
+	}
+	return writeCalendarResult(ctx, u, created, listEntry)
+}
+
+type CalendarCalendarsUpdateCmd struct {
+	CalendarID  string `arg:"" name:"calendarId" help:"Calendar ID or name"`
+	Summary     string `name:"summary" help:"Calendar name"`
+	Description string `name:"description" help:"Calendar description (set empty to clear)"`
+	Location    string `name:"location" help:"Geographic location (set empty to clear)"`
+	Timezone    string `name:"timezone" help:"IANA timezone"`
+	calendarListSettings
+}
+
+func (c *CalendarCalendarsUpdateCmd) Run(ctx context.Context, kctx *kong.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	if strings.TrimSpace(c.CalendarID) == "" {
+		return usage("empty calendarId")
+	}
+
+	patch := &calendar.Calendar{}
+	calChanged := false
+	if v := strings.TrimSpace(c.Summary); v != "" {
+		patch.Summary = v
+		calChanged = true
+	}
+	if flagProvided(kctx, "description") {
+		patch.Description = strings.TrimSpace(c.Description)
+		patch.ForceSendFields = append(patch.ForceSendFields, "Description")
+		calChanged = true
+	}
+	if flagProvided(kctx, "location") {
+		patch.Location = strings.TrimSpace(c.Location)
+		patch.ForceSendFields = append(patch.ForceSendFields, "Location")
+		calChanged = true
+	}
+	if v := strings.TrimSpace(c.Timezone); v != "" {
+		if _, err := time.LoadLocation(v); err != nil {
+			return usagef("invalid --timezone %q", v)
+		}
+		patch.TimeZone = v
+		calChanged = true
+	}
+	entry := &calendar.CalendarListEntry{}
+	entryChanged, rgb, err := c.apply(kctx, entry)
+	if err != nil {
+		return err
+	}
+	if !calChanged && !entryChanged {
+		return usage("no updates provided")
+	}
+
+	if err := dryRunExit(ctx, flags, "calendar.calendars.update", map[string]any{
+		"calendar_id": c.CalendarID,
+		"calendar":    patch,
+		"list_entry":  entry,
+	}); err != nil {
+		return err
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+	calendarID, err := resolveCalendarID(ctx, svc, c.CalendarID)
+	if err != nil {
+		return err
+	}
+
+	var updated *calendar.Calendar
+	if calChanged {
+		updated, err = svc.Calendars.Patch(calendarID, patch).Context(ctx).Do()
+	} else {
+		updated, err = svc.Calendars.Get(calendarID).Context(ctx).Do()
+	}
+	if err != nil {
+		return err
+	}
+	var listEntry *calendar.CalendarListEntry
+	if entryChanged {
+		listEntry, err = patchCalendarListEntry(ctx, svc, calendarID, entry, rgb)
+		if err != nil {
+			return err
+		}
+	}
+	return writeCalendarResult(ctx, u, updated, listEntry)
+}
+
+type CalendarCalendarsDeleteCmd struct {
+	CalendarID string `arg:"" name:"calendarId" help:"Calendar ID or name"`
+}
+
+func (c *CalendarCalendarsDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
+	calendarID := strings.TrimSpace(c.CalendarID)
+	if calendarID == "" {
+		return usage("empty calendarId")
+	}
+	if strings.EqualFold(calendarID, primaryCalendarID) {
+		return usage("the primary calendar cannot be deleted (use 'calendar calendars clear primary')")
+	}
+	return runCalendarLifecycle(ctx, flags, calendarID, "delete", "deleted", true, func(ctx context.Context, svc *calendar.Service, id string) error {
+		return svc.Calendars.Delete(id).Context(ctx).Do()
+	})
+}
+
+type CalendarCalendarsClearCmd struct {
+	CalendarID string `arg:"" name:"calendarId" optional:"" help:"Calendar ID (default: primary)"`
+}
+
+func (c *CalendarCalendarsClearCmd) Run(ctx context.Context, flags *RootFlags) error {
+	calendarID := strings.TrimSpace(c.CalendarID)
+	if calendarID == "" {
+		calendarID = primaryCalendarID
+	}
+	// Calendars.Clear only works on the primary calendar, whose ID is also the
+	// account's email; refuse anything else before the confirmation prompt.
+	if !strings.EqualFold(calendarID, primaryCalendarID) {
+		account, err := requireAccount(flags)
+		if err != nil {
+			return err
+		}
+		if !strings.EqualFold(calendarID, account) {
+			return usagef("only the primary calendar can be cleared (got %q); use 'calendar bulk delete' or 'calendar calendars delete' for other calendars", calendarID)
+		}
+	}
+	return runCalendarLifecycle(ctx, flags, calendarID, "clear all events from", "cleared", true, func(ctx context.Context, svc *calendar.Service, id string) error {
+		return svc.Calendars.Clear(id).Context(ctx).Do()
+	})
+}
+
+type CalendarCalendarsSubscribeCmd struct {
+	CalendarID string `arg:"" name:"calendarId" help:"Calendar ID to subscribe to (e.g. en.usa#holiday@group.v.calendar.google.com)"`
+	Hidden     bool   `name:"hidden" help:"Subscribe without showing it in the UI"`
+	calendarListSettings
+}
+
+func (c *CalendarCalendarsSubscribeCmd) Run(ctx context.Context, kctx *kong.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	calendarID := strings.TrimSpace(c.CalendarID)
+	if calendarID == "" {
+		return usage("empty calendarId")
+	}
+	entry := &calendar.CalendarListEntry{Id: calendarID, Hidden: c.Hidden}
+	_, rgb, err := c.apply(kctx, entry)
+	if err != nil {
+		return err
+	}
+
+	if err := dryRunExit(ctx, flags, "calendar.calendars.subscribe", map[string]any{"list_entry": entry}); err != nil {
+		return err
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+	call := svc.CalendarList.Insert(entry).Context(ctx)
+	if rgb {
+		call = call.ColorRgbFormat(true)
+	}
+	inserted, err := call.Do()
+	if err != nil {
+		return err
+	}
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"calendar": inserted})
+	}
+	printCalendarListEntry(u, inserted)
+	return nil
+}
+
+type CalendarCalendarsUnsubscribeCmd struct {
+	CalendarID string `arg:"" name:"calendarId" help:"Calendar ID or name"`
+}
+
+func (c *CalendarCalendarsUnsubscribeCmd) Run(ctx context.Context, flags *RootFlags) error {
+	calendarID := strings.TrimSpace(c.CalendarID)
+	if calendarID == "" {
+		return usage("empty calendarId")
+	}
+	if strings.EqualFold(calendarID, primaryCalendarID) {
+		return usage("cannot unsubscribe from the primary calendar")
+	}
+	return runCalendarLifecycle(ctx, flags, calendarID, "unsubscribe from", "unsubscribed", false, func(ctx context.Context, svc *calendar.Service, id string) error {
+		return svc.CalendarList.Delete(id).Context(ctx).Do()
+	})
+}
+
+type CalendarCalendarsHideCmd struct {
+	CalendarID string `arg:"" name:"calendarId" help:"Calendar ID or name"`
+}
+
+func (c *CalendarCalendarsHideCmd) Run(ctx context.Context, flags *RootFlags) error {
+	return setCalendarHidden(ctx, flags, c.CalendarID, true)
+}
+
+type CalendarCalendarsShowCmd struct {
+	CalendarID string `arg:"" name:"calendarId" help:"Calendar ID or name"`
+}
+
+func (c *CalendarCalendarsShowCmd) Run(ctx context.Context, flags *RootFlags) error {
+	return setCalendarHidden(ctx, flags, c.CalendarID, false)
+}
+
+func setCalendarHidden(ctx context.Context, flags *RootFlags, calendarID string, hidden bool) error {
+	u := ui.FromContext(ctx)
+	calendarID = strings.TrimSpace(calendarID)
+	if calendarID == "" {
+		return usage("empty calendarId")
+	}
+	op := "calendar.calendars.show"
+	if hidden {
+		op = "calendar.calendars.hide"
+	}
+	if err := dryRunExit(ctx, flags, op, map[string]any{"calendar_id": calendarID, "hidden": hidden}); err != nil {
+		return err
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+	calendarID, err = resolveCalendarID(ctx, svc, calendarID)
+	if err != nil {
+		return err
+	}
+	// Selected tracks Hidden in the UI; showing a calendar should also
+	// display its events again.
+	patch := &calendar.CalendarListEntry{Hidden: hidden, Selected: !hidden, ForceSendFields: []string{"Hidden", "Selected"}}
+	entry, err := svc.CalendarList.Patch(calendarID, patch).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"calendar": entry})
+	}
+	printCalendarListEntry(u, entry)
+	return nil
+}
+
+// runCalendarLifecycle handles the shared confirm/resolve/report flow for
+// commands that act on a whole calendar.
+func runCalendarLifecycle(ctx context.Context, flags *RootFlags, calendarID, action, resultKey string, destructive bool, do func(context.Context, *calendar.Service, string) error) error {
+	u := ui.FromContext(ctx)
+	confirmMessage := fmt.Sprintf("%s calendar %s", action, calendarID)
+	if destructive {
+		if err := confirmDestructive(ctx, flags, confirmMessage); err != nil {
+			return err
+		}
+	} else if err := dryRunExit(ctx, flags, "calendar.calendars."+strings.Fields(action)[0], map[string]any{"calendar_id": calendarID}); err != nil {
+		return err
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+	calendarID, err = resolveCalendarID(ctx, svc, calendarID)
+	if err != nil {
+		return err
+	}
+	if err := do(ctx, svc, calendarID); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			resultKey:    true,
+			"calendarId": calendarID,
+		})
+	}
+	u.Out().Printf("%s\ttrue", resultKey)
+	u.Out().Printf("calendarId\t%s", calendarID)
+	return nil
+}
+
+func patchCalendarListEntry(ctx context.Context, svc *calendar.Service, calendarID string, entry *calendar.CalendarListEntry, rgb bool) (*calendar.CalendarListEntry, error) {
+	call := svc.CalendarList.Patch(calendarID, entry).Context(ctx)
+	if rgb {
+		call = call.ColorRgbFormat(true)
+	}
+	return call.Do()
+}
+
+func writeCalendarResult(ctx context.Context, u *ui.UI, cal *calendar.Calendar, entry *calendar.CalendarListEntry) error {
+	if outfmt.IsJSON(ctx) {
+		out := map[string]any{"calendar": cal}
+		if entry != nil {
+			out["listEntry"] = entry
+		}
+		return outfmt.WriteJSON(ctx, os.Stdout, out)
+	}
+	u.Out().Printf("id\t%s", cal.Id)
+	u.Out().Printf("summary\t%s", cal.Summary)
+	if cal.Description != "" {
+		u.Out().Printf("description\t%s", cal.Description)
+	}
+	if cal.TimeZone != "" {
+		u.Out().Printf("timezone\t%s", cal.TimeZone)
+	}
+	if entry != nil {
+		printCalendarListSettings(u, entry)
+	}
+	return nil
+}
+
+func printCalendarListEntry(u *ui.UI, entry *calendar.CalendarListEntry) {
+	u.Out().Printf("id\t%s", entry.Id)
+	u.Out().Printf("summary\t%s", entry.Summary)
+	u.Out().Printf("role\t%s", entry.AccessRole)
+	u.Out().Printf("hidden\t%t", entry.Hidden)
+	printCalendarListSettings(u, entry)
+}
+
+func printCalendarListSettings(u *ui.UI, entry *calendar.CalendarListEntry) {
+	if entry.BackgroundColor != "" {
+		u.Out().Printf("color\t%s", entry.BackgroundColor)
+	}
+	if len(entry.DefaultReminders) > 0 {
+		reminders := make([]string, 0, len(entry.DefaultReminders))
+		for _, r := range entry.DefaultReminders {
+			reminders = append(reminders, fmt.Sprintf("%s:%dm", r.Method, r.Minutes))
+		}
+		u.Out().Printf("reminders\t%s", strings.Join(reminders, ","))
+	}
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/option"
+)
+
+type calendarsTestRequest struct {
+	Method string
+	Path   string
+	Query  string
+	Body   map[string]any
+}
+
+func calendarsTestService(t *testing.T) (*calendar.Service, *[]calendarsTestRequest) {
+	t.Helper()
+	var reqs []calendarsTestRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := calendarsTestRequest{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery}
+		_ = json.NewDecoder(r.Body).Decode(&req.Body)
+		reqs = append(reqs, req)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodDelete || strings.HasSuffix(r.URL.Path, "/clear"):
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPost && r.URL.Path == "/calendars":
+			body := req.Body
+			body["id"] = "proj@group.calendar.google.com"
+			_ = json.NewEncoder(w).Encode(body)
+		default:
+			body := req.Body
+			if body == nil {
+				body = map[string]any{}
+			}
+			if _, ok := body["id"]; !ok {
+				body["id"] = r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+			}
+			_ = json.NewEncoder(w).Encode(body)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	svc, err := calendar.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	return svc, &reqs
+}
+
+func TestCalendarCalendarsCreate_WithListSettings(t *testing.T) {
+	origNew := newCalendarService
+	t.Cleanup(func() { newCalendarService = origNew })
+	svc, reqs := calendarsTestService(t)
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return svc, nil }
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "calendar", "calendars", "create", "Project X",
+			"--timezone", "Europe/Berlin", "--calendar-color", "#AABBCC", "--reminder", "popup:10m"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	if len(*reqs) != 2 {
+		t.Fatalf("expected insert + list patch, got %#v", *reqs)
+	}
+	insert, patch := (*reqs)[0], (*reqs)[1]
+	if insert.Body["summary"] != "Project X" || insert.Body["timeZone"] != "Europe/Berlin" {
+		t.Fatalf("unexpected insert: %#v", insert.Body)
+	}
+	if patch.Method != http.MethodPatch || !strings.HasSuffix(patch.Path, "/users/me/calendarList/proj@group.calendar.google.com") ||
+		!strings.Contains(patch.Query, "colorRgbFormat=true") || patch.Body["backgroundColor"] != "#aabbcc" {
+		t.Fatalf("unexpected list patch: %#v", patch)
+	}
+	if rem, _ := patch.Body["defaultReminders"].([]any); len(rem) != 1 {
+		t.Fatalf("unexpected reminders: %#v", patch.Body)
+	}
+	if !strings.Contains(out, `"listEntry"`) {
+		t.Fatalf("expected list entry in output: %s", out)
+	}
+}
+
+func TestCalendarCalendars_HideSubscribeDelete(t *testing.T) {
+	origNew := newCalendarService
+	t.Cleanup(func() { newCalendarService = origNew })
+	svc, reqs := calendarsTestService(t)
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return svc, nil }
+
+	run := func(args ...string) {
+		t.Helper()
+		_ = captureStdout(t, func() {
+			if err := Execute(append([]string{"--force", "--account", "a@b.com", "calendar", "calendars"}, args...)); err != nil {
+				t.Fatalf("%v: %v", args, err)
+			}
+		})
+	}
+	run("hide", "team@group.calendar.google.com")
+	run("subscribe", "en.usa#holiday@group.v.calendar.google.com", "--calendar-color", "7")
+	run("unsubscribe", "old@group.calendar.google.com")
+	run("delete", "proj@group.calendar.google.com")
+	run("clear")
+
+	got := *reqs
+	if len(got) != 5 {
+		t.Fatalf("unexpected requests: %#v", got)
+	}
+	if got[0].Method != http.MethodPatch || got[0].Body["hidden"] != true || got[0].Body["selected"] != false {
+		t.Fatalf("unexpected hide: %#v", got[0])
+	}
+	if got[1].Method != http.MethodPost || got[1].Body["id"] != "en.usa#holiday@group.v.calendar.google.com" || got[1].Body["colorId"] != "7" {
+		t.Fatalf("unexpected subscribe: %#v", got[1])
+	}
+	if got[2].Method != http.MethodDelete || !strings.HasPrefix(got[2].Path, "/users/me/calendarList/") {
+		t.Fatalf("unexpected unsubscribe: %#v", got[2])
+	}
+	if got[3].Method != http.MethodDelete || got[3].Path != "/calendars/proj@group.calendar.google.com" {
+		t.Fatalf("unexpected delete: %#v", got[3])
+	}
+	if got[4].Path != "/calendars/primary/clear" {
+		t.Fatalf("unexpected clear: %#v", got[4])
+	}
+}
+
+func TestCalendarCalendars_Validation(t *testing.T) {
+	origNew := newCalendarService
+	t.Cleanup(func() { newCalendarService = origNew })
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) {
+		t.Fatalf("validation errors must not reach the API")
+		return nil, errors.New("unexpected calendar service call")
+	}
+
+	for _, args := range [][]string{
+		{"delete", "primary"},
+		{"update", "cal@group.calendar.google.com"},
+		{"update", "cal@group.calendar.google.com", "--calendar-color", "blue"},
+		{"update", "cal@group.calendar.google.com", "--calendar-foreground", "#ffffff"},
+		{"update", "cal@group.calendar.google.com", "--calendar-color", "7", "--calendar-foreground", "#ffffff"},
+		{"clear", "cal@group.calendar.google.com"},
+		{"clear", "Team"},
+	} {
+		var err error
+		_ = captureStderr(t, func() {
+			err = Execute(append([]string{"--force", "--account", "a@b.com", "calendar", "calendars"}, args...))
+		})
+		if err == nil {
+			t.Fatalf("%v: expected error", args)
+		}
+	}
+}
@@ -63,21 +63,12 @@ func (c *CalendarConflictsCmd) Run(ctx context.Context, flags *RootFlags) error
 
 	from, to := timeRange.FormatRFC3339()
 
-	items := make([]*calendar.FreeBusyRequestItem, 0, len(calendarIDs))
-	for _, id := range calendarIDs {
-		items = append(items, &calendar.FreeBusyRequestItem{Id: id})
-	}
-
-	resp, err := svc.Freebusy.Query(&calendar.FreeBusyRequest{
-		TimeMin: from,
-		TimeMax: to,
-		Items:   items,
-	}).Do()
+	calendars, err := queryFreeBusy(ctx, svc, calendarIDs, from, to)
 	if err != nil {
 		return err
 	}
 
-	conflicts := detectConflicts(resp.Calendars)
+	conflicts := detectConflicts(calendars)
 
 	if outfmt.IsJSON(ctx) {
 		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
@@ -115,18 +106,10 @@ func detectConflicts(calendars map[string]calendar.FreeBusyCalendar) []conflict
 
 	var allBusy []busyPeriod
 	for calID, cal := range calendars {
-		for _, b := range cal.Busy {
-			start, err := time.Parse(time.RFC3339, b.Start)
-			if err != nil {
-				continue
-			}
-			end, err := time.Parse(time.RFC3339, b.End)
-			if err != nil {
-				continue
-			}
+		for _, b := range freeBusyIntervals(cal) {
 			allBusy = append(allBusy, busyPeriod{
-				start:      start,
-				end:        end,
+				start:      b.Start,
+				end:        b.End,
 				calendarID: calID,
 			})
 		}
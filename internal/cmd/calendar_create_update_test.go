@@ -8,6 +8,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"google.golang.org/api/calendar/v3"
 	"google.golang.org/api/option"
@@ -561,3 +562,47 @@ func TestCalendarUpdateCmd_ScopeFuture(t *testing.T) {
 		t.Fatalf("expected recurrence truncation")
 	}
 }
+
+func TestResolveCreateTimes(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tzdata: %v", err)
+	}
+	now := time.Date(2026, 2, 13, 15, 45, 0, 0, loc) // Friday
+
+	cases := []struct {
+		name               string
+		from, to, duration string
+		allDay             bool
+		wantFrom, wantTo   string
+		wantErr            bool
+	}{
+		{name: "rfc3339 passthrough", from: "2026-02-16T10:00:00Z", to: "2026-02-16T11:00:00Z", wantFrom: "2026-02-16T10:00:00Z", wantTo: "2026-02-16T11:00:00Z"},
+		{name: "natural with duration", from: "next tuesday 3pm", duration: "45m", wantFrom: "2026-02-17T15:00:00-05:00", wantTo: "2026-02-17T15:45:00-05:00"},
+		{name: "relative offset", from: "+45m", duration: "1h30m", wantFrom: "2026-02-13T16:30:00-05:00", wantTo: "2026-02-13T18:00:00-05:00"},
+		{name: "natural to", from: "in 2 hours", to: "tomorrow 9am", wantFrom: "2026-02-13T17:45:00-05:00", wantTo: "2026-02-14T09:00:00-05:00"},
+		{name: "all day default length", from: "tomorrow", duration: "1d", allDay: true, wantFrom: "2026-02-14", wantTo: "2026-02-15"},
+		{name: "all day rounds up", from: "2026-03-01", duration: "36h", allDay: true, wantFrom: "2026-03-01", wantTo: "2026-03-03"},
+		{name: "to and duration", from: "tomorrow 3pm", to: "tomorrow 4pm", duration: "30m", wantErr: true},
+		{name: "end before start", from: "tomorrow 3pm", to: "tomorrow 2pm", wantErr: true},
+		{name: "bad duration", from: "tomorrow 3pm", duration: "soon", wantErr: true},
+		{name: "bad from", from: "whenever", duration: "30m", wantErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			from, to, err := resolveCreateTimes(tc.from, tc.to, tc.duration, tc.allDay, now, loc)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %q %q", from, to)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveCreateTimes: %v", err)
+			}
+			if from != tc.wantFrom || to != tc.wantTo {
+				t.Fatalf("got %q..%q, want %q..%q", from, to, tc.wantFrom, tc.wantTo)
+			}
+		})
+	}
+}
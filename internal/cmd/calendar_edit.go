@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/alecthomas/kong"
 	"google.golang.org/api/calendar/v3"
@@ -16,11 +18,16 @@ import (
 type CalendarCreateCmd struct {
 	CalendarID            string   `arg:"" name:"calendarId" help:"Calendar ID"`
 	Summary               string   `name:"summary" help:"Event summary/title"`
-	From                  string   `name:"from" help:"Start time (RFC3339)"`
-	To                    string   `name:"to" help:"End time (RFC3339)"`
+	From                  string   `name:"from" help:"Start time (RFC3339, date, or relative: 'tomorrow 3pm', 'next tuesday 10:30', 'in 2 hours', '+45m')"`
+	To                    string   `name:"to" help:"End time (same forms as --from)"`
+	Duration              string   `name:"duration" help:"Event length instead of --to (e.g. 30m, 1h30m; 2d for all-day events)"`
+	Timezone              string   `name:"timezone" help:"Timezone for relative --from/--to (IANA name; default: configured timezone or local)"`
 	Description           string   `name:"description" help:"Description"`
 	Location              string   `name:"location" help:"Location"`
 	Attendees             string   `name:"attendees" help:"Comma-separated attendee emails"`
+	Room                  string   `name:"room" help:"Book a meeting room: resource email, room name, or 'auto' for the smallest free room that fits"`
+	RoomBuilding          string   `name:"room-building" help:"Building ID or name for --room auto"`
+	RoomFeatures          string   `name:"room-features" help:"Comma-separated required room features for --room auto (e.g. video)"`
 	AllDay                bool     `name:"all-day" help:"All-day event (use date-only in --from/--to)"`
 	Recurrence            []string `name:"rrule" help:"Recurrence rules (e.g., 'RRULE:FREQ=MONTHLY;BYMONTHDAY=11'). Can be repeated."`
 	Reminders             []string `name:"reminder" help:"Custom reminders as method:duration (e.g., popup:30m, email:1d). Can be repeated (max 5)."`
@@ -67,8 +74,8 @@ func (c *CalendarCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if summary == "" {
 		summary = c.defaultSummaryForEventType(eventType)
 	}
-	if summary == "" || strings.TrimSpace(c.From) == "" || strings.TrimSpace(c.To) == "" {
-		return usage("required: --summary, --from, --to")
+	if summary == "" || strings.TrimSpace(c.From) == "" || (strings.TrimSpace(c.To) == "" && strings.TrimSpace(c.Duration) == "") {
+		return usage("required: --summary, --from, --to or --duration")
 	}
 
 	colorId, err := validateColorId(c.ColorId)
@@ -98,12 +105,21 @@ func (c *CalendarCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
 	}
 	transparency = applyEventTypeTransparencyDefault(transparency, eventType)
 
+	loc, err := resolveOutputLocation(c.Timezone, false)
+	if err != nil {
+		return err
+	}
+	from, to, err := resolveCreateTimes(c.From, c.To, c.Duration, allDay, time.Now().In(loc), loc)
+	if err != nil {
+		return err
+	}
+
 	event := &calendar.Event{
 		Summary:            summary,
 		Description:        strings.TrimSpace(c.Description),
 		Location:           strings.TrimSpace(c.Location),
-		Start:              buildEventDateTime(c.From, allDay),
-		End:                buildEventDateTime(c.To, allDay),
+		Start:              buildEventDateTime(from, allDay),
+		End:                buildEventDateTime(to, allDay),
 		Attendees:          buildAttendees(c.Attendees),
 		Recurrence:         buildRecurrence(c.Recurrence),
 		Reminders:          reminders,
@@ -133,12 +149,20 @@ func (c *CalendarCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err = c.applyCreateEventType(event, eventType); err != nil {
 		return err
 	}
+	room := strings.TrimSpace(c.Room)
+	if strings.Contains(room, "@") {
+		event.Attendees = append(event.Attendees, &calendar.EventAttendee{Email: room, Resource: true})
+		room = ""
+	} else if room != "" && allDay {
+		return usage("--room needs a timed event")
+	}
 
 	if dryRunErr := dryRunExit(ctx, flags, "calendar.create", map[string]any{
 		"calendar_id":          calendarID,
 		"send_updates":         sendUpdates,
 		"conference_version_1": c.WithMeet,
 		"supports_attachments": len(event.Attachments) > 0,
+		"room":                 room,
 		"event":                event,
 	}); dryRunErr != nil {
 		return dryRunErr
@@ -157,6 +181,14 @@ func (c *CalendarCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	if room != "" {
+		var attendee *calendar.EventAttendee
+		attendee, err = c.resolveRoom(ctx, account, svc, room, event, from, to)
+		if err != nil {
+			return err
+		}
+		event.Attendees = append(event.Attendees, attendee)
+	}
 
 	call := svc.Events.Insert(calendarID, event)
 	if sendUpdates != "" {
@@ -180,6 +212,57 @@ func (c *CalendarCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+// resolveRoom looks up --room by name, or with "auto" picks the smallest free
+// room that seats every attendee plus the organizer.
+func (c *CalendarCreateCmd) resolveRoom(ctx context.Context, account string, svc *calendar.Service, room string, event *calendar.Event, from, to string) (*calendar.EventAttendee, error) {
+	window, err := parseRoomWindow(from, to)
+	if err != nil {
+		return nil, err
+	}
+	adminSvc, err := newAdminDirectoryService(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	filter := roomFilter{Building: c.RoomBuilding, Features: c.RoomFeatures}
+	auto := strings.EqualFold(room, "auto")
+	if auto {
+		filter.Capacity = 1
+		for _, a := range event.Attendees {
+			if !a.Resource {
+				filter.Capacity++
+			}
+		}
+	}
+	rooms, err := listCalendarRooms(ctx, adminSvc, filter)
+	if err != nil {
+		return nil, wrapAdminDirectoryError(err, account)
+	}
+	if !auto {
+		var named []calendarRoom
+		for _, r := range rooms {
+			if strings.EqualFold(r.Name, room) || r.ID == room {
+				named = append(named, r)
+			}
+		}
+		rooms = named
+		if len(rooms) == 0 {
+			return nil, usagef("no room named %q", room)
+		}
+	}
+	free, err := freeCalendarRooms(ctx, svc, rooms, window)
+	if err != nil {
+		return nil, err
+	}
+	if len(free) == 0 {
+		if auto {
+			return nil, fmt.Errorf("no free room for %d people between %s and %s", filter.Capacity, from, to)
+		}
+		return nil, fmt.Errorf("room %q is busy between %s and %s", room, from, to)
+	}
+	ui.FromContext(ctx).Err().Printf("Booking room %s (%s)", free[0].Name, free[0].Email)
+	return &calendar.EventAttendee{Email: free[0].Email, DisplayName: free[0].Name, Resource: true}, nil
+}
+
 func (c *CalendarCreateCmd) resolveCreateEventType() (string, error) {
 	focusFlags := strings.TrimSpace(c.FocusAutoDecline) != "" ||
 		strings.TrimSpace(c.FocusDeclineMessage) != "" ||
@@ -213,6 +296,84 @@ func (c *CalendarCreateCmd) defaultSummaryForEventType(eventType string) string
 	}
 }
 
+// resolveCreateTimes turns --from/--to/--duration into values for the API.
+// RFC3339 timestamps and YYYY-MM-DD dates pass through unchanged; anything
+// else goes through parseTimeExpr in loc.
+func resolveCreateTimes(fromExpr, toExpr, durationExpr string, allDay bool, now time.Time, loc *time.Location) (string, string, error) {
+	fromExpr = strings.TrimSpace(fromExpr)
+	toExpr = strings.TrimSpace(toExpr)
+	durationExpr = strings.TrimSpace(durationExpr)
+	if toExpr != "" && durationExpr != "" {
+		return "", "", usage("use either --to or --duration, not both")
+	}
+
+	resolve := func(flag, expr string) (string, time.Time, error) {
+		if allDay {
+			if t, err := time.ParseInLocation("2006-01-02", expr, loc); err == nil {
+				return expr, t, nil
+			}
+		} else if t, err := time.Parse(time.RFC3339, expr); err == nil {
+			return expr, t, nil
+		}
+		t, err := parseTimeExpr(expr, now, loc)
+		if err != nil {
+			return "", time.Time{}, usagef("invalid %s: %v", flag, err)
+		}
+		if allDay {
+			return t.Format("2006-01-02"), t, nil
+		}
+		return t.Format(time.RFC3339), t, nil
+	}
+
+	from, start, err := resolve("--from", fromExpr)
+	if err != nil {
+		return "", "", err
+	}
+	if toExpr != "" {
+		to, end, err := resolve("--to", toExpr)
+		if err != nil {
+			return "", "", err
+		}
+		if !allDay && !end.After(start) {
+			return "", "", usage("--to must be after --from")
+		}
+		return from, to, nil
+	}
+
+	duration, err := parseEventDuration(durationExpr)
+	if err != nil {
+		return "", "", err
+	}
+	if allDay {
+		days := int((duration + 24*time.Hour - 1) / (24 * time.Hour))
+		return from, start.AddDate(0, 0, max(days, 1)).Format("2006-01-02"), nil
+	}
+	return from, start.Add(duration).Format(time.RFC3339), nil
+}
+
+// parseEventDuration accepts Go durations plus whole days ("2d") and weeks ("1w").
+func parseEventDuration(expr string) (time.Duration, error) {
+	expr = strings.TrimSpace(strings.ToLower(expr))
+	var d time.Duration
+	var err error
+	switch {
+	case strings.HasSuffix(expr, "d"), strings.HasSuffix(expr, "w"):
+		unit := 24 * time.Hour
+		if strings.HasSuffix(expr, "w") {
+			unit *= 7
+		}
+		var n int
+		n, err = strconv.Atoi(expr[:len(expr)-1])
+		d = time.Duration(n) * unit
+	default:
+		d, err = time.ParseDuration(expr)
+	}
+	if err != nil || d <= 0 {
+		return 0, usagef("invalid --duration %q (e.g. 30m, 1h30m, 2d)", expr)
+	}
+	return d, nil
+}
+
 func resolveCreateAllDay(from, to string, allDay bool, eventType string) (bool, error) {
 	if eventType != eventTypeWorkingLocation {
 		return allDay, nil
@@ -0,0 +1,282 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/ics"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type CalendarExportCmd struct {
+	CalendarID string `arg:"" name:"calendarId" optional:"" help:"Calendar ID (default: primary)"`
+	From       string `name:"from" help:"Start time (RFC3339, date, or relative; default: now)"`
+	To         string `name:"to" help:"End time (RFC3339, date, or relative; default: one year after --from)"`
+	Query      string `name:"query" help:"Free text search"`
+	Out        string `name:"out" short:"o" help:"Output .ics file (default: stdout)"`
+}
+
+func (c *CalendarExportCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+	calendarID, err := resolveCalendarID(ctx, svc, firstNonEmpty(strings.TrimSpace(c.CalendarID), primaryCalendarID))
+	if err != nil {
+		return err
+	}
+
+	tr, err := ResolveTimeRangeWithDefaults(ctx, svc, TimeRangeFlags{From: c.From, To: c.To}, TimeRangeDefaults{
+		ToOffset:     365 * 24 * time.Hour,
+		ToFromOffset: 365 * 24 * time.Hour,
+	})
+	if err != nil {
+		return err
+	}
+
+	entry, err := svc.CalendarList.Get(calendarID).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("failed to get calendar %q: %w", calendarID, err)
+	}
+
+	from, to := tr.FormatRFC3339()
+	events, err := fetchExportEvents(ctx, svc, calendarID, from, to, c.Query)
+	if err != nil {
+		return err
+	}
+
+	cal := eventsToICS(events, icsCalendarMeta{Name: entry.Summary, Timezone: entry.TimeZone}, time.Now())
+	var buf bytes.Buffer
+	if err := ics.Encode(&buf, cal); err != nil {
+		return err
+	}
+
+	outPath := strings.TrimSpace(c.Out)
+	if outPath == "" || outPath == "-" {
+		_, err = os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	outPath, err = config.ExpandPath(outPath)
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(outPath, buf.Bytes()); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"calendarId": calendarID,
+			"path":       outPath,
+			"events":     len(cal.Components("VEVENT")),
+			"from":       from,
+			"to":         to,
+		})
+	}
+	u.Out().Printf("path\t%s", outPath)
+	u.Out().Printf("events\t%d", len(cal.Components("VEVENT")))
+	return nil
+}
+
+// fetchExportEvents lists events without expanding recurrences so masters keep
+// their RRULE and modified or cancelled instances export as RECURRENCE-ID
+// overrides.
+func fetchExportEvents(ctx context.Context, svc *calendar.Service, calendarID, from, to, query string) ([]*calendar.Event, error) {
+	var out []*calendar.Event
+	pageToken := ""
+	for {
+		call := svc.Events.List(calendarID).
+			TimeMin(from).
+			TimeMax(to).
+			SingleEvents(false).
+			ShowDeleted(true).
+			MaxResults(2500).
+			Context(ctx)
+		if strings.TrimSpace(query) != "" {
+			call = call.Q(query)
+		}
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, resp.Items...)
+		if resp.NextPageToken == "" {
+			return out, nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+type CalendarImportCmd struct {
+	File       string `arg:"" name:"file" help:"Path to .ics file ('-' for stdin)"`
+	CalendarID string `arg:"" name:"calendarId" optional:"" help:"Calendar ID (default: primary)"`
+	Timezone   string `name:"timezone" help:"Timezone for floating times (default: calendar timezone)"`
+}
+
+type calendarImportResult struct {
+	UID     string `json:"iCalUID"`
+	Summary string `json:"summary,omitempty"`
+	Action  string `json:"action"`
+	ID      string `json:"id,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+func (c *CalendarImportCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	data, err := readICSInput(c.File)
+	if err != nil {
+		return err
+	}
+	roots, err := ics.Parse(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("parse %s: %w", c.File, err)
+	}
+	var events []*calendar.Event
+	for _, root := range roots {
+		if root.Name != "VCALENDAR" {
+			continue
+		}
+		parsed, parseErr := icsToEvents(root, strings.TrimSpace(c.Timezone))
+		if parseErr != nil {
+			return parseErr
+		}
+		events = append(events, parsed...)
+	}
+	if len(events) == 0 {
+		return usage("no VEVENT components found")
+	}
+	// Masters first so RECURRENCE-ID overrides attach to an existing series.
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].OriginalStartTime == nil && events[j].OriginalStartTime != nil
+	})
+
+	calendarID := firstNonEmpty(strings.TrimSpace(c.CalendarID), primaryCalendarID)
+	if dryRunErr := dryRunExit(ctx, flags, "calendar.import", map[string]any{
+		"calendar_id": calendarID,
+		"events":      events,
+	}); dryRunErr != nil {
+		return dryRunErr
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+	calendarID, err = resolveCalendarID(ctx, svc, calendarID)
+	if err != nil {
+		return err
+	}
+	tz, _, err := getCalendarLocation(ctx, svc, calendarID)
+	if err != nil {
+		return err
+	}
+
+	results := make([]calendarImportResult, 0, len(events))
+	failed := 0
+	for _, ev := range events {
+		fillFloatingTimezone(ev, tz)
+		res := calendarImportResult{UID: ev.ICalUID, Summary: ev.Summary}
+		res.Action, res.ID, err = importCalendarEvent(ctx, svc, calendarID, ev)
+		if err != nil {
+			res.Action = "failed"
+			res.Error = err.Error()
+			failed++
+		}
+		results = append(results, res)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"calendarId": calendarID,
+			"results":    results,
+			"failed":     failed,
+		}); err != nil {
+			return err
+		}
+	} else {
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "ACTION\tID\tUID\tSUMMARY")
+		for _, r := range results {
+			id := r.ID
+			if r.Error != "" {
+				id = r.Error
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", r.Action, id, r.UID, sanitizeTab(r.Summary))
+		}
+		_ = tw.Flush()
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d events failed to import", failed, len(events))
+	}
+	if !outfmt.IsJSON(ctx) {
+		u.Err().Printf("Imported %d events into %s", len(events), calendarID)
+	}
+	return nil
+}
+
+// importCalendarEvent uses events.import (idempotent by iCalUID) for default
+// events. Focus time, out-of-office and working location events cannot be
+// imported, so they are matched by iCalUID and updated or inserted instead.
+func importCalendarEvent(ctx context.Context, svc *calendar.Service, calendarID string, ev *calendar.Event) (string, string, error) {
+	if ev.EventType == "" || ev.EventType == eventTypeDefault {
+		imported, err := svc.Events.Import(calendarID, ev).Context(ctx).Do()
+		if err != nil {
+			return "", "", err
+		}
+		return "imported", imported.Id, nil
+	}
+
+	existing, err := svc.Events.List(calendarID).ICalUID(ev.ICalUID).ShowDeleted(false).Context(ctx).Do()
+	if err != nil {
+		return "", "", err
+	}
+	if len(existing.Items) > 0 {
+		updated, err := svc.Events.Update(calendarID, existing.Items[0].Id, ev).Context(ctx).Do()
+		if err != nil {
+			return "", "", err
+		}
+		return "updated", updated.Id, nil
+	}
+	created, err := svc.Events.Insert(calendarID, ev).Context(ctx).Do()
+	if err != nil {
+		return "", "", err
+	}
+	return "inserted", created.Id, nil
+}
+
+func readICSInput(path string) ([]byte, error) {
+	path = strings.TrimSpace(path)
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	expanded, err := config.ExpandPath(path)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(expanded) //nolint:gosec // user-provided path
+}
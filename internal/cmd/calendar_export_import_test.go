@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/option"
+)
+
+func TestCalendarExportImportCommands(t *testing.T) {
+	origNew := newCalendarService
+	t.Cleanup(func() { newCalendarService = origNew })
+
+	var imported []map[string]any
+	var inserted []map[string]any
+	srv := httptest.NewServer(withPrimaryCalendar(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/calendars/primary/events") && r.URL.Query().Get("iCalUID") != "":
+			_ = json.NewEncoder(w).Encode(map[string]any{"items": []any{}})
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/calendars/primary/events"):
+			if r.URL.Query().Get("singleEvents") != "false" {
+				t.Fatalf("export should not expand recurrences: %s", r.URL.RawQuery)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"items": []map[string]any{
+					{
+						"id":         "e1",
+						"iCalUID":    "e1@example.com",
+						"summary":    "Weekly sync",
+						"start":      map[string]any{"dateTime": "2026-01-05T10:00:00Z", "timeZone": "UTC"},
+						"end":        map[string]any{"dateTime": "2026-01-05T11:00:00Z", "timeZone": "UTC"},
+						"recurrence": []string{"RRULE:FREQ=WEEKLY"},
+					},
+					{
+						"id":        "e2",
+						"summary":   "Focus",
+						"eventType": "focusTime",
+						"start":     map[string]any{"dateTime": "2026-01-06T09:00:00Z"},
+						"end":       map[string]any{"dateTime": "2026-01-06T11:00:00Z"},
+						"focusTimeProperties": map[string]any{
+							"autoDeclineMode": "declineNone",
+							"chatStatus":      "doNotDisturb",
+						},
+					},
+				},
+			})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/calendars/primary/events/import"):
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			imported = append(imported, body)
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "imp1"})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/calendars/primary/events"):
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			inserted = append(inserted, body)
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "ins1"})
+		default:
+			http.NotFound(w, r)
+		}
+	})))
+	defer srv.Close()
+
+	svc, err := calendar.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return svc, nil }
+
+	icsPath := filepath.Join(t.TempDir(), "cal.ics")
+	_ = captureStdout(t, func() {
+		if err := Execute([]string{"--account", "a@b.com", "calendar", "export", "primary", "--from", "2026-01-01", "--to", "2026-02-01", "--out", icsPath}); err != nil {
+			t.Fatalf("export: %v", err)
+		}
+	})
+	data, err := os.ReadFile(icsPath)
+	if err != nil {
+		t.Fatalf("read export: %v", err)
+	}
+	if !strings.Contains(string(data), "UID:e1@example.com") || !strings.Contains(string(data), "X-GOG-EVENT-TYPE:focusTime") {
+		t.Fatalf("unexpected export:\n%s", data)
+	}
+
+	_ = captureStdout(t, func() {
+		_ = captureStderr(t, func() {
+			if err := Execute([]string{"--json", "--account", "a@b.com", "calendar", "import", icsPath, "primary"}); err != nil {
+				t.Fatalf("import: %v", err)
+			}
+		})
+	})
+	if len(imported) != 1 || imported[0]["iCalUID"] != "e1@example.com" {
+		t.Fatalf("unexpected imports: %#v", imported)
+	}
+	if len(inserted) != 1 || inserted[0]["eventType"] != "focusTime" || inserted[0]["iCalUID"] != "e2@google.com" {
+		t.Fatalf("unexpected inserts: %#v", inserted)
+	}
+}
@@ -0,0 +1,480 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+var findTimeWithinDaysPattern = regexp.MustCompile(`^(?:next\s+)?(\d+)\s*(?:d|days?)$`)
+
+type CalendarFindTimeCmd struct {
+	Attendees       string `name:"attendees" required:"" help:"Comma-separated attendee emails"`
+	Duration        string `name:"duration" help:"Meeting length (e.g. 30m, 45m, 1h)" default:"30m"`
+	Within          string `name:"within" help:"Search window: today, tomorrow, this week, next week, 'N days'" default:"7 days"`
+	From            string `name:"from" help:"Window start (overrides --within; RFC3339, date, or relative)"`
+	To              string `name:"to" help:"Window end (overrides --within; RFC3339, date, or relative)"`
+	WorkingHours    string `name:"working-hours" help:"Working hours applied to every participant (HH:MM-HH:MM)" default:"09:00-17:00"`
+	TzPerAttendee   bool   `name:"tz-per-attendee" help:"Apply working hours in each attendee's calendar timezone"`
+	Timezone        string `name:"timezone" help:"Your timezone (IANA name). Default: primary calendar timezone"`
+	IncludeWeekends bool   `name:"include-weekends" help:"Allow slots on Saturday and Sunday"`
+	NoSelf          bool   `name:"no-self" help:"Do not include your own primary calendar"`
+	Step            string `name:"step" help:"Slot start granularity" default:"15m"`
+	Max             int    `name:"max" aliases:"limit" help:"Number of candidate slots to show" default:"5"`
+	Book            bool   `name:"book" help:"Create an event in the top-ranked slot"`
+	Summary         string `name:"summary" help:"Event title when booking" default:"Meeting"`
+	Description     string `name:"description" help:"Event description when booking"`
+	WithMeet        bool   `name:"with-meet" help:"Add a Google Meet link when booking"`
+	SendUpdates     string `name:"send-updates" help:"Notification mode when booking: all, externalOnly, none (default: all)"`
+}
+
+// findTimeParticipant is a calendar whose busy time and working hours
+// constrain the search.
+type findTimeParticipant struct {
+	ID       string
+	Location *time.Location
+}
+
+type workingHours struct {
+	Start int // minutes after midnight
+	End   int
+}
+
+type findTimeSlot struct {
+	Start time.Time         `json:"start"`
+	End   time.Time         `json:"end"`
+	Score int               `json:"score"`
+	Local map[string]string `json:"local"`
+}
+
+type findTimeOptions struct {
+	From            time.Time
+	To              time.Time
+	Duration        time.Duration
+	Step            time.Duration
+	Hours           workingHours
+	IncludeWeekends bool
+	Location        *time.Location // slot starts align to this zone's wall clock
+}
+
+func (c *CalendarFindTimeCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	attendees := splitCSV(c.Attendees)
+	if len(attendees) == 0 {
+		return usage("--attendees is required")
+	}
+	duration, err := time.ParseDuration(strings.TrimSpace(c.Duration))
+	if err != nil || duration <= 0 {
+		return usagef("invalid --duration %q (e.g. 30m, 1h)", c.Duration)
+	}
+	step, err := time.ParseDuration(strings.TrimSpace(c.Step))
+	if err != nil || step <= 0 {
+		return usagef("invalid --step %q (e.g. 15m)", c.Step)
+	}
+	hours, err := parseWorkingHours(c.WorkingHours)
+	if err != nil {
+		return err
+	}
+	if c.Max <= 0 {
+		return usage("--max must be positive")
+	}
+	sendUpdates, err := validateSendUpdates(c.SendUpdates)
+	if err != nil {
+		return err
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	var loc *time.Location
+	if tz := strings.TrimSpace(c.Timezone); tz != "" {
+		loc, err = time.LoadLocation(tz)
+		if err != nil {
+			return fmt.Errorf("invalid --timezone %q: %w", tz, err)
+		}
+	} else {
+		loc, err = getUserTimezone(ctx, svc)
+		if err != nil {
+			return err
+		}
+	}
+
+	now := time.Now().In(loc)
+	from, to, err := resolveFindTimeWindow(c.Within, c.From, c.To, now, loc)
+	if err != nil {
+		return err
+	}
+
+	participants := make([]findTimeParticipant, 0, len(attendees)+1)
+	if !c.NoSelf {
+		participants = append(participants, findTimeParticipant{ID: primaryCalendarID, Location: loc})
+	}
+	for _, email := range attendees {
+		p := findTimeParticipant{ID: email, Location: loc}
+		if c.TzPerAttendee {
+			if attendeeLoc, tzErr := attendeeTimezone(ctx, svc, email); tzErr != nil {
+				u.Err().Printf("Warning: timezone for %s unavailable (%v); using %s", email, tzErr, loc)
+			} else {
+				p.Location = attendeeLoc
+			}
+		}
+		participants = append(participants, p)
+	}
+
+	ids := make([]string, 0, len(participants))
+	for _, p := range participants {
+		ids = append(ids, p.ID)
+	}
+	calendars, err := queryFreeBusy(ctx, svc, ids, from.Format(time.RFC3339), to.Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("freebusy query: %w", err)
+	}
+
+	var busy []timeInterval
+	for _, p := range participants {
+		cal, ok := calendars[p.ID]
+		if !ok {
+			continue
+		}
+		if len(cal.Errors) > 0 {
+			reasons := make([]string, 0, len(cal.Errors))
+			for _, e := range cal.Errors {
+				reasons = append(reasons, e.Reason)
+			}
+			u.Err().Printf("Warning: free/busy unavailable for %s (%s); treating as free", p.ID, strings.Join(reasons, ", "))
+			continue
+		}
+		busy = append(busy, freeBusyIntervals(cal)...)
+	}
+
+	slots := findFreeSlots(busy, participants, findTimeOptions{
+		From:            from,
+		To:              to,
+		Duration:        duration,
+		Step:            step,
+		Hours:           hours,
+		IncludeWeekends: c.IncludeWeekends,
+		Location:        loc,
+	}, c.Max)
+
+	if c.Book {
+		if len(slots) == 0 {
+			return fmt.Errorf("no common free slot between %s and %s", from.Format(time.RFC3339), to.Format(time.RFC3339))
+		}
+		return c.book(ctx, flags, svc, slots[0], attendees, loc, sendUpdates)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"timeMin": from.Format(time.RFC3339),
+			"timeMax": to.Format(time.RFC3339),
+			"slots":   slots,
+		})
+	}
+
+	if len(slots) == 0 {
+		u.Err().Println("No common free slot found")
+		return nil
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "RANK\tSTART\tEND\tSCORE\tLOCAL")
+	for i, s := range slots {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%s\n", i+1,
+			s.Start.In(loc).Format("Mon 2006-01-02 15:04"),
+			s.End.In(loc).Format("15:04"),
+			s.Score,
+			sanitizeTab(formatSlotLocal(s.Local)),
+		)
+	}
+	return nil
+}
+
+func (c *CalendarFindTimeCmd) book(ctx context.Context, flags *RootFlags, svc *calendar.Service, slot findTimeSlot, attendees []string, loc *time.Location, sendUpdates string) error {
+	u := ui.FromContext(ctx)
+	event := &calendar.Event{
+		Summary:        strings.TrimSpace(c.Summary),
+		Description:    strings.TrimSpace(c.Description),
+		Start:          &calendar.EventDateTime{DateTime: slot.Start.In(loc).Format(time.RFC3339), TimeZone: loc.String()},
+		End:            &calendar.EventDateTime{DateTime: slot.End.In(loc).Format(time.RFC3339), TimeZone: loc.String()},
+		Attendees:      buildAttendees(strings.Join(attendees, ",")),
+		ConferenceData: buildConferenceData(c.WithMeet),
+	}
+
+	if dryRunErr := dryRunExit(ctx, flags, "calendar.find_time.book", map[string]any{
+		"calendar_id":  primaryCalendarID,
+		"send_updates": sendUpdates,
+		"event":        event,
+	}); dryRunErr != nil {
+		return dryRunErr
+	}
+
+	call := svc.Events.Insert(primaryCalendarID, event).Context(ctx)
+	if sendUpdates != "" {
+		call = call.SendUpdates(sendUpdates)
+	}
+	if c.WithMeet {
+		call = call.ConferenceDataVersion(1)
+	}
+	created, err := call.Do()
+	if err != nil {
+		return err
+	}
+
+	tz := loc.String()
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"slot":  slot,
+			"event": wrapEventWithDaysWithTimezone(created, tz, loc),
+		})
+	}
+	printCalendarEventWithTimezone(u, created, tz, loc)
+	return nil
+}
+
+// resolveFindTimeWindow turns --within (or explicit --from/--to) into a search range.
+func resolveFindTimeWindow(within, fromExpr, toExpr string, now time.Time, loc *time.Location) (time.Time, time.Time, error) {
+	if strings.TrimSpace(fromExpr) != "" || strings.TrimSpace(toExpr) != "" {
+		from := now
+		to := now.AddDate(0, 0, 7)
+		var err error
+		if strings.TrimSpace(fromExpr) != "" {
+			from, err = parseTimeExpr(fromExpr, now, loc)
+			if err != nil {
+				return time.Time{}, time.Time{}, fmt.Errorf("invalid --from: %w", err)
+			}
+			to = from.AddDate(0, 0, 7)
+		}
+		if strings.TrimSpace(toExpr) != "" {
+			to, err = parseTimeExprEndOfDay(toExpr, now, loc)
+			if err != nil {
+				return time.Time{}, time.Time{}, fmt.Errorf("invalid --to: %w", err)
+			}
+		}
+		if !to.After(from) {
+			return time.Time{}, time.Time{}, usage("--to must be after --from")
+		}
+		return from, to, nil
+	}
+
+	expr := strings.Join(strings.Fields(strings.ToLower(within)), " ")
+	switch expr {
+	case "today":
+		return now, endOfDay(now), nil
+	case "tomorrow":
+		t := now.AddDate(0, 0, 1)
+		return startOfDay(t), endOfDay(t), nil
+	case "this week":
+		return now, endOfWeek(now, time.Monday), nil
+	case "next week":
+		t := now.AddDate(0, 0, 7)
+		return startOfWeek(t, time.Monday), endOfWeek(t, time.Monday), nil
+	}
+	if m := findTimeWithinDaysPattern.FindStringSubmatch(expr); m != nil {
+		days, _ := strconv.Atoi(m[1])
+		if days > 0 {
+			return now, endOfDay(now.AddDate(0, 0, days-1)), nil
+		}
+	}
+	return time.Time{}, time.Time{}, usagef("invalid --within %q (use today, tomorrow, this week, next week, or 'N days')", within)
+}
+
+func parseWorkingHours(value string) (workingHours, error) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) != 2 {
+		return workingHours{}, usagef("invalid --working-hours %q (expected HH:MM-HH:MM)", value)
+	}
+	var mins [2]int
+	for i, part := range parts {
+		t, err := time.Parse("15:04", strings.TrimSpace(part))
+		if err != nil {
+			return workingHours{}, usagef("invalid --working-hours %q (expected HH:MM-HH:MM)", value)
+		}
+		mins[i] = t.Hour()*60 + t.Minute()
+	}
+	if mins[1] <= mins[0] {
+		return workingHours{}, usagef("invalid --working-hours %q: end must be after start", value)
+	}
+	return workingHours{Start: mins[0], End: mins[1]}, nil
+}
+
+func attendeeTimezone(ctx context.Context, svc *calendar.Service, email string) (*time.Location, error) {
+	cal, err := svc.Calendars.Get(email).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	if cal.TimeZone == "" {
+		return nil, fmt.Errorf("no timezone set")
+	}
+	return time.LoadLocation(cal.TimeZone)
+}
+
+// findFreeSlots returns up to limit non-overlapping slots where nobody is busy
+// and every participant is within working hours. Slots are ranked by the
+// smallest distance to anyone's working-hours edge, breathing room to the
+// nearest busy block, and how soon they occur.
+func findFreeSlots(busy []timeInterval, participants []findTimeParticipant, opts findTimeOptions, limit int) []findTimeSlot {
+	sort.Slice(busy, func(i, j int) bool { return busy[i].Start.Before(busy[j].Start) })
+
+	start := alignSlotStart(opts.From, opts.Step, opts.Location)
+
+	var candidates []findTimeSlot
+	for t := start; !t.Add(opts.Duration).After(opts.To); t = t.Add(opts.Step) {
+		slot := timeInterval{Start: t, End: t.Add(opts.Duration)}
+		if overlapsAny(slot, busy) {
+			continue
+		}
+		edge, ok := workingHoursMargin(slot, participants, opts.Hours, opts.IncludeWeekends)
+		if !ok {
+			continue
+		}
+		gap := busyGapMinutes(slot, busy)
+		dayIndex := int(slot.Start.Sub(opts.From).Hours() / 24)
+		candidates = append(candidates, findTimeSlot{
+			Start: slot.Start,
+			End:   slot.End,
+			Score: min(edge, 120) + min(gap, 60) - 10*dayIndex,
+			Local: slotLocalTimes(slot, participants),
+		})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].Start.Before(candidates[j].Start)
+	})
+
+	out := make([]findTimeSlot, 0, limit)
+	for _, cand := range candidates {
+		if len(out) == limit {
+			break
+		}
+		clash := false
+		for _, chosen := range out {
+			if cand.Start.Before(chosen.End) && cand.End.After(chosen.Start) {
+				clash = true
+				break
+			}
+		}
+		if !clash {
+			out = append(out, cand)
+		}
+	}
+	return out
+}
+
+// alignSlotStart returns the first multiple of step after local midnight in
+// loc that is not before from, so slots start on round local times even in
+// zones with :30 or :45 offsets.
+func alignSlotStart(from time.Time, step time.Duration, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = from.Location()
+	}
+	local := from.In(loc)
+	sinceMidnight := time.Duration(local.Hour())*time.Hour +
+		time.Duration(local.Minute())*time.Minute +
+		time.Duration(local.Second())*time.Second +
+		time.Duration(local.Nanosecond())
+	start := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, int(sinceMidnight/step*step), loc)
+	if start.Before(from) {
+		start = start.Add(step)
+	}
+	return start
+}
+
+func overlapsAny(slot timeInterval, busy []timeInterval) bool {
+	for _, b := range busy {
+		if slot.Start.Before(b.End) && slot.End.After(b.Start) {
+			return true
+		}
+	}
+	return false
+}
+
+// workingHoursMargin reports the smallest number of minutes between the slot
+// and any participant's working-hours boundary, or false if it falls outside.
+func workingHoursMargin(slot timeInterval, participants []findTimeParticipant, hours workingHours, includeWeekends bool) (int, bool) {
+	margin := hours.End - hours.Start
+	for _, p := range participants {
+		ls := slot.Start.In(p.Location)
+		le := slot.End.In(p.Location)
+		if ls.Format("2006-01-02") != le.Format("2006-01-02") {
+			return 0, false
+		}
+		if !includeWeekends && (ls.Weekday() == time.Saturday || ls.Weekday() == time.Sunday) {
+			return 0, false
+		}
+		startMin := ls.Hour()*60 + ls.Minute()
+		endMin := le.Hour()*60 + le.Minute()
+		if startMin < hours.Start || endMin > hours.End {
+			return 0, false
+		}
+		margin = min(margin, startMin-hours.Start, hours.End-endMin)
+	}
+	return margin, true
+}
+
+func busyGapMinutes(slot timeInterval, busy []timeInterval) int {
+	gap := -1
+	for _, b := range busy {
+		var d time.Duration
+		switch {
+		case !b.End.After(slot.Start):
+			d = slot.Start.Sub(b.End)
+		case !b.Start.Before(slot.End):
+			d = b.Start.Sub(slot.End)
+		default:
+			continue
+		}
+		if m := int(d.Minutes()); gap < 0 || m < gap {
+			gap = m
+		}
+	}
+	if gap < 0 {
+		return 60
+	}
+	return gap
+}
+
+func slotLocalTimes(slot timeInterval, participants []findTimeParticipant) map[string]string {
+	out := make(map[string]string, len(participants))
+	for _, p := range participants {
+		out[p.ID] = fmt.Sprintf("%s-%s %s",
+			slot.Start.In(p.Location).Format("Mon 15:04"),
+			slot.End.In(p.Location).Format("15:04"),
+			p.Location.String(),
+		)
+	}
+	return out
+}
+
+func formatSlotLocal(local map[string]string) string {
+	keys := make([]string, 0, len(local))
+	for k := range local {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+" "+local[k])
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/option"
+)
+
+func TestFindFreeSlots(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("tzdata: %v", err)
+	}
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tzdata: %v", err)
+	}
+	// Monday 2026-01-05, 00:00 UTC .. end of day.
+	from := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 1, 6, 0, 0, 0, 0, time.UTC)
+	participants := []findTimeParticipant{
+		{ID: "berlin@example.com", Location: berlin},
+		{ID: "ny@example.com", Location: ny},
+	}
+	busy := []timeInterval{
+		// NY attendee busy 09:00-10:00 local (14:00-15:00 UTC).
+		{Start: time.Date(2026, 1, 5, 14, 0, 0, 0, time.UTC), End: time.Date(2026, 1, 5, 15, 0, 0, 0, time.UTC)},
+	}
+	hours := workingHours{Start: 9 * 60, End: 17 * 60}
+
+	slots := findFreeSlots(busy, participants, findTimeOptions{
+		From: from, To: to, Duration: 30 * time.Minute, Step: 15 * time.Minute, Hours: hours,
+	}, 3)
+	if len(slots) == 0 {
+		t.Fatalf("expected slots")
+	}
+	// Overlap of 09-17 Berlin (08-16 UTC) and 09-17 NY (14-22 UTC) is 14:00-16:00 UTC;
+	// 14:00-15:00 is busy, so every slot must lie in 15:00-16:00 UTC.
+	for _, s := range slots {
+		if s.Start.Before(time.Date(2026, 1, 5, 15, 0, 0, 0, time.UTC)) || s.End.After(time.Date(2026, 1, 5, 16, 0, 0, 0, time.UTC)) {
+			t.Fatalf("slot outside common free window: %s-%s", s.Start, s.End)
+		}
+		if !strings.Contains(s.Local["ny@example.com"], "America/New_York") {
+			t.Fatalf("missing local time: %+v", s.Local)
+		}
+	}
+	if len(slots) != 2 {
+		t.Fatalf("expected 2 non-overlapping slots, got %d", len(slots))
+	}
+
+	weekend := findFreeSlots(nil, participants, findTimeOptions{
+		From: time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC), To: time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC),
+		Duration: 30 * time.Minute, Step: 15 * time.Minute, Hours: hours,
+	}, 3)
+	if len(weekend) != 0 {
+		t.Fatalf("weekend slots should be skipped: %+v", weekend)
+	}
+}
+
+func TestAlignSlotStart(t *testing.T) {
+	kathmandu, err := time.LoadLocation("Asia/Kathmandu")
+	if err != nil {
+		t.Skipf("tzdata: %v", err)
+	}
+	// 09:10 in Kathmandu (UTC+5:45); UTC truncation would give 09:45 local.
+	from := time.Date(2026, 1, 5, 9, 10, 0, 0, kathmandu)
+	if got := alignSlotStart(from.UTC(), 30*time.Minute, kathmandu).In(kathmandu); got.Hour() != 9 || got.Minute() != 30 {
+		t.Fatalf("expected 09:30 local, got %s", got)
+	}
+	if got := alignSlotStart(from, 10*time.Minute, kathmandu); !got.Equal(from) {
+		t.Fatalf("aligned start must be kept, got %s", got)
+	}
+
+	hours := workingHours{Start: 9 * 60, End: 17 * 60}
+	slots := findFreeSlots(nil, []findTimeParticipant{{ID: "a", Location: kathmandu}}, findTimeOptions{
+		From: from.UTC(), To: from.Add(8 * time.Hour), Duration: time.Hour, Step: 15 * time.Minute, Hours: hours, Location: kathmandu,
+	}, 5)
+	for _, s := range slots {
+		if m := s.Start.In(kathmandu).Minute(); m%15 != 0 {
+			t.Fatalf("slot starts at odd local minute: %s", s.Start.In(kathmandu))
+		}
+	}
+	if len(slots) == 0 {
+		t.Fatalf("expected slots")
+	}
+}
+
+func TestResolveFindTimeWindow(t *testing.T) {
+	now := time.Date(2026, 1, 7, 15, 0, 0, 0, time.UTC) // Wednesday
+	from, to, err := resolveFindTimeWindow("next week", "", "", now, time.UTC)
+	if err != nil {
+		t.Fatalf("next week: %v", err)
+	}
+	if !from.Equal(time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)) || to.Weekday() != time.Sunday {
+		t.Fatalf("unexpected next week: %s - %s", from, to)
+	}
+	if _, to, err = resolveFindTimeWindow("3 days", "", "", now, time.UTC); err != nil || to.Day() != 9 {
+		t.Fatalf("unexpected 3 days: %s %v", to, err)
+	}
+	if _, _, err = resolveFindTimeWindow("someday", "", "", now, time.UTC); err == nil {
+		t.Fatalf("expected error for invalid --within")
+	}
+	if _, err = parseWorkingHours("17:00-09:00"); err == nil {
+		t.Fatalf("expected error for inverted working hours")
+	}
+}
+
+func TestCalendarFindTimeCmd_Book(t *testing.T) {
+	origNew := newCalendarService
+	t.Cleanup(func() { newCalendarService = origNew })
+
+	var created map[string]any
+	srv := httptest.NewServer(withPrimaryCalendar(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/freeBusy"):
+			var req map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"calendars": map[string]any{
+					"primary":         map[string]any{"busy": []any{}},
+					"a@example.com":   map[string]any{"busy": []any{}},
+					"bad@example.com": map[string]any{"errors": []map[string]any{{"reason": "notFound"}}},
+				},
+			})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/calendars/primary/events"):
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			created["id"] = "booked"
+			_ = json.NewEncoder(w).Encode(created)
+		default:
+			http.NotFound(w, r)
+		}
+	})))
+	defer srv.Close()
+
+	svc, err := calendar.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return svc, nil }
+
+	_ = captureStderr(t, func() {
+		_ = captureStdout(t, func() {
+			if err := Execute([]string{"--json", "--account", "me@example.com", "calendar", "find-time",
+				"--attendees", "a@example.com,bad@example.com", "--duration", "45m", "--within", "next week",
+				"--include-weekends", "--book", "--summary", "Sync"}); err != nil {
+				t.Fatalf("find-time: %v", err)
+			}
+		})
+	})
+	if created == nil || created["summary"] != "Sync" {
+		t.Fatalf("expected booked event, got %#v", created)
+	}
+	start, _ := time.Parse(time.RFC3339, created["start"].(map[string]any)["dateTime"].(string))
+	end, _ := time.Parse(time.RFC3339, created["end"].(map[string]any)["dateTime"].(string))
+	if end.Sub(start) != 45*time.Minute {
+		t.Fatalf("unexpected booked duration: %s", end.Sub(start))
+	}
+	if attendees, _ := created["attendees"].([]any); len(attendees) != 2 {
+		t.Fatalf("unexpected attendees: %#v", created["attendees"])
+	}
+}
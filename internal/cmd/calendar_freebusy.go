@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"google.golang.org/api/calendar/v3"
 
@@ -38,25 +39,16 @@ func (c *CalendarFreeBusyCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return err
 	}
 
-	req := &calendar.FreeBusyRequest{
-		TimeMin: c.From,
-		TimeMax: c.To,
-		Items:   make([]*calendar.FreeBusyRequestItem, 0, len(calendarIDs)),
-	}
-	for _, id := range calendarIDs {
-		req.Items = append(req.Items, &calendar.FreeBusyRequestItem{Id: id})
-	}
-
-	resp, err := svc.Freebusy.Query(req).Do()
+	calendars, err := queryFreeBusy(ctx, svc, calendarIDs, c.From, c.To)
 	if err != nil {
 		return err
 	}
 
 	if outfmt.IsJSON(ctx) {
-		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"calendars": resp.Calendars})
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"calendars": calendars})
 	}
 
-	if len(resp.Calendars) == 0 {
+	if len(calendars) == 0 {
 		u.Err().Println("No free/busy data")
 		return nil
 	}
@@ -64,10 +56,50 @@ func (c *CalendarFreeBusyCmd) Run(ctx context.Context, flags *RootFlags) error {
 	w, flush := tableWriter(ctx)
 	defer flush()
 	fmt.Fprintln(w, "CALENDAR\tSTART\tEND")
-	for id, data := range resp.Calendars {
+	for id, data := range calendars {
 		for _, b := range data.Busy {
 			fmt.Fprintf(w, "%s\t%s\t%s\n", id, b.Start, b.End)
 		}
 	}
 	return nil
 }
+
+type timeInterval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// queryFreeBusy returns the busy blocks of each calendar between from and to
+// (RFC3339), keyed by calendar ID.
+func queryFreeBusy(ctx context.Context, svc *calendar.Service, calendarIDs []string, from, to string) (map[string]calendar.FreeBusyCalendar, error) {
+	items := make([]*calendar.FreeBusyRequestItem, 0, len(calendarIDs))
+	for _, id := range calendarIDs {
+		items = append(items, &calendar.FreeBusyRequestItem{Id: id})
+	}
+	resp, err := svc.Freebusy.Query(&calendar.FreeBusyRequest{
+		TimeMin: from,
+		TimeMax: to,
+		Items:   items,
+	}).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	return resp.Calendars, nil
+}
+
+// freeBusyIntervals parses a calendar's busy blocks, skipping malformed ones.
+func freeBusyIntervals(cal calendar.FreeBusyCalendar) []timeInterval {
+	out := make([]timeInterval, 0, len(cal.Busy))
+	for _, b := range cal.Busy {
+		start, err := time.Parse(time.RFC3339, b.Start)
+		if err != nil {
+			continue
+		}
+		end, err := time.Parse(time.RFC3339, b.End)
+		if err != nil {
+			continue
+		}
+		out = append(out, timeInterval{Start: start, End: end})
+	}
+	return out
+}
@@ -0,0 +1,687 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/steipete/gogcli/internal/ics"
+)
+
+const (
+	icsDateLayout      = "20060102"
+	icsLocalLayout     = "20060102T150405"
+	icsUTCLayout       = "20060102T150405Z"
+	icsFloatingLayout  = "2006-01-02T15:04:05"
+	icsProdID          = "-//gogcli//gog//EN"
+	icsXEventType      = "X-GOG-EVENT-TYPE"
+	icsXAutoDecline    = "X-GOG-AUTO-DECLINE-MODE"
+	icsXDeclineMessage = "X-GOG-DECLINE-MESSAGE"
+	icsXChatStatus     = "X-GOG-CHAT-STATUS"
+	icsXWorkingType    = "X-GOG-WORKING-LOCATION-TYPE"
+	icsXWorkingLabel   = "X-GOG-WORKING-LOCATION-LABEL"
+	icsXWorkingBldg    = "X-GOG-WORKING-LOCATION-BUILDING"
+	icsXWorkingFloor   = "X-GOG-WORKING-LOCATION-FLOOR"
+	icsXWorkingDesk    = "X-GOG-WORKING-LOCATION-DESK"
+	icsXMSBusyStatus   = "X-MICROSOFT-CDO-BUSYSTATUS"
+)
+
+var icsDurationPattern = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// icsCalendarMeta carries the VCALENDAR-level fields written on export.
+type icsCalendarMeta struct {
+	Name     string
+	Timezone string
+}
+
+// eventsToICS builds a VCALENDAR with one VEVENT per event and a VTIMEZONE
+// for every TZID referenced.
+func eventsToICS(events []*calendar.Event, meta icsCalendarMeta, stamp time.Time) *ics.Component {
+	cal := ics.New("VCALENDAR")
+	cal.Add("PRODID", icsProdID)
+	cal.Add("VERSION", "2.0")
+	cal.Add("CALSCALE", "GREGORIAN")
+	cal.Add("METHOD", "PUBLISH")
+	cal.AddText("X-WR-CALNAME", meta.Name)
+	cal.AddText("X-WR-TIMEZONE", meta.Timezone)
+
+	tzids := map[string]struct{}{}
+	var vevents []*ics.Component
+	year := stamp.Year()
+	for _, ev := range events {
+		if ev == nil || ev.Status == "cancelled" && ev.RecurringEventId == "" {
+			continue
+		}
+		vevents = append(vevents, eventToVEvent(ev, stamp, tzids))
+		if ev.Start != nil {
+			if t, ok := parseEventTime(ev.Start.DateTime, ev.Start.TimeZone); ok && t.Year() < year {
+				year = t.Year()
+			}
+		}
+	}
+
+	names := make([]string, 0, len(tzids))
+	for tz := range tzids {
+		names = append(names, tz)
+	}
+	sort.Strings(names)
+	for _, tz := range names {
+		if vtz := buildVTimezone(tz, year); vtz != nil {
+			cal.AddChild(vtz)
+		}
+	}
+	for _, ve := range vevents {
+		cal.AddChild(ve)
+	}
+	return cal
+}
+
+func eventToVEvent(ev *calendar.Event, stamp time.Time, tzids map[string]struct{}) *ics.Component {
+	ve := ics.New("VEVENT")
+	uid := ev.ICalUID
+	if uid == "" {
+		uid = ev.Id + "@google.com"
+	}
+	ve.Add("UID", uid)
+	ve.Add("DTSTAMP", stamp.UTC().Format(icsUTCLayout))
+	addICSDateTime(ve, "DTSTART", ev.Start, tzids)
+	addICSDateTime(ve, "DTEND", ev.End, tzids)
+	if ev.OriginalStartTime != nil {
+		addICSDateTime(ve, "RECURRENCE-ID", ev.OriginalStartTime, tzids)
+	}
+	ve.AddText("SUMMARY", ev.Summary)
+	ve.AddText("DESCRIPTION", ev.Description)
+	ve.AddText("LOCATION", ev.Location)
+	if ev.Status != "" {
+		ve.Add("STATUS", strings.ToUpper(ev.Status))
+	}
+	if ev.Transparency != "" {
+		ve.Add("TRANSP", strings.ToUpper(ev.Transparency))
+	}
+	switch ev.Visibility {
+	case "public", "private", "confidential":
+		ve.Add("CLASS", strings.ToUpper(ev.Visibility))
+	}
+	if ev.Sequence > 0 {
+		ve.Add("SEQUENCE", strconv.FormatInt(ev.Sequence, 10))
+	}
+	if ev.Updated != "" {
+		if t, err := time.Parse(time.RFC3339, ev.Updated); err == nil {
+			ve.Add("LAST-MODIFIED", t.UTC().Format(icsUTCLayout))
+		}
+	}
+	if ev.HtmlLink != "" {
+		ve.Add("URL", ev.HtmlLink)
+	}
+
+	for _, rule := range ev.Recurrence {
+		prop, err := ics.ParseLine(strings.TrimSpace(rule))
+		if err != nil {
+			continue
+		}
+		if tz := prop.Param("TZID"); tz != "" {
+			tzids[tz] = struct{}{}
+		}
+		ve.Properties = append(ve.Properties, prop)
+	}
+
+	if ev.Organizer != nil && ev.Organizer.Email != "" {
+		var params []ics.Param
+		if ev.Organizer.DisplayName != "" {
+			params = append(params, ics.Param{Name: "CN", Value: ev.Organizer.DisplayName})
+		}
+		ve.Add("ORGANIZER", "mailto:"+ev.Organizer.Email, params...)
+	}
+	for _, a := range ev.Attendees {
+		if a == nil || a.Email == "" {
+			continue
+		}
+		ve.Add("ATTENDEE", "mailto:"+a.Email, attendeeICSParams(a)...)
+	}
+
+	if ev.Reminders != nil {
+		for _, r := range ev.Reminders.Overrides {
+			if r == nil {
+				continue
+			}
+			alarm := ics.New("VALARM")
+			action := "DISPLAY"
+			if r.Method == "email" {
+				action = "EMAIL"
+			}
+			alarm.Add("ACTION", action)
+			alarm.Add("TRIGGER", fmt.Sprintf("-PT%dM", r.Minutes))
+			alarm.AddText("DESCRIPTION", firstNonEmpty(ev.Summary, "Reminder"))
+			if action == "EMAIL" {
+				alarm.AddText("SUMMARY", firstNonEmpty(ev.Summary, "Reminder"))
+			}
+			ve.AddChild(alarm)
+		}
+	}
+
+	addEventTypeICSProps(ve, ev)
+	return ve
+}
+
+func addEventTypeICSProps(ve *ics.Component, ev *calendar.Event) {
+	switch ev.EventType {
+	case eventTypeFocusTime:
+		ve.Add(icsXEventType, eventTypeFocusTime)
+		if p := ev.FocusTimeProperties; p != nil {
+			ve.AddText(icsXAutoDecline, p.AutoDeclineMode)
+			ve.AddText(icsXDeclineMessage, p.DeclineMessage)
+			ve.AddText(icsXChatStatus, p.ChatStatus)
+		}
+	case eventTypeOutOfOffice:
+		ve.Add(icsXEventType, eventTypeOutOfOffice)
+		ve.Add(icsXMSBusyStatus, "OOF")
+		if p := ev.OutOfOfficeProperties; p != nil {
+			ve.AddText(icsXAutoDecline, p.AutoDeclineMode)
+			ve.AddText(icsXDeclineMessage, p.DeclineMessage)
+		}
+	case eventTypeWorkingLocation:
+		ve.Add(icsXEventType, eventTypeWorkingLocation)
+		if p := ev.WorkingLocationProperties; p != nil {
+			ve.AddText(icsXWorkingType, p.Type)
+			switch {
+			case p.OfficeLocation != nil:
+				ve.AddText(icsXWorkingLabel, p.OfficeLocation.Label)
+				ve.AddText(icsXWorkingBldg, p.OfficeLocation.BuildingId)
+				ve.AddText(icsXWorkingFloor, p.OfficeLocation.FloorId)
+				ve.AddText(icsXWorkingDesk, p.OfficeLocation.DeskId)
+			case p.CustomLocation != nil:
+				ve.AddText(icsXWorkingLabel, p.CustomLocation.Label)
+			}
+		}
+	}
+}
+
+func attendeeICSParams(a *calendar.EventAttendee) []ics.Param {
+	var params []ics.Param
+	if a.DisplayName != "" {
+		params = append(params, ics.Param{Name: "CN", Value: a.DisplayName})
+	}
+	if a.Resource {
+		params = append(params, ics.Param{Name: "CUTYPE", Value: "RESOURCE"})
+	}
+	role := "REQ-PARTICIPANT"
+	if a.Optional {
+		role = "OPT-PARTICIPANT"
+	}
+	params = append(params, ics.Param{Name: "ROLE", Value: role})
+	switch a.ResponseStatus {
+	case "accepted":
+		params = append(params, ics.Param{Name: "PARTSTAT", Value: "ACCEPTED"})
+	case "declined":
+		params = append(params, ics.Param{Name: "PARTSTAT", Value: "DECLINED"})
+	case "tentative":
+		params = append(params, ics.Param{Name: "PARTSTAT", Value: "TENTATIVE"})
+	default:
+		params = append(params, ics.Param{Name: "PARTSTAT", Value: "NEEDS-ACTION"})
+	}
+	return params
+}
+
+func addICSDateTime(ve *ics.Component, name string, dt *calendar.EventDateTime, tzids map[string]struct{}) {
+	if dt == nil {
+		return
+	}
+	if dt.Date != "" {
+		if t, err := time.Parse("2006-01-02", dt.Date); err == nil {
+			ve.Add(name, t.Format(icsDateLayout), ics.Param{Name: "VALUE", Value: "DATE"})
+		}
+		return
+	}
+	t, ok := parseEventTime(dt.DateTime, dt.TimeZone)
+	if !ok {
+		return
+	}
+	if _, ok := loadEventLocation(dt.TimeZone); ok && !strings.EqualFold(dt.TimeZone, "UTC") {
+		tzids[dt.TimeZone] = struct{}{}
+		ve.Add(name, t.Format(icsLocalLayout), ics.Param{Name: "TZID", Value: dt.TimeZone})
+		return
+	}
+	ve.Add(name, t.UTC().Format(icsUTCLayout))
+}
+
+// buildVTimezone describes tzid's offset transitions in the given year as a
+// VTIMEZONE with yearly STANDARD/DAYLIGHT rules.
+func buildVTimezone(tzid string, year int) *ics.Component {
+	loc, err := time.LoadLocation(tzid)
+	if err != nil {
+		return nil
+	}
+	vtz := ics.New("VTIMEZONE")
+	vtz.Add("TZID", tzid)
+	vtz.Add("X-LIC-LOCATION", tzid)
+
+	start := time.Date(year, 1, 1, 0, 0, 0, 0, loc)
+	var transitions []time.Time
+	for t := start; ; {
+		_, end := t.ZoneBounds()
+		if end.IsZero() || end.Year() > year {
+			break
+		}
+		transitions = append(transitions, end)
+		t = end
+	}
+
+	if len(transitions) == 0 {
+		name, offset := start.Zone()
+		std := ics.New("STANDARD")
+		std.Add("DTSTART", "19700101T000000")
+		std.Add("TZOFFSETFROM", formatICSOffset(offset))
+		std.Add("TZOFFSETTO", formatICSOffset(offset))
+		std.AddText("TZNAME", name)
+		vtz.AddChild(std)
+		return vtz
+	}
+
+	for _, at := range transitions {
+		_, fromOffset := at.Add(-time.Second).In(loc).Zone()
+		name, toOffset := at.In(loc).Zone()
+		kind := "STANDARD"
+		if at.In(loc).IsDST() {
+			kind = "DAYLIGHT"
+		}
+		wall := at.In(time.FixedZone("", fromOffset))
+		sub := ics.New(kind)
+		sub.Add("DTSTART", wall.Format(icsLocalLayout))
+		sub.Add("TZOFFSETFROM", formatICSOffset(fromOffset))
+		sub.Add("TZOFFSETTO", formatICSOffset(toOffset))
+		sub.AddText("TZNAME", name)
+		sub.Add("RRULE", yearlyByDayRule(wall))
+		vtz.AddChild(sub)
+	}
+	return vtz
+}
+
+func yearlyByDayRule(t time.Time) string {
+	weekdays := []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+	n := (t.Day()-1)/7 + 1
+	daysInMonth := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if t.Day()+7 > daysInMonth {
+		n = -1
+	}
+	return fmt.Sprintf("FREQ=YEARLY;BYMONTH=%d;BYDAY=%d%s", int(t.Month()), n, weekdays[t.Weekday()])
+}
+
+func formatICSOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, seconds/3600, (seconds%3600)/60)
+}
+
+func parseICSOffset(value string) (int, bool) {
+	value = strings.TrimSpace(value)
+	if len(value) < 5 || (value[0] != '+' && value[0] != '-') {
+		return 0, false
+	}
+	h, err1 := strconv.Atoi(value[1:3])
+	m, err2 := strconv.Atoi(value[3:5])
+	if err1 != nil || err2 != nil {
+		return 0, false
+	}
+	seconds := h*3600 + m*60
+	if value[0] == '-' {
+		seconds = -seconds
+	}
+	return seconds, true
+}
+
+// icsTimezoneMap resolves VTIMEZONE TZIDs to IANA names Google accepts: the
+// TZID itself, X-LIC-LOCATION, or an Etc/GMT zone for the standard offset.
+func icsTimezoneMap(cal *ics.Component) map[string]string {
+	out := map[string]string{}
+	for _, vtz := range cal.Components("VTIMEZONE") {
+		tzid := vtz.Value("TZID")
+		if tzid == "" {
+			continue
+		}
+		if _, ok := loadEventLocation(tzid); ok {
+			out[tzid] = tzid
+			continue
+		}
+		if lic := vtz.Value("X-LIC-LOCATION"); lic != "" {
+			if _, ok := loadEventLocation(lic); ok {
+				out[tzid] = lic
+				continue
+			}
+		}
+		subs := vtz.Components("STANDARD")
+		if len(subs) == 0 {
+			subs = vtz.Components("DAYLIGHT")
+		}
+		if len(subs) > 0 {
+			if offset, ok := parseICSOffset(subs[0].Value("TZOFFSETTO")); ok {
+				if name, ok := etcGMTForOffsetSeconds(offset); ok {
+					out[tzid] = name
+				}
+			}
+		}
+	}
+	return out
+}
+
+// icsToEvents maps every VEVENT in cal. Floating times use defaultTZ; when it
+// is empty they are left without a timezone for the caller to fill in.
+func icsToEvents(cal *ics.Component, defaultTZ string) ([]*calendar.Event, error) {
+	tzMap := icsTimezoneMap(cal)
+	var events []*calendar.Event
+	for i, ve := range cal.Components("VEVENT") {
+		ev, err := veventToEvent(ve, tzMap, defaultTZ)
+		if err != nil {
+			return nil, fmt.Errorf("VEVENT %d (%s): %w", i+1, ve.Value("UID"), err)
+		}
+		events = append(events, ev)
+	}
+	return events, nil
+}
+
+func veventToEvent(ve *ics.Component, tzMap map[string]string, defaultTZ string) (*calendar.Event, error) {
+	uid := strings.TrimSpace(ve.Value("UID"))
+	if uid == "" {
+		return nil, fmt.Errorf("missing UID")
+	}
+	startProp, ok := ve.Prop("DTSTART")
+	if !ok {
+		return nil, fmt.Errorf("missing DTSTART")
+	}
+	start, startTime, err := icsPropToEventDateTime(startProp, tzMap, defaultTZ)
+	if err != nil {
+		return nil, fmt.Errorf("DTSTART: %w", err)
+	}
+
+	var end *calendar.EventDateTime
+	if endProp, ok := ve.Prop("DTEND"); ok {
+		end, _, err = icsPropToEventDateTime(endProp, tzMap, defaultTZ)
+		if err != nil {
+			return nil, fmt.Errorf("DTEND: %w", err)
+		}
+	} else {
+		dur := 24 * time.Hour
+		if start.Date == "" {
+			dur = 0
+		}
+		if raw := ve.Value("DURATION"); raw != "" {
+			dur, err = parseICSDuration(raw)
+			if err != nil {
+				return nil, fmt.Errorf("DURATION: %w", err)
+			}
+		}
+		end = shiftEventDateTime(start, startTime, dur)
+	}
+
+	ev := &calendar.Event{
+		ICalUID:     uid,
+		Summary:     ve.Text("SUMMARY"),
+		Description: ve.Text("DESCRIPTION"),
+		Location:    ve.Text("LOCATION"),
+		Start:       start,
+		End:         end,
+	}
+	if status := strings.ToLower(ve.Value("STATUS")); status == "confirmed" || status == "tentative" || status == "cancelled" {
+		ev.Status = status
+	}
+	if transp := strings.ToLower(ve.Value("TRANSP")); transp == "opaque" || transp == "transparent" {
+		ev.Transparency = transp
+	}
+	if class := strings.ToLower(ve.Value("CLASS")); class == "public" || class == "private" || class == "confidential" {
+		ev.Visibility = class
+	}
+	if seq, err := strconv.ParseInt(ve.Value("SEQUENCE"), 10, 64); err == nil && seq > 0 {
+		ev.Sequence = seq
+	}
+	if rid, ok := ve.Prop("RECURRENCE-ID"); ok {
+		ev.OriginalStartTime, _, err = icsPropToEventDateTime(rid, tzMap, defaultTZ)
+		if err != nil {
+			return nil, fmt.Errorf("RECURRENCE-ID: %w", err)
+		}
+	}
+
+	for _, p := range ve.Properties {
+		switch p.Name {
+		case "RRULE", "EXRULE", "RDATE", "EXDATE":
+			if tz := p.Param("TZID"); tz != "" {
+				if mapped, ok := tzMap[tz]; ok {
+					p.SetParam("TZID", mapped)
+				}
+			}
+			ev.Recurrence = append(ev.Recurrence, p.String())
+		}
+	}
+	if len(ev.Recurrence) > 0 {
+		// Google requires an explicit timezone on recurring timed events.
+		for _, dt := range []*calendar.EventDateTime{ev.Start, ev.End} {
+			if dt.DateTime != "" && dt.TimeZone == "" && strings.HasSuffix(dt.DateTime, "Z") {
+				dt.TimeZone = "UTC"
+			}
+		}
+	}
+
+	if org, ok := ve.Prop("ORGANIZER"); ok {
+		if email := icsMailto(org.Value); email != "" {
+			ev.Organizer = &calendar.EventOrganizer{Email: email, DisplayName: org.Param("CN")}
+		}
+	}
+	for _, p := range ve.Props("ATTENDEE") {
+		email := icsMailto(p.Value)
+		if email == "" {
+			continue
+		}
+		ev.Attendees = append(ev.Attendees, &calendar.EventAttendee{
+			Email:          email,
+			DisplayName:    p.Param("CN"),
+			Optional:       strings.EqualFold(p.Param("ROLE"), "OPT-PARTICIPANT"),
+			Resource:       strings.EqualFold(p.Param("CUTYPE"), "RESOURCE") || strings.EqualFold(p.Param("CUTYPE"), "ROOM"),
+			ResponseStatus: icsPartstatToResponse(p.Param("PARTSTAT")),
+		})
+	}
+
+	if reminders := valarmsToReminders(ve.Components("VALARM")); reminders != nil {
+		ev.Reminders = reminders
+	}
+	applyEventTypeICSProps(ev, ve)
+	return ev, nil
+}
+
+func applyEventTypeICSProps(ev *calendar.Event, ve *ics.Component) {
+	eventType := ve.Value(icsXEventType)
+	if eventType == "" && strings.EqualFold(ve.Value(icsXMSBusyStatus), "OOF") {
+		eventType = eventTypeOutOfOffice
+	}
+	normalized, err := normalizeEventType(eventType)
+	if err != nil || normalized == "" || normalized == eventTypeDefault {
+		return
+	}
+	ev.EventType = normalized
+	switch normalized {
+	case eventTypeFocusTime:
+		ev.FocusTimeProperties = &calendar.EventFocusTimeProperties{
+			AutoDeclineMode: firstNonEmpty(ve.Text(icsXAutoDecline), "declineNone"),
+			DeclineMessage:  ve.Text(icsXDeclineMessage),
+			ChatStatus:      ve.Text(icsXChatStatus),
+		}
+	case eventTypeOutOfOffice:
+		ev.OutOfOfficeProperties = &calendar.EventOutOfOfficeProperties{
+			AutoDeclineMode: firstNonEmpty(ve.Text(icsXAutoDecline), "declineNone"),
+			DeclineMessage:  ve.Text(icsXDeclineMessage),
+		}
+	case eventTypeWorkingLocation:
+		props := &calendar.EventWorkingLocationProperties{Type: ve.Text(icsXWorkingType)}
+		switch props.Type {
+		case "officeLocation":
+			props.OfficeLocation = &calendar.EventWorkingLocationPropertiesOfficeLocation{
+				Label:      ve.Text(icsXWorkingLabel),
+				BuildingId: ve.Text(icsXWorkingBldg),
+				FloorId:    ve.Text(icsXWorkingFloor),
+				DeskId:     ve.Text(icsXWorkingDesk),
+			}
+		case "customLocation":
+			props.CustomLocation = &calendar.EventWorkingLocationPropertiesCustomLocation{Label: ve.Text(icsXWorkingLabel)}
+		default:
+			props.Type = "homeOffice"
+			props.HomeOffice = map[string]any{}
+		}
+		ev.WorkingLocationProperties = props
+	}
+}
+
+func valarmsToReminders(alarms []*ics.Component) *calendar.EventReminders {
+	var overrides []*calendar.EventReminder
+	for _, alarm := range alarms {
+		trigger, _ := alarm.Prop("TRIGGER")
+		if trigger.Value == "" || strings.EqualFold(trigger.Param("VALUE"), "DATE-TIME") {
+			continue
+		}
+		d, err := parseICSDuration(trigger.Value)
+		if err != nil || d > 0 {
+			continue
+		}
+		method := "popup"
+		if strings.EqualFold(alarm.Value("ACTION"), "EMAIL") {
+			method = "email"
+		}
+		overrides = append(overrides, &calendar.EventReminder{Method: method, Minutes: int64(-d / time.Minute)})
+	}
+	if len(overrides) == 0 {
+		return nil
+	}
+	if len(overrides) > 5 {
+		overrides = overrides[:5]
+	}
+	return &calendar.EventReminders{
+		UseDefault:      false,
+		Overrides:       overrides,
+		ForceSendFields: []string{"UseDefault"},
+	}
+}
+
+// icsPropToEventDateTime converts a DATE, UTC, TZID or floating DATE-TIME
+// value. The returned time is zero for floating values without a timezone.
+func icsPropToEventDateTime(p ics.Property, tzMap map[string]string, defaultTZ string) (*calendar.EventDateTime, time.Time, error) {
+	value := strings.TrimSpace(p.Value)
+	if strings.EqualFold(p.Param("VALUE"), "DATE") || len(value) == len(icsDateLayout) {
+		t, err := time.Parse(icsDateLayout, value)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		return &calendar.EventDateTime{Date: t.Format("2006-01-02")}, t, nil
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(icsUTCLayout, value)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		return &calendar.EventDateTime{DateTime: t.Format(time.RFC3339)}, t, nil
+	}
+
+	tz := defaultTZ
+	if tzid := p.Param("TZID"); tzid != "" {
+		tz = tzid
+		if mapped, ok := tzMap[tzid]; ok {
+			tz = mapped
+		}
+	}
+	loc, ok := loadEventLocation(tz)
+	if !ok {
+		if _, err := time.Parse(icsLocalLayout, value); err != nil {
+			return nil, time.Time{}, err
+		}
+		if tz != "" {
+			return nil, time.Time{}, fmt.Errorf("unknown timezone %q", tz)
+		}
+		t, _ := time.Parse(icsLocalLayout, value)
+		return &calendar.EventDateTime{DateTime: t.Format(icsFloatingLayout)}, time.Time{}, nil
+	}
+	t, err := time.ParseInLocation(icsLocalLayout, value, loc)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return &calendar.EventDateTime{DateTime: t.Format(time.RFC3339), TimeZone: tz}, t, nil
+}
+
+func shiftEventDateTime(start *calendar.EventDateTime, startTime time.Time, d time.Duration) *calendar.EventDateTime {
+	if start.Date != "" {
+		days := int(d / (24 * time.Hour))
+		if days < 1 {
+			days = 1
+		}
+		return &calendar.EventDateTime{Date: startTime.AddDate(0, 0, days).Format("2006-01-02")}
+	}
+	if startTime.IsZero() {
+		t, _ := time.Parse(icsFloatingLayout, start.DateTime)
+		return &calendar.EventDateTime{DateTime: t.Add(d).Format(icsFloatingLayout)}
+	}
+	return &calendar.EventDateTime{DateTime: startTime.Add(d).Format(time.RFC3339), TimeZone: start.TimeZone}
+}
+
+// fillFloatingTimezone assigns tz to floating DATE-TIME values left by icsToEvents.
+func fillFloatingTimezone(ev *calendar.Event, tz string) {
+	for _, dt := range []*calendar.EventDateTime{ev.Start, ev.End, ev.OriginalStartTime} {
+		if dt != nil && dt.DateTime != "" && dt.TimeZone == "" && !strings.HasSuffix(dt.DateTime, "Z") && !hasRFC3339Offset(dt.DateTime) {
+			dt.TimeZone = tz
+		}
+	}
+}
+
+func hasRFC3339Offset(value string) bool {
+	_, err := time.Parse(time.RFC3339, value)
+	return err == nil
+}
+
+// parseICSDuration parses an RFC 5545 DURATION such as -PT15M or P1DT2H.
+func parseICSDuration(value string) (time.Duration, error) {
+	m := icsDurationPattern.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(value)))
+	if m == nil || value == "P" || value == "PT" {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var d time.Duration
+	for i, unit := range units {
+		if m[i+2] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(m[i+2])
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		d += time.Duration(n) * unit
+	}
+	if m[1] == "-" {
+		d = -d
+	}
+	return d, nil
+}
+
+func icsMailto(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 7 && strings.EqualFold(value[:7], "mailto:") {
+		value = value[7:]
+	}
+	if !strings.Contains(value, "@") {
+		return ""
+	}
+	return value
+}
+
+func icsPartstatToResponse(partstat string) string {
+	switch strings.ToUpper(partstat) {
+	case "ACCEPTED":
+		return "accepted"
+	case "DECLINED":
+		return "declined"
+	case "TENTATIVE":
+		return "tentative"
+	default:
+		return "needsAction"
+	}
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/steipete/gogcli/internal/ics"
+)
+
+func TestCalendarICSRoundTrip(t *testing.T) {
+	events := []*calendar.Event{
+		{
+			Id:          "standup",
+			ICalUID:     "standup@example.com",
+			Summary:     "Standup, daily",
+			Description: "Line one\nLine two",
+			Start:       &calendar.EventDateTime{DateTime: "2026-01-05T10:00:00+01:00", TimeZone: "Europe/Berlin"},
+			End:         &calendar.EventDateTime{DateTime: "2026-01-05T10:15:00+01:00", TimeZone: "Europe/Berlin"},
+			Recurrence:  []string{"RRULE:FREQ=WEEKLY;BYDAY=MO", "EXDATE;TZID=Europe/Berlin:20260112T100000"},
+			Organizer:   &calendar.EventOrganizer{Email: "boss@example.com", DisplayName: "Boss"},
+			Attendees: []*calendar.EventAttendee{
+				{Email: "a@example.com", DisplayName: "Doe, Jane", ResponseStatus: "accepted"},
+				{Email: "b@example.com", Optional: true},
+			},
+			Reminders: &calendar.EventReminders{Overrides: []*calendar.EventReminder{{Method: "popup", Minutes: 10}, {Method: "email", Minutes: 1440}}},
+		},
+		{
+			Id:        "holiday",
+			Summary:   "Holiday",
+			Start:     &calendar.EventDateTime{Date: "2026-02-02"},
+			End:       &calendar.EventDateTime{Date: "2026-02-04"},
+			EventType: eventTypeOutOfOffice,
+			OutOfOfficeProperties: &calendar.EventOutOfOfficeProperties{
+				AutoDeclineMode: "declineAllConflictingInvitations",
+				DeclineMessage:  "Away; back soon",
+			},
+		},
+	}
+
+	cal := eventsToICS(events, icsCalendarMeta{Name: "Work", Timezone: "Europe/Berlin"}, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	var buf bytes.Buffer
+	if err := ics.Encode(&buf, cal); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"BEGIN:VTIMEZONE\r\nTZID:Europe/Berlin",
+		"RRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=-1SU",
+		"DTSTART;TZID=Europe/Berlin:20260105T100000",
+		"DTSTART;VALUE=DATE:20260202",
+		"UID:holiday@google.com",
+		"X-GOG-EVENT-TYPE:outOfOffice",
+		"TRIGGER:-PT1440M",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("missing %q in:\n%s", want, out)
+		}
+	}
+
+	roots, err := ics.Parse(&buf)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	got, err := icsToEvents(roots[0], "")
+	if err != nil {
+		t.Fatalf("icsToEvents: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(got))
+	}
+
+	standup := got[0]
+	if standup.ICalUID != "standup@example.com" || standup.Summary != "Standup, daily" || standup.Description != "Line one\nLine two" {
+		t.Fatalf("unexpected standup fields: %+v", standup)
+	}
+	if standup.Start.DateTime != "2026-01-05T10:00:00+01:00" || standup.Start.TimeZone != "Europe/Berlin" {
+		t.Fatalf("unexpected start: %+v", standup.Start)
+	}
+	if strings.Join(standup.Recurrence, "|") != "RRULE:FREQ=WEEKLY;BYDAY=MO|EXDATE;TZID=Europe/Berlin:20260112T100000" {
+		t.Fatalf("unexpected recurrence: %v", standup.Recurrence)
+	}
+	if len(standup.Attendees) != 2 || standup.Attendees[0].DisplayName != "Doe, Jane" || standup.Attendees[0].ResponseStatus != "accepted" || !standup.Attendees[1].Optional {
+		t.Fatalf("unexpected attendees: %+v", standup.Attendees)
+	}
+	if standup.Organizer == nil || standup.Organizer.Email != "boss@example.com" {
+		t.Fatalf("unexpected organizer: %+v", standup.Organizer)
+	}
+	if r := standup.Reminders; r == nil || len(r.Overrides) != 2 || r.Overrides[1].Method != "email" || r.Overrides[1].Minutes != 1440 {
+		t.Fatalf("unexpected reminders: %+v", standup.Reminders)
+	}
+
+	holiday := got[1]
+	if holiday.Start.Date != "2026-02-02" || holiday.End.Date != "2026-02-04" {
+		t.Fatalf("unexpected all-day bounds: %+v %+v", holiday.Start, holiday.End)
+	}
+	if holiday.EventType != eventTypeOutOfOffice || holiday.OutOfOfficeProperties == nil || holiday.OutOfOfficeProperties.DeclineMessage != "Away; back soon" {
+		t.Fatalf("unexpected OOO mapping: %+v", holiday)
+	}
+}
+
+func TestICSToEvents_ForeignTimezonesAndDuration(t *testing.T) {
+	src := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VTIMEZONE",
+		"TZID:W. Europe Standard Time",
+		"BEGIN:STANDARD",
+		"DTSTART:16010101T030000",
+		"TZOFFSETFROM:+0200",
+		"TZOFFSETTO:+0100",
+		"END:STANDARD",
+		"END:VTIMEZONE",
+		"BEGIN:VEVENT",
+		"UID:ms-1",
+		"DTSTART;TZID=W. Europe Standard Time:20260105T090000",
+		"DURATION:PT1H30M",
+		"X-MICROSOFT-CDO-BUSYSTATUS:OOF",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:floating-1",
+		"DTSTART:20260106T080000",
+		"DTEND:20260106T090000",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+	roots, err := ics.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	events, err := icsToEvents(roots[0], "")
+	if err != nil {
+		t.Fatalf("icsToEvents: %v", err)
+	}
+	ms := events[0]
+	if ms.Start.TimeZone != "Etc/GMT-1" || ms.Start.DateTime != "2026-01-05T09:00:00+01:00" || ms.End.DateTime != "2026-01-05T10:30:00+01:00" {
+		t.Fatalf("unexpected mapped times: %+v %+v", ms.Start, ms.End)
+	}
+	if ms.EventType != eventTypeOutOfOffice {
+		t.Fatalf("expected OOF to map to outOfOffice, got %q", ms.EventType)
+	}
+
+	floating := events[1]
+	if floating.Start.DateTime != "2026-01-06T08:00:00" || floating.Start.TimeZone != "" {
+		t.Fatalf("unexpected floating start: %+v", floating.Start)
+	}
+	fillFloatingTimezone(floating, "America/New_York")
+	if floating.Start.TimeZone != "America/New_York" || floating.End.TimeZone != "America/New_York" {
+		t.Fatalf("floating timezone not filled: %+v", floating.Start)
+	}
+}
+
+func TestParseICSDuration(t *testing.T) {
+	cases := map[string]time.Duration{
+		"-PT15M":  -15 * time.Minute,
+		"P1D":     24 * time.Hour,
+		"P1W":     7 * 24 * time.Hour,
+		"PT1H30M": 90 * time.Minute,
+		"+P1DT2H": 26 * time.Hour,
+	}
+	for in, want := range cases {
+		got, err := parseICSDuration(in)
+		if err != nil || got != want {
+			t.Fatalf("parseICSDuration(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	if _, err := parseICSDuration("15M"); err == nil {
+		t.Fatalf("expected error for invalid duration")
+	}
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type CalendarQuickCmd struct {
+	Text        string `arg:"" name:"text" help:"Event text, e.g. \"Lunch with Sam tomorrow 12:30 at Cafe\""`
+	CalendarID  string `name:"calendar" help:"Calendar ID (default: primary)"`
+	SendUpdates string `name:"send-updates" help:"Notification mode: all, externalOnly, none (default: none)"`
+}
+
+// Run wraps events.quickAdd, letting Google parse the date, time, title and
+// location out of free text.
+func (c *CalendarQuickCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	text := strings.TrimSpace(c.Text)
+	if text == "" {
+		return usage("empty text")
+	}
+	sendUpdates, err := validateSendUpdates(c.SendUpdates)
+	if err != nil {
+		return err
+	}
+	calendarID := firstNonEmpty(strings.TrimSpace(c.CalendarID), primaryCalendarID)
+
+	if dryRunErr := dryRunExit(ctx, flags, "calendar.quick", map[string]any{
+		"calendar_id":  calendarID,
+		"text":         text,
+		"send_updates": sendUpdates,
+	}); dryRunErr != nil {
+		return dryRunErr
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+	calendarID, err = resolveCalendarID(ctx, svc, calendarID)
+	if err != nil {
+		return err
+	}
+
+	call := svc.Events.QuickAdd(calendarID, text).Context(ctx)
+	if sendUpdates != "" {
+		call = call.SendUpdates(sendUpdates)
+	}
+	created, err := call.Do()
+	if err != nil {
+		return err
+	}
+	tz, loc, _ := getCalendarLocation(ctx, svc, calendarID)
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"event": wrapEventWithDaysWithTimezone(created, tz, loc)})
+	}
+	printCalendarEventWithTimezone(u, created, tz, loc)
+	return nil
+}
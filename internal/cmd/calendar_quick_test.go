@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/option"
+)
+
+func TestCalendarQuickCmd(t *testing.T) {
+	origNew := newCalendarService
+	t.Cleanup(func() { newCalendarService = origNew })
+
+	var gotText, gotSendUpdates string
+	srv := httptest.NewServer(withPrimaryCalendar(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/calendars/primary/events/quickAdd") {
+			http.NotFound(w, r)
+			return
+		}
+		gotText = r.URL.Query().Get("text")
+		gotSendUpdates = r.URL.Query().Get("sendUpdates")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"id":       "ev1",
+			"summary":  "Lunch with Sam",
+			"location": "Cafe",
+			"start":    map[string]any{"dateTime": "2026-01-06T12:30:00Z"},
+			"end":      map[string]any{"dateTime": "2026-01-06T13:30:00Z"},
+		})
+	})))
+	defer srv.Close()
+
+	svc, err := calendar.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return svc, nil }
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "calendar", "quick", "Lunch with Sam tomorrow 12:30 at Cafe", "--send-updates", "all"}); err != nil {
+			t.Fatalf("quick: %v", err)
+		}
+	})
+	if gotText != "Lunch with Sam tomorrow 12:30 at Cafe" || gotSendUpdates != "all" {
+		t.Fatalf("unexpected request: text=%q sendUpdates=%q", gotText, gotSendUpdates)
+	}
+	var parsed struct {
+		Event struct {
+			ID       string `json:"id"`
+			Location string `json:"location"`
+		} `json:"event"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if parsed.Event.ID != "ev1" || parsed.Event.Location != "Cafe" {
+		t.Fatalf("unexpected output: %s", out)
+	}
+}
@@ -0,0 +1,368 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	admin "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/steipete/gogcli/internal/errfmt"
+	"github.com/steipete/gogcli/internal/googleapi"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+var newAdminDirectoryService = googleapi.NewAdminDirectoryResources
+
+// adminCustomer addresses the account's own Workspace customer.
+const adminCustomer = "my_customer"
+
+// freeBusyBatch is the maximum number of calendars per freebusy request.
+const freeBusyBatch = 50
+
+type CalendarRoomsCmd struct {
+	List CalendarRoomsListCmd `cmd:"" name:"list" aliases:"ls" default:"withargs" help:"List meeting rooms (Workspace calendar resources)"`
+	Find CalendarRoomsFindCmd `cmd:"" name:"find" aliases:"free,available" help:"Find rooms that are free for a time range"`
+}
+
+// roomFilter narrows the resource list by building, capacity and features.
+type roomFilter struct {
+	Building string `name:"building" help:"Building ID or name"`
+	Capacity int64  `name:"capacity" aliases:"min-capacity" help:"Minimum capacity"`
+	Features string `name:"features" help:"Comma-separated required features (e.g. video,whiteboard)"`
+	All      bool   `name:"all-resources" help:"Include non-room resources (equipment, other)"`
+}
+
+// calendarRoom is a flattened Admin Directory calendar resource.
+type calendarRoom struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Email      string   `json:"email"`
+	Category   string   `json:"category,omitempty"`
+	BuildingID string   `json:"buildingId,omitempty"`
+	Building   string   `json:"building,omitempty"`
+	Floor      string   `json:"floor,omitempty"`
+	Section    string   `json:"section,omitempty"`
+	Capacity   int64    `json:"capacity,omitempty"`
+	Features   []string `json:"features,omitempty"`
+}
+
+type CalendarRoomsListCmd struct {
+	roomFilter
+}
+
+func (c *CalendarRoomsListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newAdminDirectoryService(ctx, account)
+	if err != nil {
+		return err
+	}
+	rooms, err := listCalendarRooms(ctx, svc, c.roomFilter)
+	if err != nil {
+		return wrapAdminDirectoryError(err, account)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"rooms": rooms})
+	}
+	if len(rooms) == 0 {
+		u.Err().Println("No rooms")
+		return nil
+	}
+	printCalendarRooms(ctx, rooms)
+	return nil
+}
+
+type CalendarRoomsFindCmd struct {
+	roomFilter
+	From     string `name:"from" required:"" help:"Start time (RFC3339, date, or relative: 'tomorrow 3pm', '+30m')"`
+	To       string `name:"to" help:"End time (same forms as --from)"`
+	Duration string `name:"duration" help:"Length instead of --to (e.g. 30m, 1h)"`
+	Timezone string `name:"timezone" help:"Timezone for relative --from/--to (IANA name; default: configured timezone or local)"`
+	Max      int    `name:"max" aliases:"limit" help:"Number of rooms to show (0 = all)" default:"0"`
+}
+
+func (c *CalendarRoomsFindCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	if strings.TrimSpace(c.To) == "" && strings.TrimSpace(c.Duration) == "" {
+		return usage("required: --to or --duration")
+	}
+	loc, err := resolveOutputLocation(c.Timezone, false)
+	if err != nil {
+		return err
+	}
+	fromStr, toStr, err := resolveCreateTimes(c.From, c.To, c.Duration, false, time.Now().In(loc), loc)
+	if err != nil {
+		return err
+	}
+	window, err := parseRoomWindow(fromStr, toStr)
+	if err != nil {
+		return err
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	adminSvc, err := newAdminDirectoryService(ctx, account)
+	if err != nil {
+		return err
+	}
+	calSvc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+	rooms, err := listCalendarRooms(ctx, adminSvc, c.roomFilter)
+	if err != nil {
+		return wrapAdminDirectoryError(err, account)
+	}
+	free, err := freeCalendarRooms(ctx, calSvc, rooms, window)
+	if err != nil {
+		return err
+	}
+	if c.Max > 0 && len(free) > c.Max {
+		free = free[:c.Max]
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"timeMin": window.Start.Format(time.RFC3339),
+			"timeMax": window.End.Format(time.RFC3339),
+			"rooms":   free,
+		})
+	}
+	if len(free) == 0 {
+		u.Err().Printf("No free room between %s and %s", window.Start.Format(time.RFC3339), window.End.Format(time.RFC3339))
+		return nil
+	}
+	printCalendarRooms(ctx, free)
+	return nil
+}
+
+func parseRoomWindow(fromStr, toStr string) (timeInterval, error) {
+	start, err := time.Parse(time.RFC3339, fromStr)
+	if err != nil {
+		return timeInterval{}, usagef("rooms need a timed range, got --from %q", fromStr)
+	}
+	end, err := time.Parse(time.RFC3339, toStr)
+	if err != nil {
+		return timeInterval{}, usagef("rooms need a timed range, got --to %q", toStr)
+	}
+	if !end.After(start) {
+		return timeInterval{}, usage("--to must be after --from")
+	}
+	return timeInterval{Start: start, End: end}, nil
+}
+
+// listCalendarRooms reads all calendar resources and buildings for the
+// customer and returns the ones matching the filter, sorted by building,
+// capacity and name.
+func listCalendarRooms(ctx context.Context, svc *admin.Service, filter roomFilter) ([]calendarRoom, error) {
+	resources, err := collectAllPages("", func(pageToken string) ([]*admin.CalendarResource, string, error) {
+		call := svc.Resources.Calendars.List(adminCustomer).MaxResults(500).Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Items, resp.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	buildings, err := collectAllPages("", func(pageToken string) ([]*admin.Building, string, error) {
+		call := svc.Resources.Buildings.List(adminCustomer).MaxResults(500).Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Buildings, resp.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	buildingNames := make(map[string]string, len(buildings))
+	for _, b := range buildings {
+		if b != nil {
+			buildingNames[b.BuildingId] = b.BuildingName
+		}
+	}
+
+	out := make([]calendarRoom, 0, len(resources))
+	for _, r := range resources {
+		if r == nil || r.ResourceEmail == "" {
+			continue
+		}
+		room := calendarRoom{
+			ID:         r.ResourceId,
+			Name:       r.ResourceName,
+			Email:      r.ResourceEmail,
+			Category:   r.ResourceCategory,
+			BuildingID: r.BuildingId,
+			Building:   buildingNames[r.BuildingId],
+			Floor:      r.FloorName,
+			Section:    r.FloorSection,
+			Capacity:   r.Capacity,
+			Features:   calendarResourceFeatures(r.FeatureInstances),
+		}
+		if filter.matches(room) {
+			out = append(out, room)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Building != out[j].Building {
+			return out[i].Building < out[j].Building
+		}
+		if out[i].Capacity != out[j].Capacity {
+			return out[i].Capacity < out[j].Capacity
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out, nil
+}
+
+func (f roomFilter) matches(room calendarRoom) bool {
+	if !f.All && room.Category != "" && room.Category != "CONFERENCE_ROOM" {
+		return false
+	}
+	if f.Capacity > 0 && room.Capacity < f.Capacity {
+		return false
+	}
+	if b := strings.TrimSpace(f.Building); b != "" &&
+		!strings.EqualFold(room.BuildingID, b) && !strings.EqualFold(room.Building, b) {
+		return false
+	}
+	for _, want := range splitCSV(f.Features) {
+		if !roomHasFeature(room, want) {
+			return false
+		}
+	}
+	return true
+}
+
+// roomHasFeature matches case-insensitively on a substring so "video" finds
+// "Video conferencing".
+func roomHasFeature(room calendarRoom, want string) bool {
+	want = strings.ToLower(strings.TrimSpace(want))
+	for _, f := range room.Features {
+		if strings.Contains(strings.ToLower(f), want) {
+			return true
+		}
+	}
+	return false
+}
+
+// calendarResourceFeatures extracts feature names from the untyped
+// featureInstances field ([{"feature": {"name": "..."}}]).
+func calendarResourceFeatures(raw any) []string {
+	items, ok := raw.([]any)
+	if !ok {
+		return nil
+	}
+	var out []string
+	for _, item := range items {
+		m, _ := item.(map[string]any)
+		feature, _ := m["feature"].(map[string]any)
+		if name, _ := feature["name"].(string); name != "" {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+// freeCalendarRooms checks freebusy for every room and returns those with no
+// busy time in the window, smallest adequate room first.
+func freeCalendarRooms(ctx context.Context, svc *calendar.Service, rooms []calendarRoom, window timeInterval) ([]calendarRoom, error) {
+	u := ui.FromContext(ctx)
+	var free []calendarRoom
+	for start := 0; start < len(rooms); start += freeBusyBatch {
+		batch := rooms[start:min(start+freeBusyBatch, len(rooms))]
+		items := make([]*calendar.FreeBusyRequestItem, 0, len(batch))
+		for _, r := range batch {
+			items = append(items, &calendar.FreeBusyRequestItem{Id: r.Email})
+		}
+		resp, err := svc.Freebusy.Query(&calendar.FreeBusyRequest{
+			TimeMin: window.Start.Format(time.RFC3339),
+			TimeMax: window.End.Format(time.RFC3339),
+			Items:   items,
+		}).Context(ctx).Do()
+		if err != nil {
+			return nil, fmt.Errorf("freebusy query: %w", err)
+		}
+		for _, r := range batch {
+			cal, ok := resp.Calendars[r.Email]
+			if !ok {
+				continue
+			}
+			if len(cal.Errors) > 0 {
+				u.Err().Printf("Warning: free/busy unavailable for %s (%s); skipping", r.Email, cal.Errors[0].Reason)
+				continue
+			}
+			if !overlapsAny(window, freeBusyIntervals(cal)) {
+				free = append(free, r)
+			}
+		}
+	}
+	sort.SliceStable(free, func(i, j int) bool {
+		if free[i].Capacity != free[j].Capacity {
+			return free[i].Capacity < free[j].Capacity
+		}
+		return free[i].Name < free[j].Name
+	})
+	return free, nil
+}
+
+func printCalendarRooms(ctx context.Context, rooms []calendarRoom) {
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "NAME\tCAPACITY\tBUILDING\tFLOOR\tFEATURES\tEMAIL")
+	for _, r := range rooms {
+		building := r.Building
+		if building == "" {
+			building = r.BuildingID
+		}
+		capacity := ""
+		if r.Capacity > 0 {
+			capacity = fmt.Sprintf("%d", r.Capacity)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			sanitizeTab(r.Name),
+			capacity,
+			sanitizeTab(building),
+			sanitizeTab(strings.TrimSpace(r.Floor+" "+r.Section)),
+			sanitizeTab(strings.Join(r.Features, ", ")),
+			r.Email,
+		)
+	}
+}
+
+// wrapAdminDirectoryError provides helpful error messages for common Admin SDK issues.
+func wrapAdminDirectoryError(err error, account string) error {
+	errStr := err.Error()
+	if strings.Contains(errStr, "accessNotConfigured") ||
+		strings.Contains(errStr, "Admin SDK API has not been used") {
+		return errfmt.NewUserFacingError("Admin SDK API is not enabled; enable it at: https://console.developers.google.com/apis/api/admin.googleapis.com/overview", err)
+	}
+	if strings.Contains(errStr, "insufficientPermissions") ||
+		strings.Contains(errStr, "insufficient authentication scopes") {
+		return errfmt.NewUserFacingError("Insufficient permissions for Admin SDK resources; re-authenticate with the admin.directory.resource.calendar.readonly scope: gog auth add <account> --services resources", err)
+	}
+	if isConsumerAccount(account) {
+		return errfmt.NewUserFacingError("Meeting rooms require a Google Workspace account; consumer accounts (gmail.com/googlemail.com) are not supported.", err)
+	}
+	return err
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	admin "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/option"
+)
+
+func TestRoomFilterMatches(t *testing.T) {
+	room := calendarRoom{Name: "Atlas", Category: "CONFERENCE_ROOM", BuildingID: "hq", Building: "Headquarters", Capacity: 8, Features: []string{"Video conferencing", "Whiteboard"}}
+	for _, tc := range []struct {
+		filter roomFilter
+		want   bool
+	}{
+		{roomFilter{}, true},
+		{roomFilter{Capacity: 8, Features: "video"}, true},
+		{roomFilter{Capacity: 10}, false},
+		{roomFilter{Building: "headquarters"}, true},
+		{roomFilter{Building: "HQ", Features: "video,whiteboard"}, true},
+		{roomFilter{Building: "annex"}, false},
+		{roomFilter{Features: "projector"}, false},
+	} {
+		if got := tc.filter.matches(room); got != tc.want {
+			t.Fatalf("%+v: got %v, want %v", tc.filter, got, tc.want)
+		}
+	}
+	if (roomFilter{}).matches(calendarRoom{Category: "OTHER"}) {
+		t.Fatalf("non-room resources should be skipped by default")
+	}
+	if got := calendarResourceFeatures([]any{map[string]any{"feature": map[string]any{"name": "Video"}}}); len(got) != 1 || got[0] != "Video" {
+		t.Fatalf("unexpected features: %#v", got)
+	}
+}
+
+func roomsTestServices(t *testing.T, inserted *map[string]any) {
+	t.Helper()
+	srv := httptest.NewServer(withPrimaryCalendar(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/resources/calendars"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"items": []map[string]any{
+				{"resourceId": "1", "resourceName": "Big", "resourceEmail": "big@resource.calendar.google.com", "resourceCategory": "CONFERENCE_ROOM", "buildingId": "hq", "capacity": 20,
+					"featureInstances": []any{map[string]any{"feature": map[string]any{"name": "Video conferencing"}}}},
+				{"resourceId": "2", "resourceName": "Small", "resourceEmail": "small@resource.calendar.google.com", "resourceCategory": "CONFERENCE_ROOM", "buildingId": "hq", "capacity": 4,
+					"featureInstances": []any{map[string]any{"feature": map[string]any{"name": "Video conferencing"}}}},
+				{"resourceId": "3", "resourceName": "Mid", "resourceEmail": "mid@resource.calendar.google.com", "resourceCategory": "CONFERENCE_ROOM", "buildingId": "hq", "capacity": 8},
+				{"resourceId": "4", "resourceName": "Busy", "resourceEmail": "busy@resource.calendar.google.com", "resourceCategory": "CONFERENCE_ROOM", "buildingId": "hq", "capacity": 10,
+					"featureInstances": []any{map[string]any{"feature": map[string]any{"name": "Video conferencing"}}}},
+			}})
+		case strings.HasSuffix(r.URL.Path, "/resources/buildings"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"buildings": []map[string]any{{"buildingId": "hq", "buildingName": "Headquarters"}}})
+		case r.URL.Path == "/freeBusy":
+			var req calendar.FreeBusyRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			cals := map[string]any{}
+			for _, item := range req.Items {
+				busy := []map[string]any{}
+				if item.Id == "busy@resource.calendar.google.com" {
+					busy = append(busy, map[string]any{"start": req.TimeMin, "end": req.TimeMax})
+				}
+				cals[item.Id] = map[string]any{"busy": busy}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"calendars": cals})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/calendars/primary/events"):
+			_ = json.NewDecoder(r.Body).Decode(inserted)
+			(*inserted)["id"] = "ev1"
+			_ = json.NewEncoder(w).Encode(*inserted)
+		default:
+			http.NotFound(w, r)
+		}
+	})))
+	t.Cleanup(srv.Close)
+
+	opts := []option.ClientOption{
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL + "/"),
+	}
+	adminSvc, err := admin.NewService(context.Background(), opts...)
+	if err != nil {
+		t.Fatalf("admin.NewService: %v", err)
+	}
+	calSvc, err := calendar.NewService(context.Background(), opts...)
+	if err != nil {
+		t.Fatalf("calendar.NewService: %v", err)
+	}
+	origAdmin, origCal := newAdminDirectoryService, newCalendarService
+	t.Cleanup(func() { newAdminDirectoryService, newCalendarService = origAdmin, origCal })
+	newAdminDirectoryService = func(context.Context, string) (*admin.Service, error) { return adminSvc, nil }
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return calSvc, nil }
+}
+
+func TestCalendarRoomsListAndFind(t *testing.T) {
+	roomsTestServices(t, &map[string]any{})
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "calendar", "rooms", "list", "--building", "Headquarters", "--capacity", "8", "--features", "video"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	var listed struct {
+		Rooms []calendarRoom `json:"rooms"`
+	}
+	if err := json.Unmarshal([]byte(out), &listed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if len(listed.Rooms) != 2 || listed.Rooms[0].Name != "Busy" || listed.Rooms[1].Name != "Big" || listed.Rooms[0].Building != "Headquarters" {
+		t.Fatalf("unexpected rooms: %s", out)
+	}
+
+	out = captureStdout(t, func() {
+		if err := Execute([]string{"--account", "a@b.com", "calendar", "rooms", "find", "--from", "2026-03-02T10:00:00Z", "--duration", "1h", "--capacity", "5"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	if strings.Contains(out, "Busy") || strings.Contains(out, "Small") || strings.Index(out, "Mid") > strings.Index(out, "Big") {
+		t.Fatalf("expected free rooms smallest first:\n%s", out)
+	}
+}
+
+func TestCalendarCreate_RoomAuto(t *testing.T) {
+	inserted := map[string]any{}
+	roomsTestServices(t, &inserted)
+
+	_ = captureStderr(t, func() {
+		_ = captureStdout(t, func() {
+			if err := Execute([]string{"--json", "--account", "a@b.com", "calendar", "create", "primary", "--summary", "Review",
+				"--from", "2026-03-02T10:00:00Z", "--duration", "30m", "--attendees", "x@b.com,y@b.com,z@b.com,w@b.com",
+				"--room", "auto", "--room-features", "video"}); err != nil {
+				t.Fatalf("Execute: %v", err)
+			}
+		})
+	})
+	attendees, _ := inserted["attendees"].([]any)
+	if len(attendees) != 5 {
+		t.Fatalf("expected room appended to attendees: %#v", inserted)
+	}
+	room, _ := attendees[4].(map[string]any)
+	if room["email"] != "big@resource.calendar.google.com" || room["resource"] != true {
+		t.Fatalf("expected smallest free video room with capacity >= 5: %#v", room)
+	}
+}
@@ -0,0 +1,413 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type CalendarStatsCmd struct {
+	Calendars       string `name:"calendars" help:"Comma-separated calendar IDs (people) to analyze" default:"primary"`
+	GroupEmail      string `name:"group-email" aliases:"group" help:"Google Group email; analyze every member's calendar instead of --calendars"`
+	WorkingHours    string `name:"working-hours" help:"Meetings outside these hours (or on weekends) count as after-hours (HH:MM-HH:MM)" default:"09:00-18:00"`
+	InternalDomains string `name:"internal-domains" help:"Comma-separated internal domains (default: each calendar's own domain)"`
+	Top             int    `name:"top" help:"Co-attendees and external domains to list per calendar" default:"5"`
+	TimeRangeFlags
+}
+
+// calendarStatsBucket counts meetings and the hours they take inside the
+// analyzed range.
+type calendarStatsBucket struct {
+	Count int     `json:"count"`
+	Hours float64 `json:"hours"`
+	dur   time.Duration
+}
+
+func (b *calendarStatsBucket) add(d time.Duration) {
+	b.Count++
+	b.dur += d
+	b.Hours = math.Round(b.dur.Hours()*100) / 100
+}
+
+type calendarStatsWeek struct {
+	Week string `json:"week"`
+	calendarStatsBucket
+}
+
+type calendarStatsTop struct {
+	Key string `json:"key"`
+	calendarStatsBucket
+}
+
+type calendarStatsPerson struct {
+	Calendar        string              `json:"calendar"`
+	Meetings        calendarStatsBucket `json:"meetings"`
+	OneOnOne        calendarStatsBucket `json:"oneOnOne"`
+	Group           calendarStatsBucket `json:"group"`
+	Recurring       calendarStatsBucket `json:"recurring"`
+	AdHoc           calendarStatsBucket `json:"adHoc"`
+	External        calendarStatsBucket `json:"external"`
+	AfterHours      calendarStatsBucket `json:"afterHours"`
+	Focus           calendarStatsBucket `json:"focus"`
+	FocusShare      float64             `json:"focusShare"`
+	Weeks           []calendarStatsWeek `json:"weeks"`
+	CoAttendees     []calendarStatsTop  `json:"coAttendees"`
+	ExternalDomains []calendarStatsTop  `json:"externalDomains"`
+	Error           string              `json:"error,omitempty"`
+}
+
+type calendarStatsOptions struct {
+	From, To  time.Time
+	Location  *time.Location
+	Hours     workingHours
+	WeekStart time.Weekday
+	Internal  map[string]bool
+	Top       int
+	// SelfIsPerson is set when the signed-in account owns the analyzed
+	// calendar, so attendee entries marked self belong to the person.
+	SelfIsPerson bool
+}
+
+func (c *CalendarStatsCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	hours, err := parseWorkingHours(c.WorkingHours)
+	if err != nil {
+		return usagef("invalid --working-hours %q (expected HH:MM-HH:MM)", c.WorkingHours)
+	}
+	weekStart, err := resolveWeekStart(c.WeekStart)
+	if err != nil {
+		return usage(err.Error())
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+	tr, err := ResolveTimeRangeWithDefaults(ctx, svc, c.TimeRangeFlags, TimeRangeDefaults{FromOffset: -28 * 24 * time.Hour})
+	if err != nil {
+		return err
+	}
+	if !tr.To.After(tr.From) {
+		return usage("--to must be after --from")
+	}
+
+	calendarIDs := splitCSV(c.Calendars)
+	if group := strings.TrimSpace(c.GroupEmail); group != "" {
+		cloudSvc, cloudErr := newCloudIdentityService(ctx, account)
+		if cloudErr != nil {
+			return wrapCloudIdentityError(cloudErr, account)
+		}
+		calendarIDs, err = collectGroupMemberEmails(ctx, cloudSvc, group)
+		if err != nil {
+			return fmt.Errorf("failed to list group members: %w", err)
+		}
+	}
+	if len(calendarIDs) == 0 {
+		return usage("no calendars to analyze")
+	}
+
+	opts := calendarStatsOptions{
+		From:      tr.From,
+		To:        tr.To,
+		Location:  tr.Location,
+		Hours:     hours,
+		WeekStart: weekStart,
+		Top:       c.Top,
+	}
+	var internal []string
+	for _, d := range splitCSV(c.InternalDomains) {
+		internal = append(internal, strings.ToLower(strings.TrimPrefix(d, "@")))
+	}
+
+	people := make([]calendarStatsPerson, 0, len(calendarIDs))
+	for _, calendarID := range calendarIDs {
+		person := calendarID
+		if calendarID == primaryCalendarID {
+			person = account
+		}
+		opts.SelfIsPerson = calendarID == primaryCalendarID || strings.EqualFold(calendarID, account)
+		opts.Internal = map[string]bool{}
+		for _, d := range internal {
+			opts.Internal[d] = true
+		}
+		if len(internal) == 0 {
+			opts.Internal[emailDomain(person)] = true
+		}
+
+		events, listErr := listCalendarStatsEvents(ctx, svc, calendarID, tr.From, tr.To)
+		if listErr != nil {
+			u.Err().Printf("%s: %v", person, listErr)
+			people = append(people, calendarStatsPerson{Calendar: person, Error: listErr.Error()})
+			continue
+		}
+		people = append(people, computeCalendarStats(person, events, opts))
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"timeMin":  tr.From.Format(time.RFC3339),
+			"timeMax":  tr.To.Format(time.RFC3339),
+			"timezone": tr.Location.String(),
+			"people":   people,
+		})
+	}
+	printCalendarStats(ctx, people)
+	return nil
+}
+
+func listCalendarStatsEvents(ctx context.Context, svc *calendar.Service, calendarID string, from, to time.Time) ([]*calendar.Event, error) {
+	var out []*calendar.Event
+	pageToken := ""
+	for {
+		call := svc.Events.List(calendarID).
+			SingleEvents(true).
+			TimeMin(from.Format(time.RFC3339)).
+			TimeMax(to.Format(time.RFC3339)).
+			MaxResults(2500).
+			Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, resp.Items...)
+		if resp.NextPageToken == "" {
+			return out, nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+// computeCalendarStats classifies one person's events. A meeting is a timed,
+// non-declined default event with at least one other human attendee; focus
+// time comes from focusTime events, and out-of-office / working-location
+// entries are ignored.
+func computeCalendarStats(person string, events []*calendar.Event, opts calendarStatsOptions) calendarStatsPerson {
+	stats := calendarStatsPerson{Calendar: person}
+	personEmail := strings.ToLower(strings.TrimSpace(person))
+	weeks := map[string]*calendarStatsBucket{}
+	coAttendees := map[string]*calendarStatsBucket{}
+	domains := map[string]*calendarStatsBucket{}
+
+	for _, ev := range events {
+		if ev == nil || ev.Status == "cancelled" || ev.Start == nil || ev.End == nil || ev.Start.DateTime == "" {
+			continue
+		}
+		start, ok1 := parseEventTime(ev.Start.DateTime, ev.Start.TimeZone)
+		end, ok2 := parseEventTime(ev.End.DateTime, ev.End.TimeZone)
+		if !ok1 || !ok2 {
+			continue
+		}
+		start, end = start.In(opts.Location), end.In(opts.Location)
+		dur := minTime(end, opts.To).Sub(maxTime(start, opts.From))
+		if dur <= 0 {
+			continue
+		}
+
+		switch ev.EventType {
+		case eventTypeFocusTime:
+			stats.Focus.add(dur)
+			continue
+		case eventTypeOutOfOffice, eventTypeWorkingLocation:
+			continue
+		}
+
+		var others []string
+		declined := false
+		for _, att := range ev.Attendees {
+			if att == nil || att.Resource {
+				continue
+			}
+			email := strings.ToLower(strings.TrimSpace(att.Email))
+			if email == personEmail || (att.Self && opts.SelfIsPerson) {
+				declined = att.ResponseStatus == "declined"
+				continue
+			}
+			if att.ResponseStatus != "declined" && email != "" {
+				others = append(others, email)
+			}
+		}
+		if declined || len(others) == 0 {
+			continue
+		}
+
+		stats.Meetings.add(dur)
+		if len(others) == 1 {
+			stats.OneOnOne.add(dur)
+		} else {
+			stats.Group.add(dur)
+		}
+		if ev.RecurringEventId != "" {
+			stats.Recurring.add(dur)
+		} else {
+			stats.AdHoc.add(dur)
+		}
+		if isAfterHours(start, end, opts.Hours) {
+			stats.AfterHours.add(dur)
+		}
+
+		week := startOfWeek(start, opts.WeekStart).Format("2006-01-02")
+		statsBucket(weeks, week).add(dur)
+
+		external := map[string]bool{}
+		for _, email := range others {
+			statsBucket(coAttendees, email).add(dur)
+			if domain := emailDomain(email); domain != "" && !opts.Internal[domain] {
+				external[domain] = true
+			}
+		}
+		if len(external) > 0 {
+			stats.External.add(dur)
+			for domain := range external {
+				statsBucket(domains, domain).add(dur)
+			}
+		}
+	}
+
+	if total := stats.Focus.dur + stats.Meetings.dur; total > 0 {
+		stats.FocusShare = math.Round(float64(stats.Focus.dur)/float64(total)*100) / 100
+	}
+	stats.Weeks = make([]calendarStatsWeek, 0, len(weeks))
+	for week, b := range weeks {
+		stats.Weeks = append(stats.Weeks, calendarStatsWeek{Week: week, calendarStatsBucket: *b})
+	}
+	sort.Slice(stats.Weeks, func(i, j int) bool { return stats.Weeks[i].Week < stats.Weeks[j].Week })
+	stats.CoAttendees = topCalendarStats(coAttendees, opts.Top)
+	stats.ExternalDomains = topCalendarStats(domains, opts.Top)
+	return stats
+}
+
+func statsBucket(m map[string]*calendarStatsBucket, key string) *calendarStatsBucket {
+	b, ok := m[key]
+	if !ok {
+		b = &calendarStatsBucket{}
+		m[key] = b
+	}
+	return b
+}
+
+func topCalendarStats(m map[string]*calendarStatsBucket, limit int) []calendarStatsTop {
+	out := make([]calendarStatsTop, 0, len(m))
+	for key, b := range m {
+		out = append(out, calendarStatsTop{Key: key, calendarStatsBucket: *b})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].dur != out[j].dur {
+			return out[i].dur > out[j].dur
+		}
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Key < out[j].Key
+	})
+	if limit >= 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
+
+// isAfterHours reports whether a meeting falls on a weekend or runs outside
+// the working window on its start day.
+func isAfterHours(start, end time.Time, hours workingHours) bool {
+	if start.Weekday() == time.Saturday || start.Weekday() == time.Sunday {
+		return true
+	}
+	day := startOfDay(start)
+	workStart := day.Add(time.Duration(hours.Start) * time.Minute)
+	workEnd := day.Add(time.Duration(hours.End) * time.Minute)
+	return start.Before(workStart) || end.After(workEnd)
+}
+
+func emailDomain(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return ""
+	}
+	return strings.ToLower(email[at+1:])
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func printCalendarStats(ctx context.Context, people []calendarStatsPerson) {
+	u := ui.FromContext(ctx)
+	w, flush := tableWriter(ctx)
+	fmt.Fprintln(w, "CALENDAR\tMEETINGS\t1:1\tGROUP\tRECURRING\tAD-HOC\tEXTERNAL\tAFTER-HOURS\tFOCUS\tFOCUS-SHARE")
+	for _, p := range people {
+		if p.Error != "" {
+			fmt.Fprintf(w, "%s\terror: %s\t\t\t\t\t\t\t\t\n", sanitizeTab(p.Calendar), sanitizeTab(p.Error))
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%.1fh\t%.0f%%\n",
+			sanitizeTab(p.Calendar),
+			formatStatsBucket(p.Meetings),
+			formatStatsBucket(p.OneOnOne),
+			formatStatsBucket(p.Group),
+			formatStatsBucket(p.Recurring),
+			formatStatsBucket(p.AdHoc),
+			formatStatsBucket(p.External),
+			formatStatsBucket(p.AfterHours),
+			p.Focus.Hours,
+			p.FocusShare*100,
+		)
+	}
+	flush()
+
+	u.Out().Println("")
+	u.Out().Println("HOURS PER WEEK:")
+	w, flush = tableWriter(ctx)
+	fmt.Fprintln(w, "CALENDAR\tWEEK\tMEETINGS\tHOURS")
+	for _, p := range people {
+		for _, wk := range p.Weeks {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%.1f\n", sanitizeTab(p.Calendar), wk.Week, wk.Count, wk.Hours)
+		}
+	}
+	flush()
+
+	printCalendarStatsTop(ctx, "TOP CO-ATTENDEES:", "ATTENDEE", people, func(p calendarStatsPerson) []calendarStatsTop { return p.CoAttendees })
+	printCalendarStatsTop(ctx, "EXTERNAL DOMAINS:", "DOMAIN", people, func(p calendarStatsPerson) []calendarStatsTop { return p.ExternalDomains })
+}
+
+func printCalendarStatsTop(ctx context.Context, title, column string, people []calendarStatsPerson, pick func(calendarStatsPerson) []calendarStatsTop) {
+	u := ui.FromContext(ctx)
+	u.Out().Println("")
+	u.Out().Println(title)
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintf(w, "CALENDAR\t%s\tMEETINGS\tHOURS\n", column)
+	for _, p := range people {
+		for _, top := range pick(p) {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%.1f\n", sanitizeTab(p.Calendar), sanitizeTab(top.Key), top.Count, top.Hours)
+		}
+	}
+}
+
+func formatStatsBucket(b calendarStatsBucket) string {
+	return fmt.Sprintf("%d (%.1fh)", b.Count, b.Hours)
+}
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/option"
+)
+
+func statsTestEvent(start, end string, attendees ...string) *calendar.Event {
+	ev := &calendar.Event{
+		Status: "confirmed",
+		Start:  &calendar.EventDateTime{DateTime: start},
+		End:    &calendar.EventDateTime{DateTime: end},
+	}
+	for _, a := range attendees {
+		ev.Attendees = append(ev.Attendees, &calendar.EventAttendee{Email: a, ResponseStatus: "accepted"})
+	}
+	return ev
+}
+
+func TestComputeCalendarStats(t *testing.T) {
+	me := "me@corp.com"
+	oneOnOne := statsTestEvent("2026-01-05T10:00:00Z", "2026-01-05T10:30:00Z", me, "bob@corp.com")
+	oneOnOne.RecurringEventId = "weekly"
+	group := statsTestEvent("2026-01-06T17:30:00Z", "2026-01-06T19:00:00Z", me, "bob@corp.com", "ann@client.io")
+	declined := statsTestEvent("2026-01-07T09:00:00Z", "2026-01-07T10:00:00Z", me, "bob@corp.com")
+	declined.Attendees[0].ResponseStatus = "declined"
+	solo := statsTestEvent("2026-01-07T11:00:00Z", "2026-01-07T12:00:00Z")
+	focus := statsTestEvent("2026-01-08T13:00:00Z", "2026-01-08T15:00:00Z")
+	focus.EventType = eventTypeFocusTime
+	nextWeek := statsTestEvent("2026-01-12T09:00:00Z", "2026-01-12T10:00:00Z", me, "carl@corp.com", "room@resource.calendar.google.com")
+	nextWeek.Attendees[2].Resource = true
+
+	stats := computeCalendarStats(me, []*calendar.Event{oneOnOne, group, declined, solo, focus, nextWeek}, calendarStatsOptions{
+		From:      time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:        time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		Location:  time.UTC,
+		Hours:     workingHours{Start: 9 * 60, End: 18 * 60},
+		WeekStart: time.Monday,
+		Internal:  map[string]bool{"corp.com": true},
+		Top:       5,
+	})
+
+	check := func(name string, b calendarStatsBucket, count int, hours float64) {
+		t.Helper()
+		if b.Count != count || b.Hours != hours {
+			t.Fatalf("%s: got %d/%.2f, want %d/%.2f", name, b.Count, b.Hours, count, hours)
+		}
+	}
+	check("meetings", stats.Meetings, 3, 3)
+	check("oneOnOne", stats.OneOnOne, 2, 1.5)
+	check("group", stats.Group, 1, 1.5)
+	check("recurring", stats.Recurring, 1, 0.5)
+	check("adHoc", stats.AdHoc, 2, 2.5)
+	check("external", stats.External, 1, 1.5)
+	check("afterHours", stats.AfterHours, 1, 1.5)
+	check("focus", stats.Focus, 1, 2)
+	if stats.FocusShare != 0.4 {
+		t.Fatalf("focus share: %v", stats.FocusShare)
+	}
+	if len(stats.Weeks) != 2 || stats.Weeks[0].Week != "2026-01-05" || stats.Weeks[0].Hours != 2 {
+		t.Fatalf("unexpected weeks: %#v", stats.Weeks)
+	}
+	if len(stats.CoAttendees) != 3 || stats.CoAttendees[0].Key != "bob@corp.com" || stats.CoAttendees[0].Count != 2 {
+		t.Fatalf("unexpected co-attendees: %#v", stats.CoAttendees)
+	}
+	if len(stats.ExternalDomains) != 1 || stats.ExternalDomains[0].Key != "client.io" {
+		t.Fatalf("unexpected domains: %#v", stats.ExternalDomains)
+	}
+}
+
+func TestComputeCalendarStats_OtherPerson(t *testing.T) {
+	// Analyzing ann's calendar while signed in as me: my own decline must not
+	// drop the meeting, and I count as one of ann's co-attendees.
+	meeting := statsTestEvent("2026-01-05T10:00:00Z", "2026-01-05T11:00:00Z", "ann@corp.com", "me@corp.com", "bob@corp.com")
+	meeting.Attendees[1].Self = true
+	meeting.Attendees[1].ResponseStatus = "declined"
+	withMe := statsTestEvent("2026-01-07T10:00:00Z", "2026-01-07T11:00:00Z", "me@corp.com", "ann@corp.com")
+	withMe.Attendees[0].Self = true
+	annDeclined := statsTestEvent("2026-01-06T10:00:00Z", "2026-01-06T11:00:00Z", "me@corp.com", "Ann@corp.com")
+	annDeclined.Attendees[0].Self = true
+	annDeclined.Attendees[1].ResponseStatus = "declined"
+
+	opts := calendarStatsOptions{
+		From:      time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:        time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		Location:  time.UTC,
+		Hours:     workingHours{Start: 9 * 60, End: 18 * 60},
+		WeekStart: time.Monday,
+		Internal:  map[string]bool{"corp.com": true},
+		Top:       5,
+	}
+	stats := computeCalendarStats("ann@corp.com", []*calendar.Event{meeting, withMe, annDeclined}, opts)
+	if stats.Meetings.Count != 2 || stats.OneOnOne.Count != 2 {
+		t.Fatalf("unexpected meetings: %+v", stats)
+	}
+	var keys []string
+	for _, c := range stats.CoAttendees {
+		keys = append(keys, c.Key)
+	}
+	if strings.Join(keys, ",") != "bob@corp.com,me@corp.com" {
+		t.Fatalf("unexpected co-attendees: %v", keys)
+	}
+
+	opts.SelfIsPerson = true
+	if own := computeCalendarStats("me@corp.com", []*calendar.Event{meeting}, opts); own.Meetings.Count != 0 {
+		t.Fatalf("own decline should drop the meeting on your own calendar: %+v", own.Meetings)
+	}
+}
+
+func TestCalendarStatsCmd_JSONAndText(t *testing.T) {
+	origNew := newCalendarService
+	t.Cleanup(func() { newCalendarService = origNew })
+
+	srv := httptest.NewServer(withPrimaryCalendar(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/calendars/primary/events") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"items": []map[string]any{
+				{
+					"id":        "e1",
+					"start":     map[string]any{"dateTime": "2026-01-05T10:00:00Z"},
+					"end":       map[string]any{"dateTime": "2026-01-05T11:00:00Z"},
+					"attendees": []map[string]any{{"email": "a@b.com", "self": true}, {"email": "x@partner.org"}},
+				},
+			},
+		})
+	})))
+	defer srv.Close()
+
+	svc, err := calendar.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return svc, nil }
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "calendar", "stats", "--from", "2026-01-01", "--to", "2026-01-31"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	var parsed struct {
+		People []calendarStatsPerson `json:"people"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if len(parsed.People) != 1 || parsed.People[0].Calendar != "a@b.com" || parsed.People[0].External.Count != 1 {
+		t.Fatalf("unexpected stats: %s", out)
+	}
+
+	text := captureStdout(t, func() {
+		if err := Execute([]string{"--account", "a@b.com", "calendar", "stats", "--from", "2026-01-01", "--to", "2026-01-31"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	for _, want := range []string{"FOCUS-SHARE", "1 (1.0h)", "HOURS PER WEEK:", "2026-01-05", "partner.org"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("missing %q in:\n%s", want, text)
+		}
+	}
+}
@@ -0,0 +1,328 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/googleapi"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/ics"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const (
+	calendarSyncStateFile = ".gog-calendar-sync.json"
+	calendarSyncFormatICS = "ics"
+)
+
+type CalendarSyncCmd struct {
+	CalendarID string `arg:"" name:"calendarId" optional:"" help:"Calendar ID (default: primary)"`
+	Dir        string `name:"dir" required:"" help:"Mirror directory (one file per event plus sync state)"`
+	Format     string `name:"format" help:"Event file format: json|ics" default:"json" enum:"json,ics"`
+	Full       bool   `name:"full" help:"Ignore the stored sync token and re-list everything"`
+}
+
+// calendarSyncState is persisted in the mirror directory between runs.
+type calendarSyncState struct {
+	CalendarID string `json:"calendarId"`
+	Format     string `json:"format"`
+	SyncToken  string `json:"syncToken"`
+	UpdatedAt  string `json:"updatedAt"`
+}
+
+type calendarSyncChange struct {
+	Action  string `json:"action"`
+	ID      string `json:"id"`
+	Summary string `json:"summary,omitempty"`
+	Path    string `json:"path"`
+}
+
+func (c *CalendarSyncCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	dir, err := config.ExpandPath(strings.TrimSpace(c.Dir))
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+	calendarID, err := resolveCalendarID(ctx, svc, firstNonEmpty(strings.TrimSpace(c.CalendarID), primaryCalendarID))
+	if err != nil {
+		return err
+	}
+
+	state, err := loadCalendarSyncState(dir)
+	if err != nil {
+		return err
+	}
+	if state.CalendarID != "" && state.CalendarID != calendarID {
+		return usagef("%s mirrors calendar %q; use a separate --dir for %q", dir, state.CalendarID, calendarID)
+	}
+	if state.Format != "" && state.Format != c.Format {
+		return usagef("%s uses --format %s; use a separate --dir or --format %s", dir, state.Format, state.Format)
+	}
+
+	token := state.SyncToken
+	if c.Full {
+		token = ""
+	}
+	full := token == ""
+	resync := false
+
+	events, nextToken, err := listCalendarSyncEvents(ctx, svc, calendarID, token)
+	if err != nil && token != "" && isSyncTokenExpired(err) {
+		u.Err().Println("Sync token expired; performing full resync")
+		full, resync = true, true
+		events, nextToken, err = listCalendarSyncEvents(ctx, svc, calendarID, "")
+	}
+	if err != nil {
+		return err
+	}
+
+	mirror := calendarMirror{dir: dir, format: c.Format}
+	changes, err := mirror.apply(events, full)
+	if err != nil {
+		return err
+	}
+
+	state = calendarSyncState{
+		CalendarID: calendarID,
+		Format:     c.Format,
+		SyncToken:  nextToken,
+		UpdatedAt:  time.Now().UTC().Format(time.RFC3339),
+	}
+	if err := saveCalendarSyncState(dir, state); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"calendarId": calendarID,
+			"dir":        dir,
+			"fullSync":   full,
+			"resync":     resync,
+			"changes":    changes,
+		})
+	}
+
+	if len(changes) == 0 {
+		u.Err().Println("No changes")
+		return nil
+	}
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "ACTION\tID\tSUMMARY")
+	for _, ch := range changes {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", ch.Action, ch.ID, sanitizeTab(ch.Summary))
+	}
+	return nil
+}
+
+// listCalendarSyncEvents pages through events.list. With an empty token it
+// performs a full listing; either way the final page yields the next token.
+func listCalendarSyncEvents(ctx context.Context, svc *calendar.Service, calendarID, syncToken string) ([]*calendar.Event, string, error) {
+	var out []*calendar.Event
+	pageToken := ""
+	for {
+		call := svc.Events.List(calendarID).
+			ShowDeleted(syncToken != "").
+			SingleEvents(false).
+			MaxResults(2500).
+			Context(ctx)
+		if syncToken != "" {
+			call = call.SyncToken(syncToken)
+		}
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		out = append(out, resp.Items...)
+		if resp.NextPageToken == "" {
+			return out, resp.NextSyncToken, nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+func isSyncTokenExpired(err error) bool {
+	var gerr *googleapi.Error
+	return errors.As(err, &gerr) && gerr.Code == http.StatusGone
+}
+
+type calendarMirror struct {
+	dir    string
+	format string
+}
+
+// apply writes changed events and removes cancelled ones. On a full sync any
+// mirrored file whose event was not returned is removed as well.
+func (m calendarMirror) apply(events []*calendar.Event, full bool) ([]calendarSyncChange, error) {
+	changes := []calendarSyncChange{}
+	seen := map[string]struct{}{}
+	for _, ev := range events {
+		if ev == nil || ev.Id == "" {
+			continue
+		}
+		path := m.path(ev.Id)
+		if ev.Status == "cancelled" {
+			removed, err := removeIfExists(path)
+			if err != nil {
+				return nil, err
+			}
+			if removed {
+				changes = append(changes, calendarSyncChange{Action: "deleted", ID: ev.Id, Summary: ev.Summary, Path: path})
+			}
+			continue
+		}
+		seen[filepath.Base(path)] = struct{}{}
+
+		data, err := m.encode(ev)
+		if err != nil {
+			return nil, err
+		}
+		existing, readErr := os.ReadFile(path) //nolint:gosec // mirror path
+		switch {
+		case readErr == nil && bytes.Equal(existing, data):
+			continue
+		case readErr != nil && !errors.Is(readErr, os.ErrNotExist):
+			return nil, readErr
+		}
+		if err := writeFileAtomic(path, data); err != nil {
+			return nil, err
+		}
+		action := "updated"
+		if readErr != nil {
+			action = "created"
+		}
+		changes = append(changes, calendarSyncChange{Action: action, ID: ev.Id, Summary: ev.Summary, Path: path})
+	}
+
+	if full {
+		stale, err := m.staleFiles(seen)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range stale {
+			path := filepath.Join(m.dir, name)
+			if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return nil, err
+			}
+			changes = append(changes, calendarSyncChange{Action: "deleted", ID: strings.TrimSuffix(name, "."+m.format), Path: path})
+		}
+	}
+	return changes, nil
+}
+
+func (m calendarMirror) path(eventID string) string {
+	return filepath.Join(m.dir, sanitizeEventFileName(eventID)+"."+m.format)
+}
+
+func (m calendarMirror) encode(ev *calendar.Event) ([]byte, error) {
+	if m.format == calendarSyncFormatICS {
+		// Stamp with the event's own update time so unchanged events encode identically.
+		stamp := time.Unix(0, 0).UTC()
+		if t, err := time.Parse(time.RFC3339, ev.Updated); err == nil {
+			stamp = t
+		}
+		var buf bytes.Buffer
+		if err := ics.Encode(&buf, eventsToICS([]*calendar.Event{ev}, icsCalendarMeta{}, stamp)); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+	data, err := json.MarshalIndent(ev, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func (m calendarMirror) staleFiles(seen map[string]struct{}) ([]string, error) {
+	entries, err := os.ReadDir(m.dir)
+	if err != nil {
+		return nil, err
+	}
+	var stale []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || strings.HasPrefix(name, ".") || filepath.Ext(name) != "."+m.format {
+			continue
+		}
+		if _, ok := seen[name]; !ok {
+			stale = append(stale, name)
+		}
+	}
+	sort.Strings(stale)
+	return stale, nil
+}
+
+func sanitizeEventFileName(id string) string {
+	var b strings.Builder
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '-':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
+
+func removeIfExists(path string) (bool, error) {
+	err := os.Remove(path)
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	return false, err
+}
+
+func loadCalendarSyncState(dir string) (calendarSyncState, error) {
+	var state calendarSyncState
+	data, err := os.ReadFile(filepath.Join(dir, calendarSyncStateFile)) //nolint:gosec // mirror path
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return state, nil
+		}
+		return state, err
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("read sync state: %w", err)
+	}
+	return state, nil
+}
+
+func saveCalendarSyncState(dir string, state calendarSyncState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(dir, calendarSyncStateFile), append(data, '\n'))
+}
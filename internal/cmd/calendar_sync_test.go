@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/option"
+)
+
+func TestCalendarSyncCmd_IncrementalAndResync(t *testing.T) {
+	origNew := newCalendarService
+	t.Cleanup(func() { newCalendarService = origNew })
+
+	event := func(id, summary, status string) map[string]any {
+		return map[string]any{
+			"id":      id,
+			"summary": summary,
+			"status":  status,
+			"start":   map[string]any{"dateTime": "2026-01-05T10:00:00Z"},
+			"end":     map[string]any{"dateTime": "2026-01-05T11:00:00Z"},
+		}
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || !strings.HasSuffix(r.URL.Path, "/calendars/primary/events") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("syncToken") {
+		case "":
+			if r.URL.Query().Get("pageToken") == "" {
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"items":         []any{event("e1", "One", "confirmed")},
+					"nextPageToken": "p2",
+				})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"items":         []any{event("e2", "Two", "confirmed")},
+				"nextSyncToken": "t1",
+			})
+		case "t1":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"items":         []any{event("e1", "", "cancelled"), event("e2", "Two (moved)", "confirmed"), event("e3", "Three", "confirmed")},
+				"nextSyncToken": "t2",
+			})
+		case "t2":
+			w.WriteHeader(http.StatusGone)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": 410, "message": "Sync token is no longer valid"}})
+		}
+	}))
+	defer srv.Close()
+
+	svc, err := calendar.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return svc, nil }
+
+	dir := t.TempDir()
+	run := func() map[string]any {
+		t.Helper()
+		var result map[string]any
+		out := captureStdout(t, func() {
+			_ = captureStderr(t, func() {
+				if err := Execute([]string{"--json", "--account", "a@b.com", "calendar", "sync", "primary", "--dir", dir}); err != nil {
+					t.Fatalf("sync: %v", err)
+				}
+			})
+		})
+		if err := json.Unmarshal([]byte(out), &result); err != nil {
+			t.Fatalf("decode: %v (%q)", err, out)
+		}
+		return result
+	}
+	actions := func(result map[string]any) string {
+		var parts []string
+		for _, ch := range result["changes"].([]any) {
+			m := ch.(map[string]any)
+			parts = append(parts, m["action"].(string)+":"+m["id"].(string))
+		}
+		return strings.Join(parts, ",")
+	}
+
+	first := run()
+	if first["fullSync"] != true || actions(first) != "created:e1,created:e2" {
+		t.Fatalf("unexpected first sync: %#v", first)
+	}
+
+	second := run()
+	if second["fullSync"] != false || actions(second) != "deleted:e1,updated:e2,created:e3" {
+		t.Fatalf("unexpected incremental sync: %#v", second)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "e1.json")); !os.IsNotExist(err) {
+		t.Fatalf("e1 should be removed: %v", err)
+	}
+
+	// Token t2 is expired; the full re-list returns only e1/e2 again, so e3 is stale.
+	third := run()
+	if third["resync"] != true || !strings.Contains(actions(third), "deleted:e3") || !strings.Contains(actions(third), "created:e1") {
+		t.Fatalf("unexpected resync: %#v", third)
+	}
+
+	state, err := loadCalendarSyncState(dir)
+	if err != nil || state.SyncToken != "t1" || state.CalendarID != "primary" {
+		t.Fatalf("unexpected state: %+v %v", state, err)
+	}
+}
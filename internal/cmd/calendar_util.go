@@ -1,7 +1,20 @@
 package cmd
 
-import "google.golang.org/api/calendar/v3"
+import (
+	"strings"
+
+	"google.golang.org/api/calendar/v3"
+)
 
 func isAllDayEvent(e *calendar.Event) bool {
 	return e != nil && e.Start != nil && e.Start.Date != ""
 }
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return v
+		}
+	}
+	return ""
+}
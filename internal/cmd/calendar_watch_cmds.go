@@ -0,0 +1,532 @@
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type CalendarWatchCmd struct {
+	Start  CalendarWatchStartCmd  `cmd:"" name:"start" aliases:"begin" help:"Register an events.watch push channel"`
+	Status CalendarWatchStatusCmd `cmd:"" name:"status" aliases:"ls" help:"Show stored watch state"`
+	Renew  CalendarWatchRenewCmd  `cmd:"" name:"renew" aliases:"update" help:"Replace the push channel using stored config"`
+	Stop   CalendarWatchStopCmd   `cmd:"" name:"stop" aliases:"rm,delete" help:"Stop the push channel and clear stored state"`
+	Serve  CalendarWatchServeCmd  `cmd:"" name:"serve" help:"Run the push notification handler"`
+	Poll   CalendarWatchPollCmd   `cmd:"" name:"poll" help:"Poll for changes with sync tokens (no public URL needed)"`
+}
+
+type CalendarWatchStartCmd struct {
+	CalendarID string `arg:"" name:"calendarId" optional:"" help:"Calendar ID (default: primary)"`
+	Address    string `name:"address" required:"" help:"Public HTTPS URL of your watch serve endpoint"`
+	Token      string `name:"token" help:"Channel token echoed in notifications (default: random)"`
+	TTL        string `name:"ttl" help:"Requested channel lifetime (seconds or Go duration)"`
+	HookURL    string `name:"hook-url" help:"Webhook URL to forward changed events"`
+	HookToken  string `name:"hook-token" help:"Webhook bearer token"`
+	HookExec   string `name:"hook-exec" help:"Shell command to run with the JSON payload on stdin"`
+}
+
+func (c *CalendarWatchStartCmd) Run(ctx context.Context, flags *RootFlags) error {
+	address := strings.TrimSpace(c.Address)
+	parsed, err := url.Parse(address)
+	if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		return usage("--address must be an https:// URL")
+	}
+	ttl, err := parseDurationSeconds(c.TTL)
+	if err != nil {
+		return err
+	}
+	hook, err := watchHookFromFlags(c.HookURL, c.HookToken, c.HookExec)
+	if err != nil {
+		return err
+	}
+	calendarID := firstNonEmpty(strings.TrimSpace(c.CalendarID), primaryCalendarID)
+
+	if dryRunErr := dryRunExit(ctx, flags, "calendar.watch.start", map[string]any{
+		"calendar_id": calendarID,
+		"address":     address,
+		"ttl":         ttl.String(),
+		"hook":        hook,
+	}); dryRunErr != nil {
+		return dryRunErr
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+	calendarID, err = resolveCalendarID(ctx, svc, calendarID)
+	if err != nil {
+		return err
+	}
+	store, err := openCalendarWatchStore(account, calendarID)
+	if err != nil {
+		return err
+	}
+	stopPreviousCalendarChannel(ctx, svc, store.Get())
+
+	token := strings.TrimSpace(c.Token)
+	if token == "" {
+		if token, err = newCalendarChannelID(); err != nil {
+			return err
+		}
+	}
+	state, err := startCalendarWatch(ctx, svc, calendarWatchState{
+		Account:      account,
+		CalendarID:   calendarID,
+		Mode:         calendarWatchModePush,
+		Address:      address,
+		ChannelToken: token,
+		Hook:         hook,
+	}, ttl)
+	if err != nil {
+		return err
+	}
+	if err := store.Update(func(s *calendarWatchState) error {
+		*s = state
+		return nil
+	}); err != nil {
+		return err
+	}
+	return writeCalendarWatchState(ctx, state)
+}
+
+type CalendarWatchStatusCmd struct {
+	CalendarID string `arg:"" name:"calendarId" optional:"" help:"Calendar ID (default: primary)"`
+}
+
+func (c *CalendarWatchStatusCmd) Run(ctx context.Context, flags *RootFlags) error {
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	store, err := loadCalendarWatchStoreFor(ctx, account, c.CalendarID)
+	if err != nil {
+		return err
+	}
+	return writeCalendarWatchState(ctx, store.Get())
+}
+
+type CalendarWatchRenewCmd struct {
+	CalendarID string `arg:"" name:"calendarId" optional:"" help:"Calendar ID (default: primary)"`
+	TTL        string `name:"ttl" help:"Requested channel lifetime (seconds or Go duration)"`
+}
+
+func (c *CalendarWatchRenewCmd) Run(ctx context.Context, flags *RootFlags) error {
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	store, err := loadCalendarWatchStoreFor(ctx, account, c.CalendarID)
+	if err != nil {
+		return err
+	}
+	state := store.Get()
+	if state.Mode != calendarWatchModePush || strings.TrimSpace(state.Address) == "" {
+		return errors.New("stored watch state has no push channel; run calendar watch start")
+	}
+	ttl, err := parseDurationSeconds(c.TTL)
+	if err != nil {
+		return err
+	}
+
+	if dryRunErr := dryRunExit(ctx, flags, "calendar.watch.renew", map[string]any{
+		"calendar_id": state.CalendarID,
+		"address":     state.Address,
+		"channel_id":  state.ChannelID,
+		"ttl":         ttl.String(),
+	}); dryRunErr != nil {
+		return dryRunErr
+	}
+
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+	stopPreviousCalendarChannel(ctx, svc, state)
+	updated, err := startCalendarWatch(ctx, svc, state, ttl)
+	if err != nil {
+		return err
+	}
+	if err := store.Update(func(s *calendarWatchState) error {
+		*s = updated
+		return nil
+	}); err != nil {
+		return err
+	}
+	return writeCalendarWatchState(ctx, updated)
+}
+
+type CalendarWatchStopCmd struct {
+	CalendarID string `arg:"" name:"calendarId" optional:"" help:"Calendar ID (default: primary)"`
+}
+
+func (c *CalendarWatchStopCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+
+	if confirmErr := confirmDestructive(ctx, flags, "stop calendar watch and clear stored state"); confirmErr != nil {
+		return confirmErr
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	store, err := loadCalendarWatchStoreFor(ctx, account, c.CalendarID)
+	if err != nil {
+		return err
+	}
+	state := store.Get()
+	if state.ChannelID != "" {
+		svc, svcErr := newCalendarService(ctx, account)
+		if svcErr != nil {
+			return svcErr
+		}
+		stopErr := svc.Channels.Stop(&calendar.Channel{Id: state.ChannelID, ResourceId: state.ResourceID}).Context(ctx).Do()
+		if stopErr != nil && !isNotFoundAPIError(stopErr) {
+			return stopErr
+		}
+	}
+	_ = os.Remove(store.path)
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"stopped": true})
+	}
+	u.Out().Printf("stopped\ttrue")
+	return nil
+}
+
+type CalendarWatchServeCmd struct {
+	CalendarID string `arg:"" name:"calendarId" optional:"" help:"Calendar ID (default: primary)"`
+	Bind       string `name:"bind" help:"Bind address" default:"127.0.0.1"`
+	Port       int    `name:"port" help:"Listen port" default:"8789"`
+	Path       string `name:"path" help:"Push handler path" default:"/calendar-push"`
+	Token      string `name:"token" help:"Expected channel token (default: token stored by watch start)"`
+	HookURL    string `name:"hook-url" help:"Webhook URL to forward changed events"`
+	HookToken  string `name:"hook-token" help:"Webhook bearer token"`
+	HookExec   string `name:"hook-exec" help:"Shell command to run with the JSON payload on stdin"`
+	SaveHook   bool   `name:"save-hook" help:"Persist hook settings to watch state"`
+}
+
+func (c *CalendarWatchServeCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(c.Path, "/") {
+		return usage("--path must start with '/'")
+	}
+	if c.Port <= 0 {
+		return usage("--port must be > 0")
+	}
+
+	store, err := loadCalendarWatchStoreFor(ctx, account, c.CalendarID)
+	if err != nil {
+		return err
+	}
+	state := store.Get()
+	if c.Token == "" && state.ChannelToken == "" && !isLoopbackHost(c.Bind) {
+		return usage("--token required when binding non-loopback without a stored channel token")
+	}
+	hook, err := resolveCalendarWatchHook(store, c.HookURL, c.HookToken, c.HookExec, c.SaveHook)
+	if err != nil {
+		return err
+	}
+	if hook == nil {
+		return usage("watch serve needs --hook-url or --hook-exec (or a hook saved with --save-hook)")
+	}
+
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+	cfg := calendarWatchServeConfig{
+		Account:       account,
+		CalendarID:    state.CalendarID,
+		Bind:          c.Bind,
+		Port:          c.Port,
+		Path:          c.Path,
+		SharedToken:   c.Token,
+		Hook:          hook,
+		HookTimeout:   defaultHookRequestTimeoutSec * time.Second,
+		VerboseOutput: flags.Verbose,
+	}
+	server := &calendarWatchServer{
+		cfg:     cfg,
+		watcher: newCalendarWatcher(cfg, store, svc, u.Err().Printf),
+		logf:    u.Err().Printf,
+		warnf:   u.Err().Printf,
+	}
+
+	addr := net.JoinHostPort(c.Bind, strconv.Itoa(c.Port))
+	u.Err().Printf("watch: listening on %s%s", addr, c.Path)
+
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           server,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	return listenAndServe(httpServer)
+}
+
+type CalendarWatchPollCmd struct {
+	CalendarID string `arg:"" name:"calendarId" optional:"" help:"Calendar ID (default: primary)"`
+	Interval   string `name:"interval" help:"Poll interval (seconds or Go duration)" default:"60s"`
+	Once       bool   `name:"once" help:"Run a single sync and exit"`
+	HookURL    string `name:"hook-url" help:"Webhook URL to forward changed events"`
+	HookToken  string `name:"hook-token" help:"Webhook bearer token"`
+	HookExec   string `name:"hook-exec" help:"Shell command to run with the JSON payload on stdin"`
+	SaveHook   bool   `name:"save-hook" help:"Persist hook settings to watch state"`
+}
+
+func (c *CalendarWatchPollCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	interval, err := parseDurationSeconds(c.Interval)
+	if err != nil {
+		return err
+	}
+	if interval <= 0 {
+		interval = defaultWatchPollInterval
+	}
+	if interval < 5*time.Second {
+		return usage("--interval must be at least 5s")
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+	calendarID, err := resolveCalendarID(ctx, svc, firstNonEmpty(strings.TrimSpace(c.CalendarID), primaryCalendarID))
+	if err != nil {
+		return err
+	}
+	store, err := openCalendarWatchStore(account, calendarID)
+	if err != nil {
+		return err
+	}
+	if err := store.Update(func(s *calendarWatchState) error {
+		s.Account = account
+		s.CalendarID = calendarID
+		if s.Mode == "" {
+			s.Mode = calendarWatchModePoll
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	hook, err := resolveCalendarWatchHook(store, c.HookURL, c.HookToken, c.HookExec, c.SaveHook)
+	if err != nil {
+		return err
+	}
+
+	cfg := calendarWatchServeConfig{
+		Account:     account,
+		CalendarID:  calendarID,
+		Hook:        hook,
+		HookTimeout: defaultHookRequestTimeoutSec * time.Second,
+	}
+	watcher := newCalendarWatcher(cfg, store, svc, u.Err().Printf)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := pollCalendarOnce(ctx, watcher); err != nil {
+			if c.Once {
+				return err
+			}
+			u.Err().Printf("watch: %v", err)
+		}
+		if c.Once {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// pollCalendarOnce syncs and delivers one batch. Without a hook the payload
+// is printed so poll can be piped into other tools.
+func pollCalendarOnce(ctx context.Context, w *calendarWatcher) error {
+	if w.hook != nil {
+		return w.syncAndDeliver(ctx, w.deliver)
+	}
+	return w.syncAndDeliver(ctx, printCalendarHookPayload)
+}
+
+func printCalendarHookPayload(ctx context.Context, payload *calendarHookPayload) error {
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, payload)
+	}
+	u := ui.FromContext(ctx)
+	if payload.Resync {
+		u.Out().Printf("resync\t%s", payload.CalendarID)
+	}
+	for _, ev := range payload.Events {
+		u.Out().Printf("%s\t%s\t%s\t%s", ev.Action, ev.ID, ev.Start, sanitizeTab(ev.Summary))
+	}
+	return nil
+}
+
+// loadCalendarWatchStoreFor resolves calendar names the same way watch start
+// does, so state is found under the ID it was stored with.
+func loadCalendarWatchStoreFor(ctx context.Context, account, calendarID string) (*calendarWatchStore, error) {
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	resolved, err := resolveCalendarID(ctx, svc, firstNonEmpty(strings.TrimSpace(calendarID), primaryCalendarID))
+	if err != nil {
+		return nil, err
+	}
+	return loadCalendarWatchStore(account, resolved)
+}
+
+func newCalendarWatcher(cfg calendarWatchServeConfig, store *calendarWatchStore, svc *calendar.Service, warnf func(string, ...any)) *calendarWatcher {
+	return &calendarWatcher{
+		account:     cfg.Account,
+		calendarID:  cfg.CalendarID,
+		store:       store,
+		svc:         svc,
+		hook:        cfg.Hook,
+		hookClient:  &http.Client{Timeout: cfg.HookTimeout},
+		hookTimeout: cfg.HookTimeout,
+		warnf:       warnf,
+	}
+}
+
+// startCalendarWatch records a sync-token baseline and then opens a channel,
+// so changes made after the channel exists are always picked up by the next
+// incremental sync.
+func startCalendarWatch(ctx context.Context, svc *calendar.Service, state calendarWatchState, ttl time.Duration) (calendarWatchState, error) {
+	_, syncToken, err := listCalendarSyncEvents(ctx, svc, state.CalendarID, "")
+	if err != nil {
+		return state, err
+	}
+	channelID, err := newCalendarChannelID()
+	if err != nil {
+		return state, err
+	}
+	ch := &calendar.Channel{
+		Id:      channelID,
+		Type:    "web_hook",
+		Address: state.Address,
+		Token:   state.ChannelToken,
+	}
+	if ttl > 0 {
+		ch.Params = map[string]string{"ttl": strconv.FormatInt(int64(ttl/time.Second), 10)}
+	}
+	resp, err := svc.Events.Watch(state.CalendarID, ch).Context(ctx).Do()
+	if err != nil {
+		return state, err
+	}
+	state.ChannelID = resp.Id
+	state.ResourceID = resp.ResourceId
+	state.ExpirationMs = resp.Expiration
+	state.SyncToken = syncToken
+	state.UpdatedAtMs = time.Now().UnixMilli()
+	return state, nil
+}
+
+func stopPreviousCalendarChannel(ctx context.Context, svc *calendar.Service, state calendarWatchState) {
+	if state.ChannelID == "" {
+		return
+	}
+	_ = svc.Channels.Stop(&calendar.Channel{Id: state.ChannelID, ResourceId: state.ResourceID}).Context(ctx).Do()
+}
+
+func newCalendarChannelID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "gog-" + hex.EncodeToString(b), nil
+}
+
+// resolveCalendarWatchHook prefers hook flags and falls back to the stored
+// hook when none are given.
+func resolveCalendarWatchHook(store *calendarWatchStore, hookURL, token, execCmd string, save bool) (*watchHook, error) {
+	hook, err := watchHookFromFlags(hookURL, token, execCmd)
+	if err != nil {
+		return nil, err
+	}
+	if hook == nil {
+		return store.Get().Hook, nil
+	}
+	if save {
+		if err := store.Update(func(s *calendarWatchState) error {
+			s.Hook = hook
+			s.UpdatedAtMs = time.Now().UnixMilli()
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return hook, nil
+}
+
+func writeCalendarWatchState(ctx context.Context, state calendarWatchState) error {
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"watch": state})
+	}
+	u := ui.FromContext(ctx)
+	u.Out().Printf("account\t%s", state.Account)
+	u.Out().Printf("calendar_id\t%s", state.CalendarID)
+	u.Out().Printf("mode\t%s", state.Mode)
+	if state.ChannelID != "" {
+		u.Out().Printf("channel_id\t%s", state.ChannelID)
+		u.Out().Printf("resource_id\t%s", state.ResourceID)
+	}
+	if state.Address != "" {
+		u.Out().Printf("address\t%s", state.Address)
+	}
+	if state.ExpirationMs > 0 {
+		u.Out().Printf("expiration\t%s", formatUnixMillis(state.ExpirationMs))
+	}
+	if state.UpdatedAtMs > 0 {
+		u.Out().Printf("updated_at\t%s", formatUnixMillis(state.UpdatedAtMs))
+	}
+	if state.Hook != nil {
+		if state.Hook.URL != "" {
+			u.Out().Printf("hook_url\t%s", state.Hook.URL)
+		}
+		if state.Hook.Exec != "" {
+			u.Out().Printf("hook_exec\t%s", state.Hook.Exec)
+		}
+	}
+	if state.LastDeliveryStatus != "" {
+		u.Out().Printf("last_delivery_status\t%s", state.LastDeliveryStatus)
+	}
+	if state.LastDeliveryAtMs > 0 {
+		u.Out().Printf("last_delivery_at\t%s", formatUnixMillis(state.LastDeliveryAtMs))
+	}
+	if state.LastDeliveryStatusNote != "" {
+		u.Out().Printf("last_delivery_note\t%s", state.LastDeliveryStatusNote)
+	}
+	if state.ExpirationMs > 0 && time.Now().UnixMilli() > state.ExpirationMs {
+		u.Err().Printf("channel expired; run calendar watch renew %s", state.CalendarID)
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// calendarWatcher turns sync-token deltas into hook payloads. Push and poll
+// modes share it; only the trigger differs.
+type calendarWatcher struct {
+	account     string
+	calendarID  string
+	store       *calendarWatchStore
+	svc         *calendar.Service
+	hook        *watchHook
+	hookClient  *http.Client
+	hookTimeout time.Duration
+	mu          sync.Mutex
+	warnf       func(string, ...any)
+}
+
+// syncAndDeliver runs one incremental sync, hands any changes to emit and
+// only then advances the stored sync token, so changes a hook failed to
+// receive are sent again on the next notification or poll.
+func (w *calendarWatcher) syncAndDeliver(ctx context.Context, emit func(context.Context, *calendarHookPayload) error) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	payload, next, err := w.sync(ctx)
+	if err != nil {
+		return err
+	}
+	if payload != nil {
+		if err := emit(ctx, payload); err != nil {
+			return err
+		}
+	}
+	return w.store.Update(func(s *calendarWatchState) error {
+		s.SyncToken = next
+		s.UpdatedAtMs = time.Now().UnixMilli()
+		return nil
+	})
+}
+
+// sync fetches changes since the stored sync token and returns them with the
+// next token; callers hold w.mu and save the token once the changes are
+// delivered. The first run only records a baseline; an expired token (410)
+// re-lists and reports a resync without events, since individual changes can
+// no longer be derived.
+func (w *calendarWatcher) sync(ctx context.Context) (*calendarHookPayload, string, error) {
+	token := w.store.Get().SyncToken
+	resync := false
+	events, next, err := listCalendarSyncEvents(ctx, w.svc, w.calendarID, token)
+	if err != nil && token != "" && isSyncTokenExpired(err) {
+		w.warnf("watch: sync token expired; re-listing %s", w.calendarID)
+		resync = true
+		events, next, err = listCalendarSyncEvents(ctx, w.svc, w.calendarID, "")
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	payload := &calendarHookPayload{
+		Source:     "calendar",
+		Account:    w.account,
+		CalendarID: w.calendarID,
+		Resync:     resync,
+		Events:     []calendarHookEvent{},
+	}
+	if resync {
+		return payload, next, nil
+	}
+	if token == "" {
+		return nil, next, nil
+	}
+	for _, ev := range events {
+		if ev == nil || ev.Id == "" {
+			continue
+		}
+		payload.Events = append(payload.Events, calendarHookEventFrom(ev))
+	}
+	if len(payload.Events) == 0 {
+		return nil, next, nil
+	}
+	return payload, next, nil
+}
+
+func calendarHookEventFrom(ev *calendar.Event) calendarHookEvent {
+	out := calendarHookEvent{
+		ID:               ev.Id,
+		Action:           calendarHookAction(ev),
+		Status:           ev.Status,
+		Summary:          ev.Summary,
+		Start:            eventStart(ev),
+		End:              eventEnd(ev),
+		Location:         ev.Location,
+		RecurringEventID: ev.RecurringEventId,
+		HTMLLink:         ev.HtmlLink,
+		Updated:          ev.Updated,
+	}
+	if ev.Organizer != nil {
+		out.Organizer = ev.Organizer.Email
+	}
+	return out
+}
+
+// calendarHookAction classifies a delta entry. The API does not say whether
+// an event is new, so events whose update time is within a moment of their
+// creation time count as created.
+func calendarHookAction(ev *calendar.Event) string {
+	if ev.Status == "cancelled" {
+		return calendarHookActionCancelled
+	}
+	created, createdErr := time.Parse(time.RFC3339, ev.Created)
+	updated, updatedErr := time.Parse(time.RFC3339, ev.Updated)
+	if createdErr == nil && updatedErr == nil && updated.Sub(created) < 2*time.Second {
+		return calendarHookActionCreated
+	}
+	return calendarHookActionUpdated
+}
+
+// deliver sends the payload to the configured webhook and/or exec hook and
+// records the outcome in watch state.
+func (w *calendarWatcher) deliver(ctx context.Context, payload *calendarHookPayload) error {
+	if w.hook == nil || payload == nil {
+		return nil
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	status, note := "ok", ""
+	if w.hook.URL != "" {
+		if err := w.postHook(ctx, data); err != nil {
+			status, note = watchStatusHTTPError, err.Error()
+		}
+	}
+	if w.hook.Exec != "" && status == "ok" {
+		execCtx, cancel := context.WithTimeout(ctx, w.hookTimeout)
+		env := []string{
+			"GOG_HOOK_SOURCE=calendar",
+			"GOG_ACCOUNT=" + w.account,
+			"GOG_CALENDAR_ID=" + w.calendarID,
+		}
+		err := runWatchHookExec(execCtx, w.hook.Exec, env, data)
+		cancel()
+		if err != nil {
+			status, note = watchStatusExecError, err.Error()
+		}
+	}
+	_ = w.store.Update(func(s *calendarWatchState) error {
+		s.LastDeliveryStatus = status
+		s.LastDeliveryAtMs = time.Now().UnixMilli()
+		s.LastDeliveryStatusNote = note
+		return nil
+	})
+	if status != "ok" {
+		return fmt.Errorf("hook %s: %s", status, note)
+	}
+	return nil
+}
+
+func (w *calendarWatcher) postHook(ctx context.Context, data []byte) error {
+	return postWatchHook(ctx, w.hookClient, w.hook, data)
+}
+
+// calendarWatchServer receives events.watch notifications. Notifications
+// carry no event data, only a nudge to run an incremental sync.
+type calendarWatchServer struct {
+	cfg     calendarWatchServeConfig
+	watcher *calendarWatcher
+	logf    func(string, ...any)
+	warnf   func(string, ...any)
+}
+
+func (s *calendarWatchServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !pathMatches(s.cfg.Path, r.URL.Path) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	state := s.watcher.store.Get()
+	expected := s.cfg.SharedToken
+	if expected == "" {
+		expected = state.ChannelToken
+	}
+	if expected != "" && !channelTokenMatches(r, expected) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	channelID := r.Header.Get("X-Goog-Channel-ID")
+	if state.ChannelID != "" && channelID != state.ChannelID {
+		s.warnf("watch: ignoring notification for channel %s", channelID)
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	resourceState := r.Header.Get("X-Goog-Resource-State")
+	if msgNum := r.Header.Get("X-Goog-Message-Number"); msgNum != "" {
+		_ = s.watcher.store.Update(func(st *calendarWatchState) error {
+			st.LastMessageNumber = msgNum
+			return nil
+		})
+	}
+	if resourceState == "sync" {
+		if s.cfg.VerboseOutput {
+			s.logf("watch: channel %s confirmed", channelID)
+		}
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// A failed sync or hook leaves the sync token in place and answers 500,
+	// so Google retries the notification and the changes are sent again.
+	if err := s.watcher.syncAndDeliver(r.Context(), s.watcher.deliver); err != nil {
+		s.warnf("watch: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// channelTokenMatches accepts the channel token Google echoes back, or the
+// same x-gog-token / ?token= forms gmail watch serve understands.
+func channelTokenMatches(r *http.Request, expected string) bool {
+	if token := r.Header.Get("X-Goog-Channel-Token"); token != "" {
+		return subtle.ConstantTimeCompare([]byte(token), []byte(expected)) == 1
+	}
+	return sharedTokenMatches(r, expected)
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/steipete/gogcli/internal/config"
+)
+
+type calendarWatchStore struct {
+	path  string
+	mu    sync.Mutex
+	state calendarWatchState
+}
+
+// calendarWatchStatePath keys state by account and calendar so several
+// calendars of one account can be watched side by side.
+func calendarWatchStatePath(account, calendarID string) (string, error) {
+	dir, err := config.EnsureCalendarWatchDir()
+	if err != nil {
+		return "", err
+	}
+	name := sanitizeAccountForPath(account) + "__" + sanitizeAccountForPath(calendarID)
+	return filepath.Join(dir, name+".json"), nil
+}
+
+func newCalendarWatchStore(account, calendarID string) (*calendarWatchStore, error) {
+	path, err := calendarWatchStatePath(account, calendarID)
+	if err != nil {
+		return nil, err
+	}
+	return &calendarWatchStore{path: path}, nil
+}
+
+func loadCalendarWatchStore(account, calendarID string) (*calendarWatchStore, error) {
+	store, err := newCalendarWatchStore(account, calendarID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(store.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, errors.New("calendar watch state not found; run calendar watch start")
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &store.state); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// openCalendarWatchStore loads existing state, or returns an empty store when
+// none has been written yet (polling does not require watch start).
+func openCalendarWatchStore(account, calendarID string) (*calendarWatchStore, error) {
+	store, err := newCalendarWatchStore(account, calendarID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(store.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return store, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &store.state); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+func (s *calendarWatchStore) Get() calendarWatchState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state
+}
+
+func (s *calendarWatchStore) Update(fn func(*calendarWatchState) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := fn(&s.state); err != nil {
+		return err
+	}
+	return s.Save()
+}
+
+func (s *calendarWatchStore) Save() error {
+	if s.path == "" {
+		return errors.New("missing watch state path")
+	}
+	payload, err := json.MarshalIndent(s.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, append(payload, '\n'), 0o600)
+}
@@ -0,0 +1,284 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/option"
+)
+
+func calendarWatchTestService(t *testing.T) (*calendar.Service, *calendar.Channel) {
+	t.Helper()
+	watched := &calendar.Channel{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/calendars/primary/events/watch"):
+			body, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(body, watched)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"id":         watched.Id,
+				"resourceId": "res-1",
+				"expiration": "1730000000000",
+			})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/channels/stop"):
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/calendars/primary/events"):
+			switch r.URL.Query().Get("syncToken") {
+			case "":
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"items":         []any{map[string]any{"id": "old", "status": "confirmed"}},
+					"nextSyncToken": "t1",
+				})
+			case "t1":
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"items": []any{
+						map[string]any{
+							"id":      "new",
+							"status":  "confirmed",
+							"summary": "Standup",
+							"created": "2026-01-05T09:00:00.000Z",
+							"updated": "2026-01-05T09:00:00.400Z",
+							"start":   map[string]any{"dateTime": "2026-01-06T10:00:00Z"},
+							"end":     map[string]any{"dateTime": "2026-01-06T10:15:00Z"},
+						},
+						map[string]any{
+							"id":      "moved",
+							"status":  "confirmed",
+							"created": "2025-12-01T09:00:00Z",
+							"updated": "2026-01-05T09:00:00Z",
+						},
+						map[string]any{"id": "old", "status": "cancelled"},
+					},
+					"nextSyncToken": "t2",
+				})
+			default:
+				w.WriteHeader(http.StatusGone)
+				_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": 410, "message": "Sync token is no longer valid"}})
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	svc, err := calendar.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	return svc, watched
+}
+
+func TestCalendarWatchStartAndServe(t *testing.T) {
+	origNew := newCalendarService
+	t.Cleanup(func() { newCalendarService = origNew })
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+
+	svc, watched := calendarWatchTestService(t)
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return svc, nil }
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "calendar", "watch", "start",
+			"--address", "https://example.com/calendar-push", "--token", "secret", "--ttl", "1h"}); err != nil {
+			t.Fatalf("start: %v", err)
+		}
+	})
+	var parsed struct {
+		Watch calendarWatchState `json:"watch"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if watched.Type != "web_hook" || watched.Address != "https://example.com/calendar-push" || watched.Token != "secret" {
+		t.Fatalf("unexpected channel request: %#v", watched)
+	}
+	if watched.Params["ttl"] != "3600" {
+		t.Fatalf("unexpected ttl param: %#v", watched.Params)
+	}
+	st := parsed.Watch
+	if st.ChannelID != watched.Id || st.ResourceID != "res-1" || st.SyncToken != "t1" || st.ExpirationMs != 1730000000000 {
+		t.Fatalf("unexpected state: %#v", st)
+	}
+
+	var hookPayload calendarHookPayload
+	var hookAuth string
+	hookDown := true
+	hookSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hookDown {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		hookAuth = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&hookPayload)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer hookSrv.Close()
+
+	store, err := loadCalendarWatchStore("a@b.com", "primary")
+	if err != nil {
+		t.Fatalf("load store: %v", err)
+	}
+	cfg := calendarWatchServeConfig{
+		Account:     "a@b.com",
+		CalendarID:  "primary",
+		Path:        "/calendar-push",
+		Hook:        &watchHook{URL: hookSrv.URL, Token: "hooktok"},
+		HookTimeout: defaultHookRequestTimeoutSec * time.Second,
+	}
+	noop := func(string, ...any) {}
+	server := &calendarWatchServer{cfg: cfg, watcher: newCalendarWatcher(cfg, store, svc, noop), logf: noop, warnf: noop}
+
+	notify := func(token, state string) int {
+		req := httptest.NewRequest(http.MethodPost, "/calendar-push", nil)
+		req.Header.Set("X-Goog-Channel-ID", st.ChannelID)
+		req.Header.Set("X-Goog-Channel-Token", token)
+		req.Header.Set("X-Goog-Resource-State", state)
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := notify("wrong", "exists"); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", code)
+	}
+	if code := notify("secret", "sync"); code != http.StatusOK || hookPayload.Source != "" {
+		t.Fatalf("sync notification should not deliver: %d %#v", code, hookPayload)
+	}
+	if code := notify("secret", "exists"); code != http.StatusInternalServerError {
+		t.Fatalf("failed hook should answer 500 so Google retries, got %d", code)
+	}
+	if got := store.Get(); got.SyncToken != "t1" || got.LastDeliveryStatus != watchStatusHTTPError {
+		t.Fatalf("sync token must not advance past undelivered changes: %#v", got)
+	}
+	hookDown = false
+	if code := notify("secret", "exists"); code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	if hookAuth != "Bearer hooktok" {
+		t.Fatalf("unexpected hook auth: %q", hookAuth)
+	}
+	if hookPayload.Source != "calendar" || hookPayload.Account != "a@b.com" || len(hookPayload.Events) != 3 {
+		t.Fatalf("unexpected payload: %#v", hookPayload)
+	}
+	actions := []string{hookPayload.Events[0].Action, hookPayload.Events[1].Action, hookPayload.Events[2].Action}
+	if strings.Join(actions, ",") != "created,updated,cancelled" {
+		t.Fatalf("unexpected actions: %v", actions)
+	}
+	if hookPayload.Events[0].Start != "2026-01-06T10:00:00Z" || hookPayload.Events[0].Summary != "Standup" {
+		t.Fatalf("unexpected event: %#v", hookPayload.Events[0])
+	}
+	after := store.Get()
+	if after.SyncToken != "t2" || after.LastDeliveryStatus != "ok" {
+		t.Fatalf("unexpected state after delivery: %#v", after)
+	}
+}
+
+func TestCalendarWatchPoll_ExecHookAndResync(t *testing.T) {
+	origNew := newCalendarService
+	origExec := runWatchHookExec
+	t.Cleanup(func() {
+		newCalendarService = origNew
+		runWatchHookExec = origExec
+	})
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+
+	svc, _ := calendarWatchTestService(t)
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return svc, nil }
+
+	var payloads []calendarHookPayload
+	var gotCommand string
+	var gotEnv []string
+	runWatchHookExec = func(_ context.Context, command string, env []string, data []byte) error {
+		gotCommand, gotEnv = command, env
+		var p calendarHookPayload
+		if err := json.Unmarshal(data, &p); err != nil {
+			t.Fatalf("payload: %v", err)
+		}
+		payloads = append(payloads, p)
+		return nil
+	}
+
+	poll := func() {
+		t.Helper()
+		_ = captureStderr(t, func() {
+			if err := Execute([]string{"--account", "a@b.com", "calendar", "watch", "poll", "--once", "--hook-exec", "bot notify", "--save-hook"}); err != nil {
+				t.Fatalf("poll: %v", err)
+			}
+		})
+	}
+
+	poll()
+	if len(payloads) != 0 {
+		t.Fatalf("baseline poll should not deliver, got %#v", payloads)
+	}
+	poll()
+	if len(payloads) != 1 || len(payloads[0].Events) != 3 {
+		t.Fatalf("expected one delivery with 3 events, got %#v", payloads)
+	}
+	if gotCommand != "bot notify" || !strings.Contains(strings.Join(gotEnv, " "), "GOG_CALENDAR_ID=primary") {
+		t.Fatalf("unexpected exec: %q %v", gotCommand, gotEnv)
+	}
+	poll()
+	if len(payloads) != 2 || !payloads[1].Resync || len(payloads[1].Events) != 0 {
+		t.Fatalf("expected resync payload, got %#v", payloads)
+	}
+
+	store, err := loadCalendarWatchStore("a@b.com", "primary")
+	if err != nil {
+		t.Fatalf("load store: %v", err)
+	}
+	st := store.Get()
+	if st.Mode != calendarWatchModePoll || st.SyncToken != "t1" || st.Hook == nil || st.Hook.Exec != "bot notify" {
+		t.Fatalf("unexpected state: %#v", st)
+	}
+}
+
+func TestCalendarWatchServe_RequiresHook(t *testing.T) {
+	origNew := newCalendarService
+	t.Cleanup(func() { newCalendarService = origNew })
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+
+	svc, _ := calendarWatchTestService(t)
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return svc, nil }
+
+	_ = captureStdout(t, func() {
+		if err := Execute([]string{"--account", "a@b.com", "calendar", "watch", "start", "--address", "https://example.com/calendar-push"}); err != nil {
+			t.Fatalf("start: %v", err)
+		}
+	})
+	err := Execute([]string{"--account", "a@b.com", "calendar", "watch", "serve"})
+	if err == nil || !strings.Contains(err.Error(), "--hook-url or --hook-exec") {
+		t.Fatalf("expected missing hook error, got %v", err)
+	}
+}
+
+func TestCalendarHookFromFlags(t *testing.T) {
+	if hook, err := watchHookFromFlags("", "", ""); err != nil || hook != nil {
+		t.Fatalf("expected no hook, got %#v %v", hook, err)
+	}
+	if _, err := watchHookFromFlags("", "tok", ""); err == nil {
+		t.Fatalf("expected error for token without url")
+	}
+	hook, err := watchHookFromFlags("", "", " ./notify.sh ")
+	if err != nil || hook == nil || hook.Exec != "./notify.sh" {
+		t.Fatalf("unexpected exec hook: %#v %v", hook, err)
+	}
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import "time"
+
+const (
+	defaultCalendarWatchPath    = "/calendar-push"
+	defaultCalendarWatchPort    = 8789
+	calendarWatchModePush       = "push"
+	calendarWatchModePoll       = "poll"
+	calendarHookActionCreated   = "created"
+	calendarHookActionUpdated   = "updated"
+	calendarHookActionCancelled = "cancelled"
+)
+
+type calendarWatchState struct {
+	Account                string     `json:"account"`
+	CalendarID             string     `json:"calendarId"`
+	Mode                   string     `json:"mode"`
+	ChannelID              string     `json:"channelId,omitempty"`
+	ResourceID             string     `json:"resourceId,omitempty"`
+	ChannelToken           string     `json:"channelToken,omitempty"`
+	Address                string     `json:"address,omitempty"`
+	ExpirationMs           int64      `json:"expirationMs,omitempty"`
+	SyncToken              string     `json:"syncToken,omitempty"`
+	UpdatedAtMs            int64      `json:"updatedAtMs,omitempty"`
+	Hook                   *watchHook `json:"hook,omitempty"`
+	LastDeliveryStatus     string     `json:"lastDeliveryStatus,omitempty"`
+	LastDeliveryAtMs       int64      `json:"lastDeliveryAtMs,omitempty"`
+	LastDeliveryStatusNote string     `json:"lastDeliveryStatusNote,omitempty"`
+	LastMessageNumber      string     `json:"lastMessageNumber,omitempty"`
+}
+
+type calendarWatchServeConfig struct {
+	Account       string
+	CalendarID    string
+	Bind          string
+	Port          int
+	Path          string
+	SharedToken   string
+	Hook          *watchHook
+	HookTimeout   time.Duration
+	VerboseOutput bool
+}
+
+type calendarHookEvent struct {
+	ID               string `json:"id"`
+	Action           string `json:"action"`
+	Status           string `json:"status,omitempty"`
+	Summary          string `json:"summary,omitempty"`
+	Start            string `json:"start,omitempty"`
+	End              string `json:"end,omitempty"`
+	Location         string `json:"location,omitempty"`
+	Organizer        string `json:"organizer,omitempty"`
+	RecurringEventID string `json:"recurringEventId,omitempty"`
+	HTMLLink         string `json:"htmlLink,omitempty"`
+	Updated          string `json:"updated,omitempty"`
+}
+
+type calendarHookPayload struct {
+	Source     string              `json:"source"`
+	Account    string              `json:"account"`
+	CalendarID string              `json:"calendarId"`
+	Resync     bool                `json:"resync,omitempty"`
+	Events     []calendarHookEvent `json:"events"`
+}
@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"google.golang.org/api/drive/v3"
 	gapi "google.golang.org/api/googleapi"
@@ -26,6 +27,7 @@ const (
 	driveMimeGoogleSheet   = "application/vnd.google-apps.spreadsheet"
 	driveMimeGoogleSlides  = "application/vnd.google-apps.presentation"
 	driveMimeGoogleDrawing = "application/vnd.google-apps.drawing"
+	driveMimeFolder        = "application/vnd.google-apps.folder"
 	mimePDF                = "application/pdf"
 	mimeCSV                = "text/csv"
 	mimeDocx               = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
@@ -50,29 +52,36 @@ const (
 )
 
 type DriveCmd struct {
-	Ls          DriveLsCmd          `cmd:"" name:"ls" help:"List files in a folder (default: root)"`
-	Search      DriveSearchCmd      `cmd:"" name:"search" help:"Full-text search across Drive"`
-	Get         DriveGetCmd         `cmd:"" name:"get" help:"Get file metadata"`
-	Download    DriveDownloadCmd    `cmd:"" name:"download" help:"Download a file (exports Google Docs formats)"`
-	Copy        DriveCopyCmd        `cmd:"" name:"copy" help:"Copy a file"`
-	Upload      DriveUploadCmd      `cmd:"" name:"upload" help:"Upload a file"`
-	Mkdir       DriveMkdirCmd       `cmd:"" name:"mkdir" help:"Create a folder"`
-	Delete      DriveDeleteCmd      `cmd:"" name:"delete" help:"Delete a file (moves to trash)" aliases:"rm,del"`
-	Move        DriveMoveCmd        `cmd:"" name:"move" help:"Move a file to a different folder"`
-	Rename      DriveRenameCmd      `cmd:"" name:"rename" help:"Rename a file or folder"`
-	Share       DriveShareCmd       `cmd:"" name:"share" help:"Share a file or folder"`
-	Unshare     DriveUnshareCmd     `cmd:"" name:"unshare" help:"Remove a permission from a file"`
-	Permissions DrivePermissionsCmd `cmd:"" name:"permissions" help:"List permissions on a file"`
-	URL         DriveURLCmd         `cmd:"" name:"url" help:"Print web URLs for files"`
-	Comments    DriveCommentsCmd    `cmd:"" name:"comments" help:"Manage comments on files"`
-	Drives      DriveDrivesCmd      `cmd:"" name:"drives" help:"List shared drives (Team Drives)"`
+	Ls                DriveLsCmd                `cmd:"" name:"ls" help:"List files in a folder (default: root)"`
+	Search            DriveSearchCmd            `cmd:"" name:"search" help:"Full-text search across Drive"`
+	Get               DriveGetCmd               `cmd:"" name:"get" help:"Get file metadata"`
+	Download          DriveDownloadCmd          `cmd:"" name:"download" help:"Download a file (exports Google Docs formats)"`
+	Copy              DriveCopyCmd              `cmd:"" name:"copy" help:"Copy a file"`
+	Upload            DriveUploadCmd            `cmd:"" name:"upload" help:"Upload a file"`
+	Mkdir             DriveMkdirCmd             `cmd:"" name:"mkdir" help:"Create a folder"`
+	Delete            DriveDeleteCmd            `cmd:"" name:"delete" help:"Move a file to the trash (--permanent to delete it)" aliases:"rm,del"`
+	Move              DriveMoveCmd              `cmd:"" name:"move" help:"Move a file to a different folder"`
+	Rename            DriveRenameCmd            `cmd:"" name:"rename" help:"Rename a file or folder"`
+	Share             DriveShareCmd             `cmd:"" name:"share" help:"Share a file or folder"`
+	Unshare           DriveUnshareCmd           `cmd:"" name:"unshare" help:"Remove a permission from a file"`
+	Permissions       DrivePermissionsCmd       `cmd:"" name:"permissions" help:"List permissions on a file"`
+	URL               DriveURLCmd               `cmd:"" name:"url" help:"Print web URLs for files"`
+	Comments          DriveCommentsCmd          `cmd:"" name:"comments" help:"Manage comments on files"`
+	Drives            DriveDrivesCmd            `cmd:"" name:"drives" help:"List shared drives (Team Drives)"`
+	Revisions         DriveRevisionsCmd         `cmd:"" name:"revisions" aliases:"revs,versions" help:"List, download, pin and restore file revisions"`
+	Trash             DriveTrashCmd             `cmd:"" name:"trash" help:"List, restore and empty the trash"`
+	Changes           DriveChangesCmd           `cmd:"" name:"changes" help:"List the change feed or watch it for new, modified and trashed files"`
+	TransferOwnership DriveTransferOwnershipCmd `cmd:"" name:"transfer-ownership" aliases:"chown" help:"Transfer ownership of a file or folder tree (pending owner where consent is required)"`
+	Audit             DriveAuditCmd             `cmd:"" name:"audit" help:"Audit sharing below a folder or shared drive"`
+	Serve             DriveServeCmd             `cmd:"" name:"serve" help:"Serve Drive to local tools (WebDAV)"`
+	Sync              DriveSyncCmd              `cmd:"" name:"sync" help:"Sync a local directory with a Drive folder (push, pull or mirror)"`
 }
 
 type DriveLsCmd struct {
 	Max    int64  `name:"max" aliases:"limit" help:"Max results" default:"20"`
 	Page   string `name:"page" aliases:"cursor" help:"Page token"`
 	Query  string `name:"query" help:"Drive query filter"`
-	Parent string `name:"parent" help:"Folder ID to list (default: root)"`
+	Parent string `name:"parent" help:"Folder ID or path to list (default: root)"`
 }
 
 func (c *DriveLsCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -82,15 +91,14 @@ func (c *DriveLsCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return err
 	}
 
-	folderID := strings.TrimSpace(c.Parent)
-	if folderID == "" {
-		folderID = "root"
-	}
-
 	svc, err := newDriveService(ctx, account)
 	if err != nil {
 		return err
 	}
+	folderID, err := resolveDriveFolderRef(ctx, svc, c.Parent)
+	if err != nil {
+		return err
+	}
 
 	q := buildDriveListQuery(folderID, c.Query)
 
@@ -205,7 +213,7 @@ func (c *DriveSearchCmd) Run(ctx context.Context, flags *RootFlags) error {
 }
 
 type DriveGetCmd struct {
-	FileID string `arg:"" name:"fileId" help:"File ID"`
+	FileID string `arg:"" name:"fileId" help:"File ID or path (drive:/Folder/file)"`
 }
 
 func (c *DriveGetCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -223,6 +231,9 @@ func (c *DriveGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	if fileID, err = resolveDriveRef(ctx, svc, fileID); err != nil {
+		return err
+	}
 
 	f, err := svc.Files.Get(fileID).
 		SupportsAllDrives(true).
@@ -254,9 +265,12 @@ func (c *DriveGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 }
 
 type DriveDownloadCmd struct {
-	FileID string         `arg:"" name:"fileId" help:"File ID"`
-	Output OutputPathFlag `embed:""`
-	Format string         `name:"format" help:"Export format for Google Docs files: pdf|csv|xlsx|pptx|txt|png|docx (default: auto)"`
+	FileID      string         `arg:"" name:"fileId" help:"File or folder ID or path (drive:/Folder)"`
+	Output      OutputPathFlag `embed:""`
+	Format      string         `name:"format" help:"Export format for Google Docs files: pdf|csv|xlsx|pptx|txt|png|docx (default: auto; in folders, applies where valid)"`
+	Recursive   bool           `name:"recursive" short:"r" help:"Download a folder, recreating its tree (follows shortcuts)"`
+	Zip         bool           `name:"zip" help:"Download a folder into a single .zip archive"`
+	Concurrency int            `name:"concurrency" help:"Parallel downloads for --recursive" default:"4"`
 }
 
 func (c *DriveDownloadCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -275,10 +289,13 @@ func (c *DriveDownloadCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	if fileID, err = resolveDriveRef(ctx, svc, fileID); err != nil {
+		return err
+	}
 
 	meta, err := svc.Files.Get(fileID).
 		SupportsAllDrives(true).
-		Fields("id, name, mimeType").
+		Fields("id, name, mimeType, shortcutDetails(targetId, targetMimeType)").
 		Context(ctx).
 		Do()
 	if err != nil {
@@ -287,11 +304,23 @@ func (c *DriveDownloadCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if meta.Name == "" {
 		return errors.New("file has no name")
 	}
+	if meta.MimeType == driveMimeShortcut && meta.ShortcutDetails != nil {
+		meta = &drive.File{Id: meta.ShortcutDetails.TargetId, Name: meta.Name, MimeType: meta.ShortcutDetails.TargetMimeType}
+	}
 
 	destPath, err := resolveDriveDownloadDestPath(meta, c.Output.Path)
 	if err != nil {
 		return err
 	}
+	if meta.MimeType == driveMimeFolder {
+		if !c.Recursive && !c.Zip {
+			return usagef("%s is a folder; use --recursive or --zip", fileID)
+		}
+		return c.downloadFolder(ctx, svc, meta, destPath)
+	}
+	if c.Zip {
+		return usage("--zip only applies to folders")
+	}
 
 	downloadedPath, size, err := downloadDriveFile(ctx, svc, meta, destPath, c.Format)
 	if err != nil {
@@ -310,10 +339,71 @@ func (c *DriveDownloadCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (c *DriveDownloadCmd) downloadFolder(ctx context.Context, svc *drive.Service, meta *drive.File, destPath string) error {
+	u := ui.FromContext(ctx)
+	if c.Concurrency < 1 {
+		return usage("--concurrency must be at least 1")
+	}
+	files, err := collectDriveTree(ctx, svc, meta.Id, c.Format)
+	if err != nil {
+		return err
+	}
+
+	var results []driveTransferResult
+	if c.Zip {
+		if !strings.EqualFold(filepath.Ext(destPath), ".zip") {
+			destPath += ".zip"
+		}
+		results, err = zipDriveTree(ctx, svc, files, destPath, driveSafeName(meta.Name), c.Format)
+		if err != nil {
+			return err
+		}
+	} else {
+		if err = os.MkdirAll(destPath, 0o700); err != nil {
+			return err
+		}
+		results = downloadDriveTree(ctx, svc, files, destPath, c.Format, c.Concurrency)
+	}
+	failed, size := countTransferFailures(results)
+	if c.Zip && failed > 0 {
+		// zipDriveTree discards the archive when an entry is missing.
+		destPath = ""
+	}
+
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"path":   destPath,
+			"size":   size,
+			"files":  results,
+			"failed": failed,
+		}); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range results {
+			if r.Error != "" {
+				u.Err().Printf("failed\t%s\t%s", r.Path, r.Error)
+			}
+		}
+		if destPath != "" {
+			u.Out().Printf("path\t%s", destPath)
+		}
+		u.Out().Printf("files\t%d", len(results)-failed)
+		u.Out().Printf("size\t%s", formatDriveSize(size))
+	}
+	if c.Zip && failed > 0 {
+		return fmt.Errorf("%d of %d downloads failed; no archive was written", failed, len(results))
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d downloads failed", failed, len(results))
+	}
+	return nil
+}
+
 type DriveCopyCmd struct {
-	FileID string `arg:"" name:"fileId" help:"File ID"`
+	FileID string `arg:"" name:"fileId" help:"File ID or path (drive:/Folder/file)"`
 	Name   string `arg:"" name:"name" help:"New file name"`
-	Parent string `name:"parent" help:"Destination folder ID"`
+	Parent string `name:"parent" help:"Destination folder ID or path"`
 }
 
 func (c *DriveCopyCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -325,12 +415,15 @@ func (c *DriveCopyCmd) Run(ctx context.Context, flags *RootFlags) error {
 type DriveUploadCmd struct {
 	LocalPath           string `arg:"" name:"localPath" help:"Path to local file"`
 	Name                string `name:"name" help:"Override filename (create) or rename target (replace)"`
-	Parent              string `name:"parent" help:"Destination folder ID (create only)"`
-	ReplaceFileID       string `name:"replace" help:"Replace the content of an existing Drive file ID (preserves shared link/permissions)"`
+	Parent              string `name:"parent" help:"Destination folder ID or path (create only)"`
+	ReplaceFileID       string `name:"replace" help:"Replace the content of an existing Drive file, by ID or path (preserves shared link/permissions)"`
 	MimeType            string `name:"mime-type" help:"Override MIME type inference"`
 	KeepRevisionForever bool   `name:"keep-revision-forever" help:"Keep the new head revision forever (binary files only)"`
 	Convert             bool   `name:"convert" help:"Auto-convert to native Google format based on file extension (create only)"`
 	ConvertTo           string `name:"convert-to" help:"Convert to a specific Google format: doc|sheet|slides (create only)"`
+	Recursive           bool   `name:"recursive" short:"r" help:"Upload a directory as a new folder, keeping its structure"`
+	Concurrency         int    `name:"concurrency" help:"Parallel uploads for --recursive" default:"4"`
+	Resume              bool   `name:"resume" help:"Continue an interrupted upload of the same file (large files always upload in resumable chunks)"`
 }
 
 func (c *DriveUploadCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -349,6 +442,10 @@ func (c *DriveUploadCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return err
 	}
 
+	if st, statErr := os.Stat(localPath); statErr == nil && st.IsDir() {
+		return c.uploadDir(ctx, account, localPath)
+	}
+
 	f, err := os.Open(localPath) //nolint:gosec // user-provided path
 	if err != nil {
 		return err
@@ -387,6 +484,26 @@ func (c *DriveUploadCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	if replaceFileID, err = resolveDriveRef(ctx, svc, replaceFileID); err != nil {
+		return err
+	}
+	if parent, err = resolveDriveRef(ctx, svc, parent); err != nil {
+		return err
+	}
+
+	st, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	resumable := c.Resume || st.Size() >= driveResumableThreshold
+	upload := driveResumableUpload{
+		Account:             account,
+		LocalPath:           localPath,
+		FileID:              replaceFileID,
+		MimeType:            mimeType,
+		KeepRevisionForever: c.KeepRevisionForever,
+		Resume:              c.Resume,
+	}
 
 	if replaceFileID == "" {
 		if fileName == "" {
@@ -404,16 +521,22 @@ func (c *DriveUploadCmd) Run(ctx context.Context, flags *RootFlags) error {
 			}
 		}
 
-		createCall := svc.Files.Create(meta).
-			SupportsAllDrives(true).
-			Media(f, gapi.ContentType(mimeType)).
-			Fields("id, name, mimeType, size, webViewLink").
-			Context(ctx)
-		if c.KeepRevisionForever {
-			createCall = createCall.KeepRevisionForever(true)
+		var created *drive.File
+		var createErr error
+		if resumable {
+			upload.Meta = meta
+			created, createErr = uploadDriveResumable(ctx, svc, f, upload)
+		} else {
+			createCall := svc.Files.Create(meta).
+				SupportsAllDrives(true).
+				Media(f, gapi.ContentType(mimeType)).
+				Fields("id, name, mimeType, size, webViewLink").
+				Context(ctx)
+			if c.KeepRevisionForever {
+				createCall = createCall.KeepRevisionForever(true)
+			}
+			created, createErr = createCall.Do()
 		}
-
-		created, createErr := createCall.Do()
 		if createErr != nil {
 			return createErr
 		}
@@ -449,15 +572,21 @@ func (c *DriveUploadCmd) Run(ctx context.Context, flags *RootFlags) error {
 		meta.Name = fileName
 	}
 
-	call := svc.Files.Update(replaceFileID, meta).
-		SupportsAllDrives(true).
-		Media(f, gapi.ContentType(mimeType)).
-		Fields("id, name, mimeType, size, webViewLink").
-		Context(ctx)
-	if c.KeepRevisionForever {
-		call = call.KeepRevisionForever(true)
+	var updated *drive.File
+	if resumable {
+		upload.Meta = meta
+		updated, err = uploadDriveResumable(ctx, svc, f, upload)
+	} else {
+		call := svc.Files.Update(replaceFileID, meta).
+			SupportsAllDrives(true).
+			Media(f, gapi.ContentType(mimeType)).
+			Fields("id, name, mimeType, size, webViewLink").
+			Context(ctx)
+		if c.KeepRevisionForever {
+			call = call.KeepRevisionForever(true)
+		}
+		updated, err = call.Do()
 	}
-	updated, err := call.Do()
 	if err != nil {
 		return err
 	}
@@ -479,9 +608,69 @@ func (c *DriveUploadCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (c *DriveUploadCmd) uploadDir(ctx context.Context, account, localDir string) error {
+	u := ui.FromContext(ctx)
+	switch {
+	case !c.Recursive:
+		return usagef("%s is a directory; use --recursive", localDir)
+	case strings.TrimSpace(c.ReplaceFileID) != "":
+		return usage("--replace cannot be combined with --recursive")
+	case strings.TrimSpace(c.ConvertTo) != "":
+		return usage("--convert-to cannot be combined with --recursive (use --convert)")
+	case c.Concurrency < 1:
+		return usage("--concurrency must be at least 1")
+	}
+	name := strings.TrimSpace(c.Name)
+	if name == "" {
+		name = filepath.Base(filepath.Clean(localDir))
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	parent, err := resolveDriveRef(ctx, svc, c.Parent)
+	if err != nil {
+		return err
+	}
+	folder, results, err := uploadDriveTree(ctx, svc, localDir, name, parent, c.Convert, c.Concurrency)
+	if err != nil {
+		return err
+	}
+	failed, size := countTransferFailures(results)
+
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"folder": folder,
+			"files":  results,
+			"size":   size,
+			"failed": failed,
+		}); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range results {
+			if r.Error != "" {
+				u.Err().Printf("failed\t%s\t%s", r.Path, r.Error)
+			}
+		}
+		u.Out().Printf("id\t%s", folder.Id)
+		u.Out().Printf("name\t%s", folder.Name)
+		u.Out().Printf("files\t%d", len(results)-failed)
+		u.Out().Printf("size\t%s", formatDriveSize(size))
+		if folder.WebViewLink != "" {
+			u.Out().Printf("link\t%s", folder.WebViewLink)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d uploads failed", failed, len(results))
+	}
+	return nil
+}
+
 type DriveMkdirCmd struct {
 	Name   string `arg:"" name:"name" help:"Folder name"`
-	Parent string `name:"parent" help:"Parent folder ID"`
+	Parent string `name:"parent" help:"Parent folder ID or path"`
 }
 
 func (c *DriveMkdirCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -501,19 +690,11 @@ func (c *DriveMkdirCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return err
 	}
 
-	f := &drive.File{
-		Name:     name,
-		MimeType: "application/vnd.google-apps.folder",
-	}
-	if strings.TrimSpace(c.Parent) != "" {
-		f.Parents = []string{strings.TrimSpace(c.Parent)}
+	parent, err := resolveDriveRef(ctx, svc, c.Parent)
+	if err != nil {
+		return err
 	}
-
-	created, err := svc.Files.Create(f).
-		SupportsAllDrives(true).
-		Fields("id, name, webViewLink").
-		Context(ctx).
-		Do()
+	created, err := createDriveFolder(ctx, svc, name, parent)
 	if err != nil {
 		return err
 	}
@@ -530,8 +711,25 @@ func (c *DriveMkdirCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+// createDriveFolder creates a folder under parent ("" for My Drive).
+func createDriveFolder(ctx context.Context, svc *drive.Service, name, parent string) (*drive.File, error) {
+	f := &drive.File{
+		Name:     name,
+		MimeType: driveMimeFolder,
+	}
+	if parent != "" {
+		f.Parents = []string{parent}
+	}
+	return svc.Files.Create(f).
+		SupportsAllDrives(true).
+		Fields("id, name, webViewLink").
+		Context(ctx).
+		Do()
+}
+
 type DriveDeleteCmd struct {
-	FileID string `arg:"" name:"fileId" help:"File ID"`
+	FileID    string `arg:"" name:"fileId" help:"File ID or path (drive:/Folder/file)"`
+	Permanent bool   `name:"permanent" help:"Delete permanently instead of moving to the trash (cannot be undone)"`
 }
 
 func (c *DriveDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -545,7 +743,11 @@ func (c *DriveDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return usage("empty fileId")
 	}
 
-	if confirmErr := confirmDestructive(ctx, flags, fmt.Sprintf("delete drive file %s", fileID)); confirmErr != nil {
+	action := fmt.Sprintf("move drive file %s to the trash", fileID)
+	if c.Permanent {
+		action = fmt.Sprintf("permanently delete drive file %s", fileID)
+	}
+	if confirmErr := confirmDestructive(ctx, flags, action); confirmErr != nil {
 		return confirmErr
 	}
 
@@ -553,24 +755,34 @@ func (c *DriveDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	if fileID, err = resolveDriveRef(ctx, svc, fileID); err != nil {
+		return err
+	}
 
-	if err := svc.Files.Delete(fileID).SupportsAllDrives(true).Context(ctx).Do(); err != nil {
+	if c.Permanent {
+		err = svc.Files.Delete(fileID).SupportsAllDrives(true).Context(ctx).Do()
+	} else {
+		_, err = setDriveTrashed(ctx, svc, fileID, true)
+	}
+	if err != nil {
 		return err
 	}
 	if outfmt.IsJSON(ctx) {
 		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
 			"deleted": true,
+			"trashed": !c.Permanent,
 			"id":      fileID,
 		})
 	}
 	u.Out().Printf("deleted\ttrue")
+	u.Out().Printf("trashed\t%t", !c.Permanent)
 	u.Out().Printf("id\t%s", fileID)
 	return nil
 }
 
 type DriveMoveCmd struct {
-	FileID string `arg:"" name:"fileId" help:"File ID"`
-	Parent string `name:"parent" help:"New parent folder ID (required)"`
+	FileID string `arg:"" name:"fileId" help:"File ID or path (drive:/Folder/file)"`
+	Parent string `name:"parent" help:"New parent folder ID or path (required)"`
 }
 
 func (c *DriveMoveCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -592,6 +804,12 @@ func (c *DriveMoveCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	if fileID, err = resolveDriveRef(ctx, svc, fileID); err != nil {
+		return err
+	}
+	if parent, err = resolveDriveRef(ctx, svc, parent); err != nil {
+		return err
+	}
 
 	meta, err := svc.Files.Get(fileID).
 		SupportsAllDrives(true).
@@ -625,7 +843,7 @@ func (c *DriveMoveCmd) Run(ctx context.Context, flags *RootFlags) error {
 }
 
 type DriveRenameCmd struct {
-	FileID  string `arg:"" name:"fileId" help:"File ID"`
+	FileID  string `arg:"" name:"fileId" help:"File ID or path (drive:/Folder/file)"`
 	NewName string `arg:"" name:"newName" help:"New name"`
 }
 
@@ -648,6 +866,9 @@ func (c *DriveRenameCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	if fileID, err = resolveDriveRef(ctx, svc, fileID); err != nil {
+		return err
+	}
 
 	updated, err := svc.Files.Update(fileID, &drive.File{Name: newName}).
 		SupportsAllDrives(true).
@@ -668,13 +889,16 @@ func (c *DriveRenameCmd) Run(ctx context.Context, flags *RootFlags) error {
 }
 
 type DriveShareCmd struct {
-	FileID       string `arg:"" name:"fileId" help:"File ID"`
+	FileID       string `arg:"" optional:"" name:"fileId" help:"File or folder ID or path (omit with --from-csv)"`
 	To           string `name:"to" help:"Share target: anyone|user|domain"`
 	Anyone       bool   `name:"anyone" hidden:"" help:"(deprecated) Use --to=anyone"`
 	Email        string `name:"email" help:"User email (for --to=user)"`
 	Domain       string `name:"domain" help:"Domain (for --to=domain; e.g. example.com)"`
 	Role         string `name:"role" help:"Permission: reader|writer" default:"reader"`
 	Discoverable bool   `name:"discoverable" help:"Allow file discovery in search (anyone/domain only)"`
+	Expires      string `name:"expires" help:"Remove the access after a duration (30d, 2w, 12h) or at a date/time (--to=user only; with --from-csv, the default for user rows without expires)"`
+	Recursive    bool   `name:"recursive" short:"r" help:"Also grant the permission on everything below a folder"`
+	FromCSV      string `name:"from-csv" help:"Apply grants from a CSV file with columns file,email|domain|to,role,expires"`
 }
 
 func (c *DriveShareCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -684,10 +908,94 @@ func (c *DriveShareCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return err
 	}
 	fileID := strings.TrimSpace(c.FileID)
+	if strings.TrimSpace(c.FromCSV) != "" {
+		if fileID != "" {
+			return usage("--from-csv cannot be combined with a fileId")
+		}
+		return c.runCSV(ctx, flags, account)
+	}
 	if fileID == "" {
 		return usage("empty fileId")
 	}
 
+	perm, err := c.permission(time.Now())
+	if err != nil {
+		return err
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	if fileID, err = resolveDriveRef(ctx, svc, fileID); err != nil {
+		return err
+	}
+
+	// The grants made below the folder are direct ones, so list the tree and
+	// confirm before writing anything.
+	var items []driveTreeFile
+	if c.Recursive {
+		if items, err = listDriveDescendants(ctx, svc, fileID); err != nil {
+			return err
+		}
+		if err := dryRunExit(ctx, flags, "drive.share.recursive", map[string]any{"fileId": fileID, "permission": perm, "fileIds": driveTreeFileIDs(items)}); err != nil {
+			return err
+		}
+		if err := confirmDestructive(ctx, flags, fmt.Sprintf("share drive file %s and %d items below it", fileID, len(items))); err != nil {
+			return err
+		}
+	}
+
+	created, err := createDrivePermission(ctx, svc, fileID, perm)
+	if err != nil {
+		return err
+	}
+
+	link, err := driveWebLink(ctx, svc, fileID)
+	if err != nil {
+		return err
+	}
+
+	var below []driveShareResult
+	failed := 0
+	if c.Recursive {
+		below, failed = shareDriveDescendants(ctx, svc, items, perm)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		out := map[string]any{
+			"link":         link,
+			"permissionId": created.Id,
+			"permission":   created,
+		}
+		if c.Recursive {
+			out["descendants"] = below
+			out["failed"] = failed
+		}
+		if err := outfmt.WriteJSON(ctx, os.Stdout, out); err != nil {
+			return err
+		}
+	} else {
+		u.Out().Printf("link\t%s", link)
+		u.Out().Printf("permission_id\t%s", created.Id)
+		if c.Recursive {
+			u.Out().Printf("descendants\t%d", len(below)-failed)
+			for _, r := range below {
+				if r.Error != "" {
+					u.Err().Printf("share: %s: %s", r.Path, r.Error)
+				}
+			}
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d items below the folder could not be shared", failed, len(below))
+	}
+	return nil
+}
+
+// permission validates the share target flags and builds the permission to
+// create. Relative --expires values count from now.
+func (c *DriveShareCmd) permission(now time.Time) (*drive.Permission, error) {
 	to := strings.TrimSpace(c.To)
 	email := strings.TrimSpace(c.Email)
 	domain := strings.TrimSpace(c.Domain)
@@ -703,49 +1011,44 @@ func (c *DriveShareCmd) Run(ctx context.Context, flags *RootFlags) error {
 		case !c.Anyone && email == "" && domain != "":
 			to = driveShareToDomain
 		case !c.Anyone && email == "" && domain == "":
-			return usage("must specify --to (anyone|user|domain)")
+			return nil, usage("must specify --to (anyone|user|domain)")
 		default:
-			return usage("ambiguous share target (use --to=anyone|user|domain)")
+			return nil, usage("ambiguous share target (use --to=anyone|user|domain)")
 		}
 	}
 
 	switch to {
 	case driveShareToAnyone:
 		if email != "" || domain != "" {
-			return usage("--to=anyone cannot be combined with --email or --domain")
+			return nil, usage("--to=anyone cannot be combined with --email or --domain")
 		}
 	case driveShareToUser:
 		if email == "" {
-			return usage("missing --email for --to=user")
+			return nil, usage("missing --email for --to=user")
 		}
 		if domain != "" || c.Anyone {
-			return usage("--to=user cannot be combined with --anyone or --domain")
+			return nil, usage("--to=user cannot be combined with --anyone or --domain")
 		}
 		if c.Discoverable {
-			return usage("--discoverable is only valid for --to=anyone or --to=domain")
+			return nil, usage("--discoverable is only valid for --to=anyone or --to=domain")
 		}
 	case driveShareToDomain:
 		if domain == "" {
-			return usage("missing --domain for --to=domain")
+			return nil, usage("missing --domain for --to=domain")
 		}
 		if email != "" || c.Anyone {
-			return usage("--to=domain cannot be combined with --anyone or --email")
+			return nil, usage("--to=domain cannot be combined with --anyone or --email")
 		}
 	default:
 		// Should be guarded by enum, but keep a friendly message for future changes.
-		return usage("invalid --to (expected anyone|user|domain)")
+		return nil, usage("invalid --to (expected anyone|user|domain)")
 	}
 	role := strings.TrimSpace(c.Role)
 	if role == "" {
 		role = drivePermRoleReader
 	}
 	if role != drivePermRoleReader && role != drivePermRoleWriter {
-		return usage("invalid --role (expected reader|writer)")
-	}
-
-	svc, err := newDriveService(ctx, account)
-	if err != nil {
-		return err
+		return nil, usage("invalid --role (expected reader|writer)")
 	}
 
 	perm := &drive.Permission{Role: role}
@@ -762,37 +1065,23 @@ func (c *DriveShareCmd) Run(ctx context.Context, flags *RootFlags) error {
 		perm.EmailAddress = email
 	}
 
-	created, err := svc.Permissions.Create(fileID, perm).
-		SupportsAllDrives(true).
-		SendNotificationEmail(false).
-		Fields("id, type, role, emailAddress, domain, allowFileDiscovery").
-		Context(ctx).
-		Do()
-	if err != nil {
-		return err
-	}
-
-	link, err := driveWebLink(ctx, svc, fileID)
-	if err != nil {
-		return err
-	}
-
-	if outfmt.IsJSON(ctx) {
-		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
-			"link":         link,
-			"permissionId": created.Id,
-			"permission":   created,
-		})
+	if expires := strings.TrimSpace(c.Expires); expires != "" {
+		if to != driveShareToUser {
+			return nil, usage("--expires is only valid for --to=user")
+		}
+		at, err := parseDriveShareExpires(expires, now)
+		if err != nil {
+			return nil, err
+		}
+		perm.ExpirationTime = at.UTC().Format(time.RFC3339)
 	}
-
-	u.Out().Printf("link\t%s", link)
-	u.Out().Printf("permission_id\t%s", created.Id)
-	return nil
+	return perm, nil
 }
 
 type DriveUnshareCmd struct {
-	FileID       string `arg:"" name:"fileId" help:"File ID"`
+	FileID       string `arg:"" name:"fileId" help:"File ID or path (drive:/Folder/file)"`
 	PermissionID string `arg:"" name:"permissionId" help:"Permission ID"`
+	Recursive    bool   `name:"recursive" short:"r" help:"Also remove the permission from everything below a folder (undoes share --recursive)"`
 }
 
 func (c *DriveUnshareCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -810,35 +1099,83 @@ func (c *DriveUnshareCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return usage("empty permissionId")
 	}
 
-	if confirmErr := confirmDestructive(ctx, flags, fmt.Sprintf("remove permission %s from drive file %s", permissionID, fileID)); confirmErr != nil {
-		return confirmErr
+	if !c.Recursive {
+		if confirmErr := confirmDestructive(ctx, flags, fmt.Sprintf("remove permission %s from drive file %s", permissionID, fileID)); confirmErr != nil {
+			return confirmErr
+		}
 	}
 
 	svc, err := newDriveService(ctx, account)
 	if err != nil {
 		return err
 	}
+	if fileID, err = resolveDriveRef(ctx, svc, fileID); err != nil {
+		return err
+	}
+
+	var items []driveTreeFile
+	if c.Recursive {
+		if items, err = listDriveDescendants(ctx, svc, fileID); err != nil {
+			return err
+		}
+		if err := dryRunExit(ctx, flags, "drive.unshare.recursive", map[string]any{"fileId": fileID, "permissionId": permissionID, "fileIds": driveTreeFileIDs(items)}); err != nil {
+			return err
+		}
+		if err := confirmDestructive(ctx, flags, fmt.Sprintf("remove permission %s from drive file %s and %d items below it", permissionID, fileID, len(items))); err != nil {
+			return err
+		}
+	}
 
 	if err := svc.Permissions.Delete(fileID, permissionID).SupportsAllDrives(true).Context(ctx).Do(); err != nil {
-		return err
+		// Inherited grants vanish with the folder's, so a recursive run still
+		// walks the tree when the folder itself no longer has the grant.
+		if !c.Recursive || !isNotFoundAPIError(err) {
+			return err
+		}
+	}
+
+	var below []driveShareResult
+	skipped, failed := 0, 0
+	if c.Recursive {
+		below, skipped, failed = unshareDriveDescendants(ctx, svc, items, permissionID)
 	}
 
 	if outfmt.IsJSON(ctx) {
-		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+		out := map[string]any{
 			"removed":      true,
 			"fileId":       fileID,
 			"permissionId": permissionID,
-		})
+		}
+		if c.Recursive {
+			out["descendants"] = below
+			out["skipped"] = skipped
+			out["failed"] = failed
+		}
+		if err := outfmt.WriteJSON(ctx, os.Stdout, out); err != nil {
+			return err
+		}
+	} else {
+		u.Out().Printf("removed\ttrue")
+		u.Out().Printf("file_id\t%s", fileID)
+		u.Out().Printf("permission_id\t%s", permissionID)
+		if c.Recursive {
+			u.Out().Printf("descendants\t%d", len(below)-skipped-failed)
+			u.Out().Printf("skipped\t%d", skipped)
+			for _, r := range below {
+				if r.Error != "" {
+					u.Err().Printf("unshare: %s: %s", r.Path, r.Error)
+				}
+			}
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d items below the folder could not be unshared", failed, len(below))
 	}
-
-	u.Out().Printf("removed\ttrue")
-	u.Out().Printf("file_id\t%s", fileID)
-	u.Out().Printf("permission_id\t%s", permissionID)
 	return nil
 }
 
 type DrivePermissionsCmd struct {
-	FileID string `arg:"" name:"fileId" help:"File ID"`
+	FileID string `arg:"" name:"fileId" help:"File ID or path (drive:/Folder/file)"`
 	Max    int64  `name:"max" aliases:"limit" help:"Max results" default:"100"`
 	Page   string `name:"page" aliases:"cursor" help:"Page token"`
 }
@@ -858,6 +1195,9 @@ func (c *DrivePermissionsCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	if fileID, err = resolveDriveRef(ctx, svc, fileID); err != nil {
+		return err
+	}
 
 	call := svc.Permissions.List(fileID).
 		SupportsAllDrives(true).
@@ -905,7 +1245,7 @@ func (c *DrivePermissionsCmd) Run(ctx context.Context, flags *RootFlags) error {
 }
 
 type DriveURLCmd struct {
-	FileIDs []string `arg:"" name:"fileId" help:"File IDs"`
+	FileIDs []string `arg:"" name:"fileId" help:"File IDs or paths"`
 }
 
 func (c *DriveURLCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -920,26 +1260,23 @@ func (c *DriveURLCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return err
 	}
 
-	for _, id := range c.FileIDs {
+	urls := make([]map[string]string, 0, len(c.FileIDs))
+	for _, ref := range c.FileIDs {
+		id, err := resolveDriveRef(ctx, svc, ref)
+		if err != nil {
+			return err
+		}
 		link, err := driveWebLink(ctx, svc, id)
 		if err != nil {
 			return err
 		}
 		if outfmt.IsJSON(ctx) {
-			// collected below
+			urls = append(urls, map[string]string{"id": id, "url": link})
 		} else {
 			u.Out().Printf("%s\t%s", id, link)
 		}
 	}
 	if outfmt.IsJSON(ctx) {
-		urls := make([]map[string]string, 0, len(c.FileIDs))
-		for _, id := range c.FileIDs {
-			link, err := driveWebLink(ctx, svc, id)
-			if err != nil {
-				return err
-			}
-			urls = append(urls, map[string]string{"id": id, "url": link})
-		}
 		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"urls": urls})
 	}
 	return nil
@@ -972,7 +1309,7 @@ func escapeDriveQueryString(s string) string {
 }
 
 func driveType(mimeType string) string {
-	if mimeType == "application/vnd.google-apps.folder" {
+	if mimeType == driveMimeFolder {
 		return "folder"
 	}
 	return strFile
@@ -1051,54 +1388,59 @@ func guessMimeType(path string) string {
 }
 
 func downloadDriveFile(ctx context.Context, svc *drive.Service, meta *drive.File, destPath string, format string) (string, int64, error) {
-	isGoogleDoc := strings.HasPrefix(meta.MimeType, "application/vnd.google-apps.")
-
-	var (
-		resp    *http.Response
-		outPath string
-		err     error
-	)
-
-	if isGoogleDoc {
-		var exportMimeType string
-		if strings.TrimSpace(format) == "" {
-			exportMimeType = driveExportMimeType(meta.MimeType)
-		} else {
-			var mimeErr error
-			exportMimeType, mimeErr = driveExportMimeTypeForFormat(meta.MimeType, format)
-			if mimeErr != nil {
-				return "", 0, mimeErr
-			}
-		}
-		outPath = replaceExt(destPath, driveExportExtension(exportMimeType))
-		resp, err = driveExportDownload(ctx, svc, meta.Id, exportMimeType)
-	} else {
-		outPath = destPath
-		resp, err = driveDownload(ctx, svc, meta.Id)
-	}
+	body, exportExt, err := openDriveContent(ctx, svc, meta, format)
 	if err != nil {
 		return "", 0, err
 	}
-	defer resp.Body.Close()
+	defer body.Close()
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		body, _ := io.ReadAll(resp.Body)
-		return "", 0, fmt.Errorf("download failed: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	outPath := destPath
+	if exportExt != "" {
+		outPath = replaceExt(destPath, exportExt)
 	}
-
 	f, err := os.Create(outPath) //nolint:gosec // user-provided path
 	if err != nil {
 		return "", 0, err
 	}
 	defer f.Close()
 
-	n, err := io.Copy(f, resp.Body)
+	n, err := io.Copy(f, body)
 	if err != nil {
 		return "", 0, err
 	}
 	return outPath, n, nil
 }
 
+// openDriveContent starts downloading a file's content, exporting Google
+// Docs formats. exportExt is the extension of the export ("" for binary files).
+func openDriveContent(ctx context.Context, svc *drive.Service, meta *drive.File, format string) (io.ReadCloser, string, error) {
+	var (
+		resp      *http.Response
+		exportExt string
+		err       error
+	)
+	if strings.HasPrefix(meta.MimeType, "application/vnd.google-apps.") {
+		exportMimeType, mimeErr := driveExportMimeTypeForFormat(meta.MimeType, format)
+		if mimeErr != nil {
+			return nil, "", mimeErr
+		}
+		exportExt = driveExportExtension(exportMimeType)
+		resp, err = driveExportDownload(ctx, svc, meta.Id, exportMimeType)
+	} else {
+		resp, err = driveDownload(ctx, svc, meta.Id)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, "", fmt.Errorf("download failed: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return resp.Body, exportExt, nil
+}
+
 var driveDownload = func(ctx context.Context, svc *drive.Service, fileID string) (*http.Response, error) {
 	return svc.Files.Get(fileID).SupportsAllDrives(true).Context(ctx).Download()
 }
@@ -0,0 +1,559 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+// Sharing audit finding kinds.
+const (
+	driveAuditAnyone    = "anyone"
+	driveAuditExternal  = "external"
+	driveAuditOwnerOnly = "owner-only"
+	driveAuditReshare   = "reshare"
+	driveAuditExpiring  = "expiring"
+)
+
+var driveAuditKinds = []string{driveAuditAnyone, driveAuditExternal, driveAuditOwnerOnly, driveAuditReshare, driveAuditExpiring}
+
+// DriveAuditCmd groups Drive audits.
+type DriveAuditCmd struct {
+	Sharing DriveAuditSharingCmd `cmd:"" name:"sharing" help:"Report link sharing, external grants, owner-only files, resharing writers and expiring access below a folder or shared drive"`
+}
+
+type DriveAuditSharingCmd struct {
+	Root        string   `name:"root" help:"Folder to audit: ID or path (default: My Drive)"`
+	DriveID     string   `name:"drive" help:"Audit a whole shared drive (members are reported once, on the drive)"`
+	Domain      []string `name:"domain" help:"Internal domains; grants outside them are external (default: the account's domain; required for consumer accounts)"`
+	Only        []string `name:"only" help:"Only report these findings: anyone,external,owner-only,reshare,expiring"`
+	CSV         bool     `name:"csv" help:"Write findings as CSV"`
+	Fix         []string `name:"fix" help:"Apply an unshare policy: anyone and external remove the flagged permissions; reshare stops writers from resharing"`
+	Concurrency int      `name:"concurrency" help:"Parallel permission lookups" default:"4"`
+}
+
+type driveAuditFinding struct {
+	Kind         string `json:"kind"`
+	PermissionID string `json:"permissionId,omitempty"`
+	Principal    string `json:"principal,omitempty"`
+	Role         string `json:"role,omitempty"`
+	Expires      string `json:"expires,omitempty"`
+	Inherited    bool   `json:"inherited,omitempty"`
+	Fixed        bool   `json:"fixed,omitempty"`
+	FixError     string `json:"fixError,omitempty"`
+
+	detailsKnown bool // the API reported permissionDetails for this grant
+	guessed      bool // Inherited comes from the parent heuristic
+}
+
+type driveAuditItem struct {
+	ID       string              `json:"id"`
+	Name     string              `json:"name"`
+	Path     string              `json:"path"`
+	MimeType string              `json:"mimeType"`
+	Owner    string              `json:"owner,omitempty"`
+	Findings []driveAuditFinding `json:"findings"`
+	Error    string              `json:"error,omitempty"`
+
+	parent          *driveAuditItem
+	grants          map[string]string // permission ID -> role
+	sharedDrive     bool
+	driveRoot       bool // the shared drive itself; its grants are memberships
+	writersCanShare bool
+}
+
+func (c *DriveAuditSharingCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	if strings.TrimSpace(c.Root) != "" && strings.TrimSpace(c.DriveID) != "" {
+		return usage("--root cannot be combined with --drive")
+	}
+	if c.Concurrency < 1 {
+		return usage("--concurrency must be at least 1")
+	}
+	only, err := parseDriveAuditKinds("--only", c.Only, driveAuditKinds)
+	if err != nil {
+		return err
+	}
+	fix, err := parseDriveAuditKinds("--fix", c.Fix, []string{driveAuditAnyone, driveAuditExternal, driveAuditReshare})
+	if err != nil {
+		return err
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	internal := map[string]bool{}
+	for _, d := range c.Domain {
+		if d = strings.ToLower(strings.TrimSpace(d)); d != "" {
+			internal[d] = true
+		}
+	}
+	if len(internal) == 0 {
+		if isConsumerAccount(account) {
+			return usage("--domain is required for consumer accounts (otherwise every user of your mail domain counts as internal)")
+		}
+		if _, d, ok := strings.Cut(account, "@"); ok {
+			internal[strings.ToLower(d)] = true
+		}
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	rootID := strings.TrimSpace(c.DriveID)
+	sharedDrive := rootID != ""
+	if !sharedDrive {
+		if rootID, err = resolveDriveFolderRef(ctx, svc, c.Root); err != nil {
+			return err
+		}
+	}
+
+	items, err := collectDriveAuditItems(ctx, svc, rootID, sharedDrive)
+	if err != nil {
+		return err
+	}
+	u.Err().Printf("audit: checking permissions on %d items", len(items))
+	auditDriveItems(ctx, svc, items, account, internal, c.Concurrency)
+
+	flagged := make([]*driveAuditItem, 0)
+	summary := map[string]int{}
+	failed := 0
+	for _, it := range items {
+		if it.Error != "" {
+			failed++
+			u.Err().Printf("audit: %s: %s", it.Path, it.Error)
+		}
+		if len(only) > 0 {
+			it.Findings = slices.DeleteFunc(it.Findings, func(f driveAuditFinding) bool { return !slices.Contains(only, f.Kind) })
+		}
+		for _, f := range it.Findings {
+			summary[f.Kind]++
+		}
+		if len(it.Findings) > 0 {
+			flagged = append(flagged, it)
+		}
+	}
+
+	if len(fix) > 0 {
+		if err := c.applyFix(ctx, flags, svc, flagged, fix); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case outfmt.IsJSON(ctx):
+		if err := outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"rootId":  rootID,
+			"scanned": len(items),
+			"items":   flagged,
+			"summary": summary,
+			"failed":  failed,
+		}); err != nil {
+			return err
+		}
+	case c.CSV:
+		if err := writeDriveAuditCSV(flagged); err != nil {
+			return err
+		}
+	default:
+		if len(flagged) == 0 {
+			u.Err().Printf("No findings in %d items", len(items))
+			break
+		}
+		w, flush := tableWriter(ctx)
+		fmt.Fprintln(w, "PATH\tFINDING\tPRINCIPAL\tROLE\tEXPIRES\tFIXED")
+		for _, it := range flagged {
+			for _, f := range it.Findings {
+				fixed := ""
+				switch {
+				case f.Fixed:
+					fixed = "yes"
+				case f.FixError != "":
+					fixed = f.FixError
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", sanitizeTab(it.Path), f.Kind, sanitizeTab(f.Principal), f.Role, formatDateTime(f.Expires), fixed)
+			}
+		}
+		flush()
+		parts := make([]string, 0, len(summary))
+		for _, k := range driveAuditKinds {
+			if summary[k] > 0 {
+				parts = append(parts, fmt.Sprintf("%s %d", k, summary[k]))
+			}
+		}
+		u.Err().Printf("audit: %d of %d items flagged (%s)", len(flagged), len(items), strings.Join(parts, ", "))
+	}
+	if failed > 0 {
+		return fmt.Errorf("could not read permissions of %d of %d items", failed, len(items))
+	}
+	return nil
+}
+
+func parseDriveAuditKinds(flag string, values, allowed []string) ([]string, error) {
+	var out []string
+	for _, v := range values {
+		v = strings.ToLower(strings.TrimSpace(v))
+		if v == "" {
+			continue
+		}
+		if !slices.Contains(allowed, v) {
+			return nil, usagef("invalid %s value %q (expected %s)", flag, v, strings.Join(allowed, ", "))
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+// collectDriveAuditItems lists every file and folder below rootID, the root
+// included. Shortcuts are not followed: their targets live elsewhere.
+func collectDriveAuditItems(ctx context.Context, svc *drive.Service, rootID string, sharedDrive bool) ([]*driveAuditItem, error) {
+	var root *driveAuditItem
+	if sharedDrive {
+		// A shared drive's root is not a file, but Permissions.List on the
+		// drive ID returns its members, which every item below inherits.
+		d, err := svc.Drives.Get(rootID).Fields("id, name").Context(ctx).Do()
+		if err != nil {
+			return nil, err
+		}
+		root = &driveAuditItem{ID: d.Id, Name: d.Name, Path: d.Name, MimeType: driveMimeFolder, sharedDrive: true, driveRoot: true}
+	} else {
+		f, err := svc.Files.Get(rootID).
+			SupportsAllDrives(true).
+			Fields("id, name, mimeType, driveId, writersCanShare, owners(emailAddress)").
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, err
+		}
+		root = newDriveAuditItem(f, f.Name)
+	}
+	items := []*driveAuditItem{root}
+	if root.MimeType != driveMimeFolder {
+		return items, nil
+	}
+
+	type pending struct {
+		id, dir string
+		item    *driveAuditItem
+	}
+	queue := []pending{{id: root.ID, dir: root.Path, item: root}}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		children, err := collectAllPages("", func(pageToken string) ([]*drive.File, string, error) {
+			call := svc.Files.List().
+				Q(buildDriveListQuery(cur.id, "")).
+				PageSize(1000).
+				SupportsAllDrives(true).
+				IncludeItemsFromAllDrives(true).
+				Fields("nextPageToken, files(id, name, mimeType, driveId, writersCanShare, owners(emailAddress))").
+				Context(ctx)
+			if pageToken != "" {
+				call = call.PageToken(pageToken)
+			}
+			resp, err := call.Do()
+			if err != nil {
+				return nil, "", err
+			}
+			return resp.Files, resp.NextPageToken, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		sort.SliceStable(children, func(i, j int) bool { return children[i].Name < children[j].Name })
+		for _, f := range children {
+			p := path.Join(cur.dir, f.Name)
+			it := newDriveAuditItem(f, p)
+			it.parent = cur.item
+			items = append(items, it)
+			if f.MimeType == driveMimeFolder {
+				queue = append(queue, pending{id: f.Id, dir: p, item: it})
+			}
+		}
+	}
+	return items, nil
+}
+
+func newDriveAuditItem(f *drive.File, p string) *driveAuditItem {
+	it := &driveAuditItem{
+		ID:              f.Id,
+		Name:            f.Name,
+		Path:            p,
+		MimeType:        f.MimeType,
+		sharedDrive:     f.DriveId != "",
+		writersCanShare: f.WritersCanShare,
+	}
+	if len(f.Owners) > 0 {
+		it.Owner = f.Owners[0].EmailAddress
+	}
+	return it
+}
+
+func auditDriveItems(ctx context.Context, svc *drive.Service, items []*driveAuditItem, account string, internal map[string]bool, concurrency int) {
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, it := range items {
+		wg.Add(1)
+		go func(it *driveAuditItem) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				it.Error = ctx.Err().Error()
+				return
+			}
+			perms, err := listDriveFilePermissions(ctx, svc, it.ID)
+			if err != nil {
+				it.Error = err.Error()
+				return
+			}
+			it.grants = map[string]string{}
+			for _, p := range perms {
+				if p != nil && !p.Deleted {
+					it.grants[p.Id] = p.Role
+				}
+			}
+			it.Findings = driveAuditFindings(it, perms, account, internal)
+		}(it)
+	}
+	wg.Wait()
+
+	// Shared drive members are reported once, on the drive; the inherited copy
+	// on every item below would only repeat them.
+	if root := items[0]; root.driveRoot && root.grants != nil {
+		for _, it := range items[1:] {
+			it.Findings = slices.DeleteFunc(it.Findings, func(f driveAuditFinding) bool {
+				_, member := root.grants[f.PermissionID]
+				return member && (f.Inherited || !f.detailsKnown)
+			})
+		}
+	}
+
+	// Without permissionDetails, a grant the parent also has is probably the
+	// copy a folder share leaves on everything below it. Permission IDs are
+	// per principal, so a direct grant looks the same; --fix re-checks these
+	// after the parent is fixed.
+	for _, it := range items {
+		if it.parent == nil {
+			continue
+		}
+		for i := range it.Findings {
+			f := &it.Findings[i]
+			if f.detailsKnown || f.PermissionID == "" {
+				continue
+			}
+			if role, ok := it.parent.grants[f.PermissionID]; ok && role == f.Role {
+				f.Inherited = true
+				f.guessed = true
+			}
+		}
+	}
+}
+
+func listDriveFilePermissions(ctx context.Context, svc *drive.Service, fileID string) ([]*drive.Permission, error) {
+	return collectAllPages("", func(pageToken string) ([]*drive.Permission, string, error) {
+		call := svc.Permissions.List(fileID).
+			SupportsAllDrives(true).
+			PageSize(100).
+			Fields("nextPageToken, permissions(id, type, role, emailAddress, domain, allowFileDiscovery, expirationTime, deleted, permissionDetails(inherited))").
+			Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Permissions, resp.NextPageToken, nil
+	})
+}
+
+// driveAuditFindings applies the audit rules to one item's permissions.
+func driveAuditFindings(it *driveAuditItem, perms []*drive.Permission, account string, internal map[string]bool) []driveAuditFinding {
+	var out []driveAuditFinding
+	var writers []string
+	onlyOwner := true
+	for _, p := range perms {
+		if p == nil || p.Deleted {
+			continue
+		}
+		base := driveAuditFinding{PermissionID: p.Id, Principal: drivePermissionPrincipal(p), Role: p.Role, detailsKnown: len(p.PermissionDetails) > 0}
+		for _, d := range p.PermissionDetails {
+			if d != nil && d.Inherited {
+				base.Inherited = true
+			}
+		}
+		if p.Role != "owner" {
+			onlyOwner = false
+		}
+		if p.Role == drivePermRoleWriter {
+			writers = append(writers, base.Principal)
+		}
+
+		switch p.Type {
+		case "anyone":
+			f := base
+			f.Kind = driveAuditAnyone
+			out = append(out, f)
+		case "user", "group":
+			if _, domain, ok := strings.Cut(strings.ToLower(p.EmailAddress), "@"); ok && !internal[domain] {
+				f := base
+				f.Kind = driveAuditExternal
+				out = append(out, f)
+			}
+		case "domain":
+			if !internal[strings.ToLower(p.Domain)] {
+				f := base
+				f.Kind = driveAuditExternal
+				out = append(out, f)
+			}
+		}
+		if p.ExpirationTime != "" {
+			f := base
+			f.Kind = driveAuditExpiring
+			f.Expires = p.ExpirationTime
+			out = append(out, f)
+		}
+	}
+
+	if onlyOwner && !it.sharedDrive && it.Owner != "" && !strings.EqualFold(it.Owner, account) {
+		out = append(out, driveAuditFinding{Kind: driveAuditOwnerOnly, Principal: it.Owner, Role: "owner"})
+	}
+	if it.writersCanShare && len(writers) > 0 {
+		out = append(out, driveAuditFinding{Kind: driveAuditReshare, Principal: strings.Join(writers, ", "), Role: drivePermRoleWriter})
+	}
+	return out
+}
+
+func drivePermissionPrincipal(p *drive.Permission) string {
+	switch p.Type {
+	case "anyone":
+		if p.AllowFileDiscovery {
+			return "anyone (discoverable)"
+		}
+		return "anyone with the link"
+	case "domain":
+		return p.Domain
+	default:
+		return p.EmailAddress
+	}
+}
+
+func (c *DriveAuditSharingCmd) applyFix(ctx context.Context, flags *RootFlags, svc *drive.Service, items []*driveAuditItem, kinds []string) error {
+	type fixAction struct {
+		FileID       string `json:"fileId"`
+		Path         string `json:"path"`
+		Kind         string `json:"kind"`
+		PermissionID string `json:"permissionId,omitempty"`
+		Principal    string `json:"principal,omitempty"`
+	}
+	var actions []fixAction
+	for _, it := range items {
+		for i := range it.Findings {
+			f := &it.Findings[i]
+			if !slices.Contains(kinds, f.Kind) {
+				continue
+			}
+			if f.Inherited && !f.guessed {
+				f.FixError = "inherited (fix the parent)"
+				continue
+			}
+			actions = append(actions, fixAction{FileID: it.ID, Path: it.Path, Kind: f.Kind, PermissionID: f.PermissionID, Principal: f.Principal})
+		}
+	}
+	if len(actions) == 0 {
+		return nil
+	}
+	if err := dryRunExit(ctx, flags, "drive.audit.sharing.fix", map[string]any{"actions": actions}); err != nil {
+		return err
+	}
+	if err := confirmDestructive(ctx, flags, fmt.Sprintf("change sharing on %d findings (%s)", len(actions), strings.Join(kinds, ", "))); err != nil {
+		return err
+	}
+
+	// Items are in walk order, so parents are fixed before their children.
+	for _, it := range items {
+		var remaining map[string]bool
+		for i := range it.Findings {
+			f := &it.Findings[i]
+			if !slices.Contains(kinds, f.Kind) || (f.Inherited && !f.guessed) {
+				continue
+			}
+			if f.guessed {
+				// Fixing the parent removes copied grants; only a direct grant
+				// with the same ID is left to delete here.
+				if remaining == nil {
+					perms, err := listDriveFilePermissions(ctx, svc, it.ID)
+					if err != nil {
+						f.FixError = err.Error()
+						continue
+					}
+					remaining = map[string]bool{}
+					for _, p := range perms {
+						if p != nil && !p.Deleted {
+							remaining[p.Id] = true
+						}
+					}
+				}
+				if !remaining[f.PermissionID] {
+					f.Fixed = true
+					continue
+				}
+			}
+			var err error
+			if f.Kind == driveAuditReshare {
+				_, err = svc.Files.Update(it.ID, &drive.File{WritersCanShare: false, ForceSendFields: []string{"WritersCanShare"}}).
+					SupportsAllDrives(true).
+					Fields("id").
+					Context(ctx).
+					Do()
+			} else {
+				err = svc.Permissions.Delete(it.ID, f.PermissionID).SupportsAllDrives(true).Context(ctx).Do()
+			}
+			if err != nil {
+				f.FixError = err.Error()
+				continue
+			}
+			f.Fixed = true
+		}
+	}
+	return nil
+}
+
+func writeDriveAuditCSV(items []*driveAuditItem) error {
+	w := csv.NewWriter(os.Stdout)
+	_ = w.Write([]string{"path", "id", "type", "finding", "permission_id", "principal", "role", "expires", "inherited", "fixed"})
+	for _, it := range items {
+		for _, f := range it.Findings {
+			_ = w.Write([]string{
+				it.Path,
+				it.ID,
+				driveType(it.MimeType),
+				f.Kind,
+				f.PermissionID,
+				f.Principal,
+				f.Role,
+				f.Expires,
+				strconv.FormatBool(f.Inherited),
+				strconv.FormatBool(f.Fixed),
+			})
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
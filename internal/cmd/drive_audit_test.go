@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+func TestDriveAuditSharing_FindingsAndFix(t *testing.T) {
+	owner := map[string]any{"id": "own", "type": "user", "role": "owner", "emailAddress": "a@b.com"}
+	link := map[string]any{"id": "anyoneWithLink", "type": "anyone", "role": "reader"}
+	perms := map[string][]map[string]any{
+		"f0":    {owner, link},
+		"doc1":  {owner, link, {"id": "ext1", "type": "user", "role": "writer", "emailAddress": "x@evil.com", "expirationTime": "2026-12-01T00:00:00Z"}},
+		"sub":   {owner, {"id": "anyoneWithLink", "type": "anyone", "role": "reader", "permissionDetails": []map[string]any{{"inherited": false}}}},
+		"memo1": {owner, link},
+		"pdf1":  {owner, {"id": "dom1", "type": "domain", "role": "reader", "domain": "partner.com"}},
+		"orp1":  {{"id": "bob", "type": "user", "role": "owner", "emailAddress": "bob@b.com"}},
+	}
+	children := map[string][]map[string]any{
+		"f0": {
+			{"id": "doc1", "name": "plan.docx", "mimeType": mimeDocx, "writersCanShare": true, "owners": []map[string]any{{"emailAddress": "a@b.com"}}},
+			{"id": "memo1", "name": "memo.txt", "mimeType": "text/plain", "owners": []map[string]any{{"emailAddress": "a@b.com"}}},
+			{"id": "orp1", "name": "orphan.txt", "mimeType": "text/plain", "owners": []map[string]any{{"emailAddress": "bob@b.com"}}},
+			{"id": "sub", "name": "Sub", "mimeType": driveMimeFolder, "owners": []map[string]any{{"emailAddress": "a@b.com"}}},
+		},
+		"sub": {{"id": "pdf1", "name": "secret.pdf", "mimeType": mimePDF, "owners": []map[string]any{{"emailAddress": "a@b.com"}}}},
+	}
+
+	var mu sync.Mutex
+	var deleted []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		switch {
+		case r.Method == http.MethodDelete && len(parts) == 4 && parts[2] == "permissions":
+			mu.Lock()
+			deleted = append(deleted, parts[1]+"/"+parts[3])
+			drop := func(fileID string) {
+				perms[fileID] = slices.DeleteFunc(slices.Clone(perms[fileID]), func(p map[string]any) bool { return p["id"] == parts[3] })
+			}
+			drop(parts[1])
+			if parts[1] == "f0" {
+				// The link on plan.docx is a copy of the folder's; memo.txt has
+				// its own direct link grant.
+				drop("doc1")
+			}
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		case len(parts) == 3 && parts[2] == "permissions":
+			mu.Lock()
+			_ = json.NewEncoder(w).Encode(map[string]any{"permissions": perms[parts[1]]})
+			mu.Unlock()
+		case r.URL.Path == "/files":
+			parent := strings.TrimSuffix(strings.TrimPrefix(r.URL.Query().Get("q"), "'"), "' in parents and trashed = false")
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": children[parent]})
+		case r.URL.Path == "/files/f0":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "f0", "name": "Team", "mimeType": driveMimeFolder, "owners": []map[string]any{{"emailAddress": "a@b.com"}}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+
+	run := func(args ...string) string {
+		t.Helper()
+		var out string
+		_ = captureStderr(t, func() {
+			out = captureStdout(t, func() {
+				if err := Execute(append([]string{"--json", "--force", "--account", "a@b.com", "drive", "audit", "sharing", "--root", "f0"}, args...)); err != nil {
+					t.Fatalf("audit: %v", err)
+				}
+			})
+		})
+		return out
+	}
+
+	var parsed struct {
+		Scanned int              `json:"scanned"`
+		Items   []driveAuditItem `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(run()), &parsed); err != nil {
+		t.Fatalf("json: %v", err)
+	}
+	var got []string
+	for _, it := range parsed.Items {
+		for _, f := range it.Findings {
+			s := it.Path + " " + f.Kind + " " + f.Principal
+			if f.Inherited {
+				s += " (inherited)"
+			}
+			got = append(got, s)
+		}
+	}
+	sort.Strings(got)
+	want := []string{
+		"Team anyone anyone with the link",
+		"Team/Sub anyone anyone with the link",
+		"Team/Sub/secret.pdf external partner.com",
+		"Team/memo.txt anyone anyone with the link (inherited)",
+		"Team/orphan.txt owner-only bob@b.com",
+		"Team/plan.docx anyone anyone with the link (inherited)",
+		"Team/plan.docx expiring x@evil.com",
+		"Team/plan.docx external x@evil.com",
+		"Team/plan.docx reshare x@evil.com",
+	}
+	if parsed.Scanned != 6 || strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("scanned %d, findings:\n%s", parsed.Scanned, strings.Join(got, "\n"))
+	}
+	if len(deleted) != 0 {
+		t.Fatalf("audit without --fix must not change sharing: %v", deleted)
+	}
+
+	run("--fix", "anyone,external")
+	sort.Strings(deleted)
+	if strings.Join(deleted, " ") != "doc1/ext1 f0/anyoneWithLink memo1/anyoneWithLink pdf1/dom1 sub/anyoneWithLink" {
+		t.Fatalf("unexpected permission deletes: %v", deleted)
+	}
+
+	err = Execute([]string{"--account", "me@gmail.com", "drive", "audit", "sharing", "--root", "f0"})
+	if err == nil || !strings.Contains(err.Error(), "--domain is required") {
+		t.Fatalf("expected --domain to be required for consumer accounts, got %v", err)
+	}
+}
+
+func TestDriveAuditSharing_SharedDriveMembers(t *testing.T) {
+	extMember := map[string]any{"id": "extm", "type": "user", "role": "writer", "emailAddress": "x@evil.com"}
+	perms := map[string][]map[string]any{
+		"sd1": {{"id": "org", "type": "user", "role": "organizer", "emailAddress": "a@b.com"}, extMember},
+		"doc1": {
+			{"id": "org", "type": "user", "role": "organizer", "emailAddress": "a@b.com", "permissionDetails": []map[string]any{{"inherited": true}}},
+			{"id": "extm", "type": "user", "role": "writer", "emailAddress": "x@evil.com", "permissionDetails": []map[string]any{{"inherited": true}}},
+			{"id": "anyoneWithLink", "type": "anyone", "role": "reader", "permissionDetails": []map[string]any{{"inherited": false}}},
+		},
+		"doc2": {
+			{"id": "extm", "type": "user", "role": "writer", "emailAddress": "x@evil.com", "permissionDetails": []map[string]any{{"inherited": true}}},
+		},
+	}
+	children := []map[string]any{
+		{"id": "doc1", "name": "plan.docx", "mimeType": mimeDocx, "driveId": "sd1"},
+		{"id": "doc2", "name": "notes.txt", "mimeType": "text/plain", "driveId": "sd1"},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		switch {
+		case r.URL.Path == "/drives/sd1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "sd1", "name": "Eng"})
+		case len(parts) == 3 && parts[2] == "permissions":
+			_ = json.NewEncoder(w).Encode(map[string]any{"permissions": perms[parts[1]]})
+		case r.URL.Path == "/files" && strings.HasPrefix(r.URL.Query().Get("q"), "'sd1' in parents"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": children})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+
+	var out string
+	_ = captureStderr(t, func() {
+		out = captureStdout(t, func() {
+			if err := Execute([]string{"--json", "--account", "a@b.com", "drive", "audit", "sharing", "--drive", "sd1"}); err != nil {
+				t.Fatalf("audit: %v", err)
+			}
+		})
+	})
+	var parsed struct {
+		Scanned int              `json:"scanned"`
+		Items   []driveAuditItem `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v", err)
+	}
+	var got []string
+	for _, it := range parsed.Items {
+		for _, f := range it.Findings {
+			got = append(got, it.Path+" "+f.Kind+" "+f.Principal)
+		}
+	}
+	sort.Strings(got)
+	want := []string{
+		"Eng external x@evil.com",
+		"Eng/plan.docx anyone anyone with the link",
+	}
+	if parsed.Scanned != 3 || strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("scanned %d, findings:\n%s", parsed.Scanned, strings.Join(got, "\n"))
+	}
+}
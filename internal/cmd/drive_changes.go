@@ -0,0 +1,537 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const (
+	driveChangeCreated  = "created"
+	driveChangeModified = "modified"
+	driveChangeTrashed  = "trashed"
+	driveChangeRemoved  = "removed"
+
+	driveChangesFields = "nextPageToken, newStartPageToken, changes(changeType, removed, fileId, time, driveId, " +
+		"file(id, name, mimeType, size, parents, createdTime, modifiedTime, trashed, webViewLink, lastModifyingUser(displayName, emailAddress)))"
+
+	// driveChangesMaxDepth bounds the parent walk used to build paths.
+	driveChangesMaxDepth = 32
+)
+
+// DriveChangesCmd lists the Drive change feed and polls it for hooks.
+type DriveChangesCmd struct {
+	List  DriveChangesListCmd  `cmd:"" name:"list" aliases:"ls" default:"withargs" help:"List changes since a page token (default: the stored token)"`
+	Watch DriveChangesWatchCmd `cmd:"" name:"watch" aliases:"poll" help:"Poll for changes and deliver them to a webhook or command"`
+}
+
+type DriveChangesListCmd struct {
+	SinceToken string `name:"since-token" help:"List changes since this page token (the stored token is left unchanged)"`
+	Init       bool   `name:"init" help:"Record the current position as the stored token and exit"`
+	DriveID    string `name:"drive" help:"Follow a shared drive instead of My Drive and everything shared with you"`
+	Folder     string `name:"folder" help:"Only report files below this folder (ID or path)"`
+}
+
+func (c *DriveChangesListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	if c.Init && strings.TrimSpace(c.SinceToken) != "" {
+		return usage("--init cannot be combined with --since-token")
+	}
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	driveID := strings.TrimSpace(c.DriveID)
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	folder, err := resolveDriveRef(ctx, svc, c.Folder)
+	if err != nil {
+		return err
+	}
+	store, err := openDriveChangesStore(account, driveID)
+	if err != nil {
+		return err
+	}
+
+	if c.Init {
+		token, initErr := initDriveChangesToken(ctx, svc, store, account, driveID)
+		if initErr != nil {
+			return initErr
+		}
+		if outfmt.IsJSON(ctx) {
+			return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"pageToken": token, "driveId": driveID})
+		}
+		u.Out().Printf("pageToken\t%s", token)
+		return nil
+	}
+
+	token := strings.TrimSpace(c.SinceToken)
+	stored := token == ""
+	var since time.Time
+	if stored {
+		state := store.Get()
+		if state.PageToken == "" {
+			return errors.New("no stored page token; run drive changes --init first (or pass --since-token)")
+		}
+		token = state.PageToken
+		since = time.UnixMilli(state.UpdatedAtMs)
+	}
+
+	w := &driveChangesWatcher{svc: svc, folder: folder, driveID: driveID}
+	events, next, err := w.collect(ctx, token, since)
+	if err != nil {
+		return err
+	}
+	if stored {
+		if err := store.advance(next); err != nil {
+			return err
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"changes":   events,
+			"nextToken": next,
+		})
+	}
+	if len(events) == 0 {
+		u.Err().Println("No changes")
+	} else {
+		printDriveChangeEvents(ctx, events)
+	}
+	if !stored {
+		u.Err().Printf("# Next token: --since-token %s", next)
+	}
+	return nil
+}
+
+type DriveChangesWatchCmd struct {
+	Interval  string `name:"interval" help:"Poll interval (seconds or Go duration)" default:"60s"`
+	Once      bool   `name:"once" help:"Run a single poll and exit"`
+	DriveID   string `name:"drive" help:"Follow a shared drive instead of My Drive and everything shared with you"`
+	Folder    string `name:"folder" help:"Only report files below this folder (ID or path)"`
+	HookURL   string `name:"hook-url" help:"Webhook URL to forward changes"`
+	HookToken string `name:"hook-token" help:"Webhook bearer token"`
+	HookExec  string `name:"hook-exec" aliases:"exec" help:"Shell command to run with the JSON payload on stdin"`
+	SaveHook  bool   `name:"save-hook" help:"Persist hook settings to watch state"`
+}
+
+func (c *DriveChangesWatchCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	interval, err := parseDurationSeconds(c.Interval)
+	if err != nil {
+		return err
+	}
+	if interval <= 0 {
+		interval = defaultWatchPollInterval
+	}
+	if interval < 5*time.Second {
+		return usage("--interval must be at least 5s")
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	driveID := strings.TrimSpace(c.DriveID)
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	folder, err := resolveDriveRef(ctx, svc, c.Folder)
+	if err != nil {
+		return err
+	}
+	store, err := openDriveChangesStore(account, driveID)
+	if err != nil {
+		return err
+	}
+	hook, err := watchHookFromFlags(c.HookURL, c.HookToken, c.HookExec)
+	if err != nil {
+		return err
+	}
+	if hook == nil {
+		hook = store.Get().Hook
+	} else if c.SaveHook {
+		if err := store.Update(func(s *driveChangesState) error {
+			s.Hook = hook
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	if store.Get().PageToken == "" {
+		if _, err := initDriveChangesToken(ctx, svc, store, account, driveID); err != nil {
+			return err
+		}
+		u.Err().Println("watch: recorded starting page token; reporting changes from now on")
+	}
+
+	w := &driveChangesWatcher{
+		account:     account,
+		driveID:     driveID,
+		folder:      folder,
+		svc:         svc,
+		store:       store,
+		hook:        hook,
+		hookClient:  &http.Client{Timeout: defaultHookRequestTimeoutSec * time.Second},
+		hookTimeout: defaultHookRequestTimeoutSec * time.Second,
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := w.poll(ctx); err != nil {
+			if c.Once {
+				return err
+			}
+			u.Err().Printf("watch: %v", err)
+		}
+		if c.Once {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// driveChangeEvent is one entry of the change feed, classified and with the
+// file's path resolved through its parents.
+type driveChangeEvent struct {
+	Action       string `json:"action"`
+	FileID       string `json:"fileId"`
+	Name         string `json:"name,omitempty"`
+	Path         string `json:"path,omitempty"`
+	MimeType     string `json:"mimeType,omitempty"`
+	Size         int64  `json:"size,omitempty"`
+	ModifiedTime string `json:"modifiedTime,omitempty"`
+	ModifiedBy   string `json:"modifiedBy,omitempty"`
+	WebViewLink  string `json:"webViewLink,omitempty"`
+	DriveID      string `json:"driveId,omitempty"`
+	Time         string `json:"time"`
+}
+
+type driveChangesPayload struct {
+	Source  string             `json:"source"`
+	Account string             `json:"account"`
+	DriveID string             `json:"driveId,omitempty"`
+	Events  []driveChangeEvent `json:"events"`
+}
+
+type driveChangesWatcher struct {
+	account     string
+	driveID     string
+	folder      string
+	svc         *drive.Service
+	store       *driveChangesStore
+	hook        *watchHook
+	hookClient  *http.Client
+	hookTimeout time.Duration
+}
+
+type driveFolderInfo struct {
+	name    string
+	parents []string
+}
+
+// collect pages through changes.list from token and returns the events and
+// the token to continue from. since (when known) marks files created after
+// the previous poll as created rather than modified.
+func (w *driveChangesWatcher) collect(ctx context.Context, token string, since time.Time) ([]driveChangeEvent, string, error) {
+	folders := map[string]driveFolderInfo{}
+	events := []driveChangeEvent{}
+	for {
+		call := w.svc.Changes.List(token).
+			PageSize(1000).
+			IncludeRemoved(true).
+			IncludeItemsFromAllDrives(true).
+			SupportsAllDrives(true).
+			Fields(driveChangesFields).
+			Context(ctx)
+		if w.driveID != "" {
+			call = call.DriveId(w.driveID)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		for _, ch := range resp.Changes {
+			if ch == nil || ch.ChangeType == "drive" {
+				continue
+			}
+			ev := driveChangeEvent{FileID: ch.FileId, DriveID: ch.DriveId, Time: ch.Time}
+			if ch.Removed || ch.File == nil {
+				if w.folder != "" {
+					continue // a removed file's location is unknown
+				}
+				ev.Action = driveChangeRemoved
+				events = append(events, ev)
+				continue
+			}
+			f := ch.File
+			path, ancestors, err := w.resolvePath(ctx, folders, f)
+			if err != nil {
+				return nil, "", err
+			}
+			if w.folder != "" && !slices.Contains(ancestors, w.folder) {
+				continue
+			}
+			ev.Action = driveChangeAction(f, since)
+			ev.Name = f.Name
+			ev.Path = path
+			ev.MimeType = f.MimeType
+			ev.Size = f.Size
+			ev.ModifiedTime = f.ModifiedTime
+			ev.WebViewLink = f.WebViewLink
+			if f.LastModifyingUser != nil {
+				ev.ModifiedBy = f.LastModifyingUser.EmailAddress
+			}
+			events = append(events, ev)
+		}
+		if resp.NewStartPageToken != "" {
+			return events, resp.NewStartPageToken, nil
+		}
+		if resp.NextPageToken == "" {
+			return events, token, nil
+		}
+		token = resp.NextPageToken
+	}
+}
+
+// resolvePath walks parents (cached per poll) and returns the slash path
+// from the top-level folder plus every ancestor folder ID.
+func (w *driveChangesWatcher) resolvePath(ctx context.Context, cache map[string]driveFolderInfo, f *drive.File) (string, []string, error) {
+	names := []string{f.Name}
+	var ancestors []string
+	parents := f.Parents
+	for depth := 0; len(parents) > 0 && depth < driveChangesMaxDepth; depth++ {
+		id := parents[0]
+		ancestors = append(ancestors, id)
+		info, ok := cache[id]
+		if !ok {
+			folder, err := w.svc.Files.Get(id).SupportsAllDrives(true).Fields("id, name, parents").Context(ctx).Do()
+			switch {
+			case isNotFoundAPIError(err):
+				// Not visible to us (e.g. another user's folder); stop here.
+				cache[id] = driveFolderInfo{}
+				return strings.Join(reverseStrings(names), "/"), ancestors, nil
+			case err != nil:
+				return "", nil, err
+			}
+			info = driveFolderInfo{name: folder.Name, parents: folder.Parents}
+			cache[id] = info
+		}
+		if info.name == "" {
+			break
+		}
+		names = append(names, info.name)
+		parents = info.parents
+	}
+	return strings.Join(reverseStrings(names), "/"), ancestors, nil
+}
+
+// driveChangeAction classifies a change. The feed does not say whether a
+// file is new, so files created after the previous poll, or modified within
+// a moment of their creation, count as created.
+func driveChangeAction(f *drive.File, since time.Time) string {
+	if f.Trashed {
+		return driveChangeTrashed
+	}
+	created, createdErr := time.Parse(time.RFC3339, f.CreatedTime)
+	modified, modifiedErr := time.Parse(time.RFC3339, f.ModifiedTime)
+	if createdErr == nil {
+		if !since.IsZero() && created.After(since) {
+			return driveChangeCreated
+		}
+		if modifiedErr == nil && modified.Sub(created) < 2*time.Second {
+			return driveChangeCreated
+		}
+	}
+	return driveChangeModified
+}
+
+// poll delivers new changes and only then advances the stored token, so a
+// failed delivery is retried on the next poll.
+func (w *driveChangesWatcher) poll(ctx context.Context) error {
+	state := w.store.Get()
+	events, next, err := w.collect(ctx, state.PageToken, time.UnixMilli(state.UpdatedAtMs))
+	if err != nil {
+		return err
+	}
+	if len(events) > 0 {
+		payload := &driveChangesPayload{Source: "drive", Account: w.account, DriveID: w.driveID, Events: events}
+		if w.hook != nil {
+			if err := w.deliver(ctx, payload); err != nil {
+				return err
+			}
+		} else if outfmt.IsJSON(ctx) {
+			if err := outfmt.WriteJSON(ctx, os.Stdout, payload); err != nil {
+				return err
+			}
+		} else {
+			printDriveChangeEvents(ctx, events)
+		}
+	}
+	return w.store.advance(next)
+}
+
+func (w *driveChangesWatcher) deliver(ctx context.Context, payload *driveChangesPayload) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	status, note := "ok", ""
+	if w.hook.URL != "" {
+		if err := postWatchHook(ctx, w.hookClient, w.hook, data); err != nil {
+			status, note = watchStatusHTTPError, err.Error()
+		}
+	}
+	if w.hook.Exec != "" && status == "ok" {
+		execCtx, cancel := context.WithTimeout(ctx, w.hookTimeout)
+		env := []string{
+			"GOG_HOOK_SOURCE=drive",
+			"GOG_ACCOUNT=" + w.account,
+			"GOG_DRIVE_ID=" + w.driveID,
+		}
+		err := runWatchHookExec(execCtx, w.hook.Exec, env, data)
+		cancel()
+		if err != nil {
+			status, note = watchStatusExecError, err.Error()
+		}
+	}
+	_ = w.store.Update(func(s *driveChangesState) error {
+		s.LastDeliveryStatus = status
+		s.LastDeliveryAtMs = time.Now().UnixMilli()
+		s.LastDeliveryStatusNote = note
+		return nil
+	})
+	if status != "ok" {
+		return fmt.Errorf("hook %s: %s", status, note)
+	}
+	return nil
+}
+
+func printDriveChangeEvents(ctx context.Context, events []driveChangeEvent) {
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "ACTION\tID\tPATH\tMODIFIED\tBY")
+	for _, ev := range events {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", ev.Action, ev.FileID, sanitizeTab(ev.Path), formatDateTime(ev.ModifiedTime), ev.ModifiedBy)
+	}
+}
+
+func initDriveChangesToken(ctx context.Context, svc *drive.Service, store *driveChangesStore, account, driveID string) (string, error) {
+	call := svc.Changes.GetStartPageToken().SupportsAllDrives(true).Context(ctx)
+	if driveID != "" {
+		call = call.DriveId(driveID)
+	}
+	resp, err := call.Do()
+	if err != nil {
+		return "", err
+	}
+	if err := store.Update(func(s *driveChangesState) error {
+		s.Account = account
+		s.DriveID = driveID
+		s.PageToken = resp.StartPageToken
+		s.UpdatedAtMs = time.Now().UnixMilli()
+		return nil
+	}); err != nil {
+		return "", err
+	}
+	return resp.StartPageToken, nil
+}
+
+func reverseStrings(s []string) []string {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+	return s
+}
+
+type driveChangesState struct {
+	Account                string     `json:"account"`
+	DriveID                string     `json:"driveId,omitempty"`
+	PageToken              string     `json:"pageToken"`
+	UpdatedAtMs            int64      `json:"updatedAtMs,omitempty"`
+	Hook                   *watchHook `json:"hook,omitempty"`
+	LastDeliveryStatus     string     `json:"lastDeliveryStatus,omitempty"`
+	LastDeliveryAtMs       int64      `json:"lastDeliveryAtMs,omitempty"`
+	LastDeliveryStatusNote string     `json:"lastDeliveryStatusNote,omitempty"`
+}
+
+type driveChangesStore struct {
+	path  string
+	mu    sync.Mutex
+	state driveChangesState
+}
+
+// openDriveChangesStore keys state by account and shared drive ("" is My
+// Drive), returning an empty store when nothing was stored yet.
+func openDriveChangesStore(account, driveID string) (*driveChangesStore, error) {
+	dir, err := config.EnsureDriveChangesDir()
+	if err != nil {
+		return nil, err
+	}
+	name := sanitizeAccountForPath(account)
+	if driveID != "" {
+		name += "__" + sanitizeAccountForPath(driveID)
+	}
+	store := &driveChangesStore{path: filepath.Join(dir, name+".json")}
+	data, err := os.ReadFile(store.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return store, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &store.state); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+func (s *driveChangesStore) Get() driveChangesState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state
+}
+
+func (s *driveChangesStore) Update(fn func(*driveChangesState) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := fn(&s.state); err != nil {
+		return err
+	}
+	payload, err := json.MarshalIndent(s.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, append(payload, '\n'), 0o600)
+}
+
+func (s *driveChangesStore) advance(token string) error {
+	return s.Update(func(st *driveChangesState) error {
+		st.PageToken = token
+		st.UpdatedAtMs = time.Now().UnixMilli()
+		return nil
+	})
+}
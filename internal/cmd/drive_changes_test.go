@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+func driveChangesTestServer(t *testing.T) {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/changes/startPageToken":
+			_ = json.NewEncoder(w).Encode(map[string]any{"startPageToken": "10"})
+		case r.URL.Path == "/changes" && r.URL.Query().Get("pageToken") == "10":
+			_ = json.NewEncoder(w).Encode(map[string]any{"nextPageToken": "11", "changes": []map[string]any{
+				{"changeType": "file", "fileId": "new1", "time": "2026-10-18T10:00:00Z", "file": map[string]any{
+					"id": "new1", "name": "drop.csv", "parents": []string{"inbox"},
+					"createdTime": "2026-10-18T09:59:59Z", "modifiedTime": "2026-10-18T09:59:59Z",
+					"lastModifyingUser": map[string]any{"emailAddress": "mate@b.com"},
+				}},
+				{"changeType": "drive", "driveId": "sd1"},
+			}})
+		case r.URL.Path == "/changes" && r.URL.Query().Get("pageToken") == "11":
+			_ = json.NewEncoder(w).Encode(map[string]any{"newStartPageToken": "20", "changes": []map[string]any{
+				{"changeType": "file", "fileId": "old1", "time": "2026-10-18T10:01:00Z", "file": map[string]any{
+					"id": "old1", "name": "plan.txt", "parents": []string{"hidden"}, "trashed": true,
+				}},
+				{"changeType": "file", "fileId": "gone1", "removed": true, "time": "2026-10-18T10:02:00Z"},
+			}})
+		case r.URL.Path == "/changes" && r.URL.Query().Get("pageToken") == "20":
+			_ = json.NewEncoder(w).Encode(map[string]any{"newStartPageToken": "20", "changes": []any{}})
+		case r.URL.Path == "/files/inbox":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "inbox", "name": "Inbox", "parents": []string{"root"}})
+		case r.URL.Path == "/files/root":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "root", "name": "My Drive"})
+		default:
+			http.Error(w, `{"error":{"code":404,"message":"not found"}}`, http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+}
+
+func storedDriveChangesToken(t *testing.T) string {
+	t.Helper()
+	store, err := openDriveChangesStore("a@b.com", "")
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	return store.Get().PageToken
+}
+
+func TestDriveChanges_InitListAndFolderFilter(t *testing.T) {
+	driveChangesTestServer(t)
+
+	_ = captureStdout(t, func() {
+		if err := Execute([]string{"--account", "a@b.com", "drive", "changes", "--init"}); err != nil {
+			t.Fatalf("init: %v", err)
+		}
+	})
+	if got := storedDriveChangesToken(t); got != "10" {
+		t.Fatalf("stored token after --init = %q", got)
+	}
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "drive", "changes"}); err != nil {
+			t.Fatalf("list: %v", err)
+		}
+	})
+	var parsed struct {
+		Changes   []driveChangeEvent `json:"changes"`
+		NextToken string             `json:"nextToken"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	var summary []string
+	for _, ev := range parsed.Changes {
+		summary = append(summary, ev.Action+":"+ev.FileID+":"+ev.Path)
+	}
+	if got := strings.Join(summary, " "); got != "created:new1:My Drive/Inbox/drop.csv trashed:old1:plan.txt removed:gone1:" {
+		t.Fatalf("unexpected events: %s", got)
+	}
+	if parsed.NextToken != "20" || storedDriveChangesToken(t) != "20" {
+		t.Fatalf("expected token advanced to 20, got %q / %q", parsed.NextToken, storedDriveChangesToken(t))
+	}
+
+	out = captureStdout(t, func() {
+		_ = captureStderr(t, func() {
+			if err := Execute([]string{"--account", "a@b.com", "drive", "changes", "--since-token", "10", "--folder", "inbox"}); err != nil {
+				t.Fatalf("list --since-token: %v", err)
+			}
+		})
+	})
+	if !strings.Contains(out, "drop.csv") || strings.Contains(out, "old1") || strings.Contains(out, "gone1") {
+		t.Fatalf("--folder should keep only files below the folder: %q", out)
+	}
+	if got := storedDriveChangesToken(t); got != "20" {
+		t.Fatalf("--since-token must not touch the stored token, got %q", got)
+	}
+}
+
+func TestDriveChangesWatch_DeliversAndAdvancesOnSuccess(t *testing.T) {
+	driveChangesTestServer(t)
+	var fail atomic.Bool
+	var received []driveChangesPayload
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		var p driveChangesPayload
+		_ = json.Unmarshal(body, &p)
+		received = append(received, p)
+	}))
+	t.Cleanup(hook.Close)
+
+	store, err := openDriveChangesStore("a@b.com", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.advance("10"); err != nil {
+		t.Fatal(err)
+	}
+
+	args := []string{"--account", "a@b.com", "drive", "changes", "watch", "--once", "--hook-url", hook.URL}
+	fail.Store(true)
+	_ = captureStderr(t, func() {
+		if err := Execute(args); err == nil {
+			t.Fatalf("expected hook failure")
+		}
+	})
+	if got := storedDriveChangesToken(t); got != "10" {
+		t.Fatalf("failed delivery must not advance the token, got %q", got)
+	}
+
+	fail.Store(false)
+	if err := Execute(args); err != nil {
+		t.Fatalf("watch: %v", err)
+	}
+	if len(received) != 1 || received[0].Source != "drive" || len(received[0].Events) != 3 {
+		t.Fatalf("unexpected deliveries: %#v", received)
+	}
+	if got := storedDriveChangesToken(t); got != "20" {
+		t.Fatalf("expected token advanced after delivery, got %q", got)
+	}
+}
@@ -23,7 +23,7 @@ type DriveCommentsCmd struct {
 }
 
 type DriveCommentsListCmd struct {
-	FileID        string `arg:"" name:"fileId" help:"File ID"`
+	FileID        string `arg:"" name:"fileId" help:"File ID or path (drive:/Folder/file)"`
 	Max           int64  `name:"max" aliases:"limit" help:"Max results" default:"100"`
 	Page          string `name:"page" aliases:"cursor" help:"Page token"`
 	All           bool   `name:"all" aliases:"all-pages,allpages" help:"Fetch all pages"`
@@ -46,6 +46,9 @@ func (c *DriveCommentsListCmd) Run(ctx context.Context, flags *RootFlags) error
 	if err != nil {
 		return err
 	}
+	if fileID, err = resolveDriveRef(ctx, svc, fileID); err != nil {
+		return err
+	}
 
 	fetch := func(pageToken string) ([]*drive.Comment, string, error) {
 		var call *drive.CommentsListCall
@@ -152,7 +155,7 @@ func (c *DriveCommentsListCmd) Run(ctx context.Context, flags *RootFlags) error
 }
 
 type DriveCommentsGetCmd struct {
-	FileID    string `arg:"" name:"fileId" help:"File ID"`
+	FileID    string `arg:"" name:"fileId" help:"File ID or path (drive:/Folder/file)"`
 	CommentID string `arg:"" name:"commentId" help:"Comment ID"`
 }
 
@@ -175,6 +178,9 @@ func (c *DriveCommentsGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	if fileID, err = resolveDriveRef(ctx, svc, fileID); err != nil {
+		return err
+	}
 
 	comment, err := svc.Comments.Get(fileID, commentID).
 		Fields("id, author, content, createdTime, modifiedTime, resolved, quotedFileContent, anchor, replies").
@@ -206,7 +212,7 @@ func (c *DriveCommentsGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 }
 
 type DriveCommentsCreateCmd struct {
-	FileID  string `arg:"" name:"fileId" help:"File ID"`
+	FileID  string `arg:"" name:"fileId" help:"File ID or path (drive:/Folder/file)"`
 	Content string `arg:"" name:"content" help:"Comment text"`
 	Quoted  string `name:"quoted" help:"Text to anchor the comment to (for Google Docs)"`
 }
@@ -240,6 +246,9 @@ func (c *DriveCommentsCreateCmd) Run(ctx context.Context, flags *RootFlags) erro
 	if err != nil {
 		return err
 	}
+	if fileID, err = resolveDriveRef(ctx, svc, fileID); err != nil {
+		return err
+	}
 
 	comment := &drive.Comment{
 		Content: content,
@@ -271,7 +280,7 @@ func (c *DriveCommentsCreateCmd) Run(ctx context.Context, flags *RootFlags) erro
 }
 
 type DriveCommentsUpdateCmd struct {
-	FileID    string `arg:"" name:"fileId" help:"File ID"`
+	FileID    string `arg:"" name:"fileId" help:"File ID or path (drive:/Folder/file)"`
 	CommentID string `arg:"" name:"commentId" help:"Comment ID"`
 	Content   string `arg:"" name:"content" help:"New comment text"`
 }
@@ -308,6 +317,9 @@ func (c *DriveCommentsUpdateCmd) Run(ctx context.Context, flags *RootFlags) erro
 	if err != nil {
 		return err
 	}
+	if fileID, err = resolveDriveRef(ctx, svc, fileID); err != nil {
+		return err
+	}
 
 	comment := &drive.Comment{
 		Content: content,
@@ -332,7 +344,7 @@ func (c *DriveCommentsUpdateCmd) Run(ctx context.Context, flags *RootFlags) erro
 }
 
 type DriveCommentsDeleteCmd struct {
-	FileID    string `arg:"" name:"fileId" help:"File ID"`
+	FileID    string `arg:"" name:"fileId" help:"File ID or path (drive:/Folder/file)"`
 	CommentID string `arg:"" name:"commentId" help:"Comment ID"`
 }
 
@@ -360,6 +372,9 @@ func (c *DriveCommentsDeleteCmd) Run(ctx context.Context, flags *RootFlags) erro
 	if err != nil {
 		return err
 	}
+	if fileID, err = resolveDriveRef(ctx, svc, fileID); err != nil {
+		return err
+	}
 
 	if err := svc.Comments.Delete(fileID, commentID).Context(ctx).Do(); err != nil {
 		return err
@@ -380,7 +395,7 @@ func (c *DriveCommentsDeleteCmd) Run(ctx context.Context, flags *RootFlags) erro
 }
 
 type DriveCommentReplyCmd struct {
-	FileID    string `arg:"" name:"fileId" help:"File ID"`
+	FileID    string `arg:"" name:"fileId" help:"File ID or path (drive:/Folder/file)"`
 	CommentID string `arg:"" name:"commentId" help:"Comment ID"`
 	Content   string `arg:"" name:"content" help:"Reply text"`
 }
@@ -417,6 +432,9 @@ func (c *DriveCommentReplyCmd) Run(ctx context.Context, flags *RootFlags) error
 	if err != nil {
 		return err
 	}
+	if fileID, err = resolveDriveRef(ctx, svc, fileID); err != nil {
+		return err
+	}
 
 	reply := &drive.Reply{
 		Content: content,
@@ -52,6 +52,12 @@ func copyViaDrive(ctx context.Context, flags *RootFlags, opts copyViaDriveOption
 	if err != nil {
 		return err
 	}
+	if id, err = resolveDriveRef(ctx, svc, id); err != nil {
+		return err
+	}
+	if parent, err = resolveDriveRef(ctx, svc, parent); err != nil {
+		return err
+	}
 
 	meta, err := svc.Files.Get(id).
 		SupportsAllDrives(true).
@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+)
+
+const (
+	driveRefPrefix  = "drive:"
+	sharedRefPrefix = "shared:"
+)
+
+// isDrivePathRef reports whether ref addresses a file by path
+// (drive:/Folder/file or shared:<Drive>/Folder/file) rather than by ID.
+func isDrivePathRef(ref string) bool {
+	ref = strings.TrimSpace(ref)
+	return strings.HasPrefix(ref, driveRefPrefix+"/") || strings.HasPrefix(ref, sharedRefPrefix)
+}
+
+// driveRefCacheTTL bounds how long a resolved path is trusted. Long-running
+// commands (drive changes watch, drive serve webdav) share one service, so a
+// moved or renamed item must eventually be looked up again.
+const driveRefCacheTTL = 30 * time.Second
+
+// driveRefResolver walks Drive paths one folder at a time, caching each step
+// for driveRefCacheTTL.
+type driveRefResolver struct {
+	svc *drive.Service
+	now func() time.Time
+
+	mu     sync.Mutex
+	drives map[string]driveRefCacheEntry // shared drive name -> ID
+	paths  map[string]driveRefCacheEntry // parentID + "/" + name -> ID
+}
+
+type driveRefCacheEntry struct {
+	id      string
+	expires time.Time
+}
+
+var (
+	driveRefResolversMu sync.Mutex
+	driveRefResolvers   = map[*drive.Service]*driveRefResolver{}
+)
+
+func driveRefResolverFor(svc *drive.Service) *driveRefResolver {
+	driveRefResolversMu.Lock()
+	defer driveRefResolversMu.Unlock()
+	r, ok := driveRefResolvers[svc]
+	if !ok {
+		r = &driveRefResolver{svc: svc, now: time.Now, drives: map[string]driveRefCacheEntry{}, paths: map[string]driveRefCacheEntry{}}
+		driveRefResolvers[svc] = r
+	}
+	return r
+}
+
+// cached returns the unexpired ID stored under key, dropping stale entries.
+func (r *driveRefResolver) cached(m map[string]driveRefCacheEntry, key string) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e, ok := m[key]
+	if !ok {
+		return "", false
+	}
+	if !r.now().Before(e.expires) {
+		delete(m, key)
+		return "", false
+	}
+	return e.id, true
+}
+
+func (r *driveRefResolver) store(m map[string]driveRefCacheEntry, key, id string) {
+	r.mu.Lock()
+	m[key] = driveRefCacheEntry{id: id, expires: r.now().Add(driveRefCacheTTL)}
+	r.mu.Unlock()
+}
+
+// resolveDriveRef turns a file reference into a Drive file ID. It accepts
+// bare IDs, Drive URLs, drive:ID, drive:/path/in/My Drive and
+// shared:<Drive name>/path. Only path references call the API.
+func resolveDriveRef(ctx context.Context, svc *drive.Service, ref string) (string, error) {
+	ref = strings.TrimSpace(ref)
+	if !isDrivePathRef(ref) {
+		return normalizeGoogleID(strings.TrimPrefix(ref, driveRefPrefix)), nil
+	}
+	return driveRefResolverFor(svc).resolve(ctx, ref)
+}
+
+// resolveDriveFolderRef is resolveDriveRef for folder arguments, where empty
+// means the root of My Drive.
+func resolveDriveFolderRef(ctx context.Context, svc *drive.Service, ref string) (string, error) {
+	id, err := resolveDriveRef(ctx, svc, ref)
+	if err != nil {
+		return "", err
+	}
+	if id == "" {
+		return "root", nil
+	}
+	return id, nil
+}
+
+func (r *driveRefResolver) resolve(ctx context.Context, ref string) (string, error) {
+	var (
+		parentID string
+		driveID  string
+		rest     string
+	)
+	if after, ok := strings.CutPrefix(ref, sharedRefPrefix); ok {
+		name, p, _ := strings.Cut(after, "/")
+		if strings.TrimSpace(name) == "" {
+			return "", usagef("invalid Drive path %q (want shared:<Drive name>/path)", ref)
+		}
+		id, err := r.sharedDrive(ctx, name)
+		if err != nil {
+			return "", err
+		}
+		parentID, driveID, rest = id, id, p
+	} else {
+		parentID, rest = "root", strings.TrimPrefix(ref, driveRefPrefix)
+	}
+
+	segments := make([]string, 0)
+	for _, s := range strings.Split(rest, "/") {
+		if s = strings.TrimSpace(s); s != "" {
+			segments = append(segments, s)
+		}
+	}
+	for i, name := range segments {
+		id, err := r.child(ctx, ref, parentID, driveID, name, i < len(segments)-1)
+		if err != nil {
+			return "", err
+		}
+		parentID = id
+	}
+	return parentID, nil
+}
+
+func (r *driveRefResolver) sharedDrive(ctx context.Context, name string) (string, error) {
+	id, ok := r.cached(r.drives, name)
+	if ok {
+		return id, nil
+	}
+
+	drives, err := collectAllPages("", func(pageToken string) ([]*drive.Drive, string, error) {
+		call := r.svc.Drives.List().
+			Q(fmt.Sprintf("name = '%s'", escapeDriveQueryString(name))).
+			PageSize(100).
+			Fields("nextPageToken, drives(id, name)").
+			Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Drives, resp.NextPageToken, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	switch len(drives) {
+	case 0:
+		return "", usagef("shared drive %q not found", name)
+	case 1:
+		id = drives[0].Id
+	default:
+		ids := make([]string, 0, len(drives))
+		for _, d := range drives {
+			ids = append(ids, d.Id)
+		}
+		sort.Strings(ids)
+		return "", usagef("ambiguous shared drive %q; matches: %s", name, strings.Join(ids, ", "))
+	}
+
+	r.store(r.drives, name, id)
+	return id, nil
+}
+
+// child finds name inside parentID. Intermediate segments must be folders
+// (shortcuts to folders are followed); the last one may be anything.
+func (r *driveRefResolver) child(ctx context.Context, ref, parentID, driveID, name string, wantFolder bool) (string, error) {
+	key := parentID + "/" + name
+	if wantFolder {
+		key += "/"
+	}
+	id, ok := r.cached(r.paths, key)
+	if ok {
+		return id, nil
+	}
+
+	files, err := collectAllPages("", func(pageToken string) ([]*drive.File, string, error) {
+		call := r.svc.Files.List().
+			Q(fmt.Sprintf("'%s' in parents and name = '%s' and trashed = false", escapeDriveQueryString(parentID), escapeDriveQueryString(name))).
+			PageSize(100).
+			SupportsAllDrives(true).
+			IncludeItemsFromAllDrives(true).
+			Fields("nextPageToken, files(id, name, mimeType, modifiedTime, shortcutDetails(targetId, targetMimeType))").
+			Context(ctx)
+		if driveID != "" {
+			call = call.Corpora("drive").DriveId(driveID)
+		}
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Files, resp.NextPageToken, nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	matches := make([]*drive.File, 0, len(files))
+	for _, f := range files {
+		if !wantFolder {
+			matches = append(matches, f)
+			continue
+		}
+		switch {
+		case f.MimeType == driveMimeFolder:
+			matches = append(matches, f)
+		case f.MimeType == driveMimeShortcut && f.ShortcutDetails != nil && f.ShortcutDetails.TargetMimeType == driveMimeFolder:
+			matches = append(matches, &drive.File{Id: f.ShortcutDetails.TargetId, Name: f.Name, MimeType: driveMimeFolder, ModifiedTime: f.ModifiedTime})
+		}
+	}
+	switch len(matches) {
+	case 0:
+		what := "file or folder"
+		if wantFolder {
+			what = "folder"
+		}
+		return "", usagef("%s: no %s named %q", ref, what, name)
+	case 1:
+		id = matches[0].Id
+	default:
+		parts := make([]string, 0, len(matches))
+		for _, m := range matches {
+			parts = append(parts, fmt.Sprintf("%s (%s, modified %s)", m.Id, driveType(m.MimeType), formatDateTime(m.ModifiedTime)))
+		}
+		sort.Strings(parts)
+		return "", usagef("%s: ambiguous name %q; use one of the IDs: %s", ref, name, strings.Join(parts, ", "))
+	}
+
+	r.store(r.paths, key, id)
+	return id, nil
+}
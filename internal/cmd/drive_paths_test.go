@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+func drivePathsTestService(t *testing.T) (*drive.Service, *atomic.Int32) {
+	t.Helper()
+	children := map[string][]map[string]any{
+		"root": {
+			{"id": "f1", "name": "Reports", "mimeType": driveMimeFolder},
+			{"id": "x1", "name": "dup.txt", "mimeType": "text/plain"},
+			{"id": "x2", "name": "dup.txt", "mimeType": "text/plain"},
+			{"id": "sc1", "name": "Link", "mimeType": driveMimeShortcut, "shortcutDetails": map[string]any{"targetId": "f9", "targetMimeType": driveMimeFolder}},
+		},
+		"f1":  {{"id": "s1", "name": "q3.xlsx", "mimeType": mimeXlsx}},
+		"f9":  {{"id": "t1", "name": "target.txt", "mimeType": "text/plain"}},
+		"sd1": {{"id": "p1", "name": "Plans", "mimeType": driveMimeFolder}},
+	}
+	queryRe := regexp.MustCompile(`^'([^']*)' in parents and name = '((?:[^'\\]|\\.)*)' and trashed = false$`)
+	var lists atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/drives":
+			var drives []map[string]any
+			if r.URL.Query().Get("q") == "name = 'Team'" {
+				drives = append(drives, map[string]any{"id": "sd1", "name": "Team"})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"drives": drives})
+		case r.URL.Path == "/files":
+			lists.Add(1)
+			m := queryRe.FindStringSubmatch(r.URL.Query().Get("q"))
+			if m == nil {
+				t.Errorf("unexpected query %q", r.URL.Query().Get("q"))
+				return
+			}
+			if m[1] == "sd1" && r.URL.Query().Get("driveId") != "sd1" {
+				t.Errorf("shared drive lookups should set driveId")
+			}
+			var files []map[string]any
+			for _, f := range children[m[1]] {
+				if f["name"] == strings.ReplaceAll(m[2], `\'`, "'") {
+					files = append(files, f)
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": files})
+		case strings.HasPrefix(r.URL.Path, "/files/"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": strings.TrimPrefix(r.URL.Path, "/files/"), "name": "q3.xlsx", "mimeType": mimeXlsx})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	return svc, &lists
+}
+
+func TestResolveDriveRef(t *testing.T) {
+	svc, lists := drivePathsTestService(t)
+	ctx := context.Background()
+
+	for _, tc := range []struct{ ref, want string }{
+		{"abc123", "abc123"},
+		{"drive:abc123", "abc123"},
+		{"https://drive.google.com/file/d/abc123/view", "abc123"},
+		{"", ""},
+		{"drive:/", "root"},
+		{"drive:/Reports/q3.xlsx", "s1"},
+		{"drive:/Link/target.txt", "t1"},
+		{"shared:Team/Plans", "p1"},
+		{"shared:Team", "sd1"},
+	} {
+		got, err := resolveDriveRef(ctx, svc, tc.ref)
+		if err != nil || got != tc.want {
+			t.Fatalf("resolveDriveRef(%q) = %q, %v; want %q", tc.ref, got, err, tc.want)
+		}
+	}
+
+	before := lists.Load()
+	if got, err := resolveDriveRef(ctx, svc, "drive:/Reports/q3.xlsx"); err != nil || got != "s1" {
+		t.Fatalf("cached lookup = %q, %v", got, err)
+	}
+	if lists.Load() != before {
+		t.Fatalf("repeated lookup should be served from the cache")
+	}
+	driveRefResolverFor(svc).now = func() time.Time { return time.Now().Add(driveRefCacheTTL) }
+	if got, err := resolveDriveRef(ctx, svc, "drive:/Reports/q3.xlsx"); err != nil || got != "s1" {
+		t.Fatalf("expired lookup = %q, %v", got, err)
+	}
+	if lists.Load() != before+2 {
+		t.Fatalf("expired entries must be looked up again, got %d lists", lists.Load()-before)
+	}
+
+	if _, err := resolveDriveRef(ctx, svc, "drive:/dup.txt"); err == nil || !strings.Contains(err.Error(), "x1") || !strings.Contains(err.Error(), "x2") {
+		t.Fatalf("expected ambiguity error listing candidates, got %v", err)
+	}
+	if _, err := resolveDriveRef(ctx, svc, "drive:/Reports/missing.txt"); err == nil || !strings.Contains(err.Error(), "missing.txt") {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+	if _, err := resolveDriveRef(ctx, svc, "drive:/dup.txt/inner"); err == nil || !strings.Contains(err.Error(), "no folder") {
+		t.Fatalf("files must not be walked into, got %v", err)
+	}
+	if _, err := resolveDriveRef(ctx, svc, "shared:Nope/x"); err == nil || !strings.Contains(err.Error(), "shared drive") {
+		t.Fatalf("expected unknown shared drive error, got %v", err)
+	}
+}
+
+func TestDriveGet_AcceptsPath(t *testing.T) {
+	svc, _ := drivePathsTestService(t)
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--account", "a@b.com", "drive", "get", "drive:/Reports/q3.xlsx"}); err != nil {
+			t.Fatalf("drive get: %v", err)
+		}
+	})
+	if !strings.Contains(out, "id\ts1") {
+		t.Fatalf("expected resolved ID, got %q", out)
+	}
+}
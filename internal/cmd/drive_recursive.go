@@ -0,0 +1,286 @@
+package cmd
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"google.golang.org/api/drive/v3"
+	gapi "google.golang.org/api/googleapi"
+)
+
+const driveMimeShortcut = "application/vnd.google-apps.shortcut"
+
+// driveTreeFile is a file found below a folder, with shortcuts resolved to
+// their targets.
+type driveTreeFile struct {
+	Path string // slash-separated, relative to the folder; includes export extension
+	File *drive.File
+}
+
+type driveTransferResult struct {
+	Path   string `json:"path"`
+	FileID string `json:"fileId,omitempty"`
+	Size   int64  `json:"size"`
+	Error  string `json:"error,omitempty"`
+}
+
+// driveTreeExportFormat keeps --format for files it applies to; other
+// native types fall back to their default export.
+func driveTreeExportFormat(mimeType, format string) string {
+	if _, err := driveExportMimeTypeForFormat(mimeType, format); err != nil {
+		return ""
+	}
+	return format
+}
+
+// collectDriveTree lists every downloadable file below folderID. Folder
+// shortcuts are followed (each folder at most once); native files get the
+// extension of their export format.
+func collectDriveTree(ctx context.Context, svc *drive.Service, folderID, format string) ([]driveTreeFile, error) {
+	type pending struct{ id, dir string }
+	var out []driveTreeFile
+	visited := map[string]bool{folderID: true}
+	used := map[string]bool{}
+	queue := []pending{{id: folderID}}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		children, err := listDriveChildren(ctx, svc, cur.id)
+		if err != nil {
+			return nil, err
+		}
+		sort.SliceStable(children, func(i, j int) bool { return children[i].Name < children[j].Name })
+		for _, f := range children {
+			name := driveSafeName(f.Name)
+			if name == "" {
+				continue
+			}
+			target := f
+			if f.MimeType == driveMimeShortcut && f.ShortcutDetails != nil {
+				target = &drive.File{Id: f.ShortcutDetails.TargetId, Name: f.Name, MimeType: f.ShortcutDetails.TargetMimeType}
+			}
+			if target.MimeType == driveMimeFolder {
+				if !visited[target.Id] {
+					visited[target.Id] = true
+					queue = append(queue, pending{id: target.Id, dir: path.Join(cur.dir, name)})
+				}
+				continue
+			}
+			if strings.HasPrefix(target.MimeType, "application/vnd.google-apps.") {
+				if !isDriveExportable(target.MimeType) {
+					continue
+				}
+				exportMime, _ := driveExportMimeTypeForFormat(target.MimeType, driveTreeExportFormat(target.MimeType, format))
+				ext := driveExportExtension(exportMime)
+				if !strings.EqualFold(filepath.Ext(name), ext) {
+					name += ext
+				}
+			}
+			rel := path.Join(cur.dir, name)
+			if used[rel] {
+				ext := path.Ext(rel)
+				rel = fmt.Sprintf("%s (%s)%s", strings.TrimSuffix(rel, ext), target.Id, ext)
+			}
+			used[rel] = true
+			out = append(out, driveTreeFile{Path: rel, File: target})
+		}
+	}
+	return out, nil
+}
+
+// downloadDriveTree recreates the folder below destDir with bounded concurrency.
+func downloadDriveTree(ctx context.Context, svc *drive.Service, files []driveTreeFile, destDir, format string, concurrency int) []driveTransferResult {
+	results := make([]driveTransferResult, len(files))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, tf := range files {
+		wg.Add(1)
+		go func(idx int, tf driveTreeFile) {
+			defer wg.Done()
+			res := &results[idx]
+			res.Path = tf.Path
+			res.FileID = tf.File.Id
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				res.Error = ctx.Err().Error()
+				return
+			}
+			dest := filepath.Join(destDir, filepath.FromSlash(tf.Path))
+			if err := os.MkdirAll(filepath.Dir(dest), 0o700); err != nil {
+				res.Error = err.Error()
+				return
+			}
+			_, n, err := downloadDriveFile(ctx, svc, tf.File, dest, driveTreeExportFormat(tf.File.MimeType, format))
+			if err != nil {
+				res.Error = err.Error()
+				return
+			}
+			res.Size = n
+		}(i, tf)
+	}
+	wg.Wait()
+	return results
+}
+
+// zipDriveTree streams every file into a single archive. Entries are written
+// sequentially because zip.Writer is not safe for concurrent use. The archive
+// is built in a temp file next to zipPath and only renamed into place when
+// every entry made it in, so a failed run never leaves a partial zip behind.
+func zipDriveTree(ctx context.Context, svc *drive.Service, files []driveTreeFile, zipPath, root, format string) ([]driveTransferResult, error) {
+	out, err := os.CreateTemp(filepath.Dir(zipPath), ".gog-zip-*")
+	if err != nil {
+		return nil, err
+	}
+	tmp := out.Name()
+	defer func() {
+		_ = out.Close()
+		_ = os.Remove(tmp)
+	}()
+	zw := zip.NewWriter(out)
+
+	results := make([]driveTransferResult, len(files))
+	failed := false
+	for i, tf := range files {
+		res := &results[i]
+		res.Path = tf.Path
+		res.FileID = tf.File.Id
+		body, _, openErr := openDriveContent(ctx, svc, tf.File, driveTreeExportFormat(tf.File.MimeType, format))
+		if openErr != nil {
+			res.Error = openErr.Error()
+			failed = true
+			continue
+		}
+		w, createErr := zw.Create(path.Join(root, tf.Path))
+		if createErr != nil {
+			body.Close()
+			return nil, createErr
+		}
+		n, copyErr := io.Copy(w, body)
+		body.Close()
+		if copyErr != nil {
+			return nil, copyErr
+		}
+		res.Size = n
+	}
+	if failed {
+		return results, nil
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	if err := out.Close(); err != nil {
+		return nil, err
+	}
+	return results, os.Rename(tmp, zipPath)
+}
+
+// uploadDriveTree mirrors localDir into a new folder under parent: folders
+// are created first (parents before children), then files upload concurrently.
+func uploadDriveTree(ctx context.Context, svc *drive.Service, localDir, rootName, parent string, convert bool, concurrency int) (*drive.File, []driveTransferResult, error) {
+	var dirs, files []string
+	err := filepath.WalkDir(localDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(localDir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		switch {
+		case rel == ".":
+		case d.IsDir():
+			dirs = append(dirs, rel)
+		case d.Type().IsRegular():
+			files = append(files, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root, err := createDriveFolder(ctx, svc, rootName, parent)
+	if err != nil {
+		return nil, nil, err
+	}
+	folders := map[string]string{".": root.Id}
+	for _, dir := range dirs { // WalkDir visits parents before children
+		created, mkErr := createDriveFolder(ctx, svc, path.Base(dir), folders[path.Dir(dir)])
+		if mkErr != nil {
+			return nil, nil, fmt.Errorf("create folder %s: %w", dir, mkErr)
+		}
+		folders[dir] = created.Id
+	}
+
+	results := make([]driveTransferResult, len(files))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, rel := range files {
+		wg.Add(1)
+		go func(idx int, rel string) {
+			defer wg.Done()
+			res := &results[idx]
+			res.Path = rel
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				res.Error = ctx.Err().Error()
+				return
+			}
+			localPath := filepath.Join(localDir, filepath.FromSlash(rel))
+			f, openErr := os.Open(localPath) //nolint:gosec // path comes from walking the upload root
+			if openErr != nil {
+				res.Error = openErr.Error()
+				return
+			}
+			defer f.Close()
+
+			meta := &drive.File{Name: path.Base(rel), Parents: []string{folders[path.Dir(rel)]}}
+			if convert {
+				if mimeType, ok := googleConvertMimeType(localPath); ok {
+					meta.MimeType = mimeType
+					meta.Name = stripOfficeExt(meta.Name)
+				}
+			}
+			created, upErr := svc.Files.Create(meta).
+				SupportsAllDrives(true).
+				Media(f, gapi.ContentType(guessMimeType(localPath))).
+				Fields("id, size").
+				Context(ctx).
+				Do()
+			if upErr != nil {
+				res.Error = upErr.Error()
+				return
+			}
+			res.FileID = created.Id
+			if st, statErr := f.Stat(); statErr == nil {
+				res.Size = st.Size()
+			}
+		}(i, rel)
+	}
+	wg.Wait()
+	return root, results, nil
+}
+
+func countTransferFailures(results []driveTransferResult) (failed int, total int64) {
+	for _, r := range results {
+		if r.Error != "" {
+			failed++
+		}
+		total += r.Size
+	}
+	return failed, total
+}
@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+// driveTreeTestServer serves folder "top" containing a file, a Google Doc,
+// a subfolder and a shortcut back to the top folder (which must not loop).
+func driveTreeTestServer(t *testing.T) func() []map[string]any {
+	t.Helper()
+	var mu sync.Mutex
+	var created []map[string]any
+	parentRe := regexp.MustCompile(`'([^']+)' in parents`)
+	children := map[string][]map[string]any{
+		"top": {
+			{"id": "f1", "name": "notes.txt", "mimeType": "text/plain"},
+			{"id": "doc1", "name": "Plan", "mimeType": driveMimeGoogleDoc},
+			{"id": "sub", "name": "sub", "mimeType": driveMimeFolder},
+			{"id": "sc1", "name": "loop", "mimeType": driveMimeShortcut, "shortcutDetails": map[string]any{"targetId": "top", "targetMimeType": driveMimeFolder}},
+		},
+		"sub": {
+			{"id": "sc2", "name": "budget", "mimeType": driveMimeShortcut, "shortcutDetails": map[string]any{"targetId": "sheet1", "targetMimeType": driveMimeGoogleSheet}},
+			{"id": "form1", "name": "Survey", "mimeType": "application/vnd.google-apps.form"},
+		},
+		"bad": {
+			{"id": "f2", "name": "ok.txt", "mimeType": "text/plain"},
+			{"id": "gone", "name": "gone.bin", "mimeType": "application/octet-stream"},
+		},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/files":
+			m := parentRe.FindStringSubmatch(r.URL.Query().Get("q"))
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": children[m[1]]})
+		case r.Method == http.MethodGet && r.URL.Path == "/files/top" && r.URL.Query().Get("alt") != "media":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "top", "name": "Top", "mimeType": driveMimeFolder})
+		case r.Method == http.MethodGet && r.URL.Path == "/files/bad" && r.URL.Query().Get("alt") != "media":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "bad", "name": "Bad", "mimeType": driveMimeFolder})
+		case r.Method == http.MethodGet && r.URL.Path == "/files/gone":
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": 403, "message": "cannotDownloadFile"}})
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/export"):
+			_, _ = io.WriteString(w, "export:"+r.URL.Query().Get("mimeType"))
+		case r.Method == http.MethodGet && r.URL.Query().Get("alt") == "media":
+			_, _ = io.WriteString(w, "content:"+strings.TrimPrefix(r.URL.Path, "/files/"))
+		case r.Method == http.MethodPost:
+			var meta map[string]any
+			if mt, params, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err == nil && strings.HasPrefix(mt, "multipart/") {
+				if part, perr := multipart.NewReader(r.Body, params["boundary"]).NextPart(); perr == nil {
+					_ = json.NewDecoder(part).Decode(&meta)
+				}
+			} else {
+				_ = json.NewDecoder(r.Body).Decode(&meta)
+			}
+			mu.Lock()
+			created = append(created, meta)
+			mu.Unlock()
+			meta["id"] = "id-" + meta["name"].(string)
+			_ = json.NewEncoder(w).Encode(meta)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+	return func() []map[string]any {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]map[string]any(nil), created...)
+	}
+}
+
+func TestDriveDownload_RecursiveFolder(t *testing.T) {
+	_ = driveTreeTestServer(t)
+	dest := filepath.Join(t.TempDir(), "out")
+
+	_ = captureStdout(t, func() {
+		if err := Execute([]string{"--account", "a@b.com", "drive", "download", "top", "--recursive", "--out", dest, "--format", "xlsx"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	for name, want := range map[string]string{
+		"notes.txt":       "content:f1",
+		"Plan.pdf":        "export:" + mimePDF, // xlsx does not apply to Docs
+		"sub/budget.xlsx": "export:" + mimeXlsx,
+	} {
+		b, err := os.ReadFile(filepath.Join(dest, filepath.FromSlash(name)))
+		if err != nil || string(b) != want {
+			t.Fatalf("%s: %q %v", name, b, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dest, "loop")); err == nil {
+		t.Fatalf("shortcut loop back to the root should not be followed")
+	}
+
+	var err error
+	_ = captureStderr(t, func() {
+		err = Execute([]string{"--account", "a@b.com", "drive", "download", "top", "--out", dest})
+	})
+	if err == nil || !strings.Contains(err.Error(), "--recursive") {
+		t.Fatalf("expected folder usage error, got %v", err)
+	}
+}
+
+func TestDriveDownload_Zip(t *testing.T) {
+	_ = driveTreeTestServer(t)
+	dest := filepath.Join(t.TempDir(), "archive")
+
+	_ = captureStdout(t, func() {
+		if err := Execute([]string{"--account", "a@b.com", "drive", "download", "top", "--zip", "--out", dest}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	zr, err := zip.OpenReader(dest + ".zip")
+	if err != nil {
+		t.Fatalf("open zip: %v", err)
+	}
+	defer zr.Close()
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	sort.Strings(names)
+	if strings.Join(names, ",") != "Top/Plan.pdf,Top/notes.txt,Top/sub/budget.csv" {
+		t.Fatalf("unexpected zip entries: %v", names)
+	}
+}
+
+func TestDriveDownload_ZipDiscardedOnFailure(t *testing.T) {
+	_ = driveTreeTestServer(t)
+	dir := t.TempDir()
+	dest := filepath.Join(dir, "archive.zip")
+
+	var err error
+	_ = captureStdout(t, func() {
+		_ = captureStderr(t, func() {
+			err = Execute([]string{"--account", "a@b.com", "drive", "download", "bad", "--zip", "--out", dest})
+		})
+	})
+	if err == nil || !strings.Contains(err.Error(), "no archive was written") {
+		t.Fatalf("expected failed download error, got %v", err)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 0 {
+		t.Fatalf("a failed zip must leave nothing behind, found %v", entries)
+	}
+}
+
+func TestDriveUpload_RecursiveDirectory(t *testing.T) {
+	created := driveTreeTestServer(t)
+	dir := filepath.Join(t.TempDir(), "site")
+	for name, body := range map[string]string{"index.html": "<p>", "css/app.css": "a{}", "css/vendor/x.css": "", "docs/spec.docx": "PK"} {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "drive", "upload", dir, "--recursive", "--parent", "dest", "--convert"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	var parsed struct {
+		Folder *drive.File           `json:"folder"`
+		Files  []driveTransferResult `json:"files"`
+		Failed int                   `json:"failed"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if parsed.Folder == nil || parsed.Folder.Id != "id-site" || len(parsed.Files) != 4 || parsed.Failed != 0 {
+		t.Fatalf("unexpected result: %s", out)
+	}
+
+	byName := map[string]map[string]any{}
+	for _, m := range created() {
+		byName[m["name"].(string)] = m
+	}
+	parentOf := func(name string) any {
+		parents, _ := byName[name]["parents"].([]any)
+		if len(parents) != 1 {
+			return nil
+		}
+		return parents[0]
+	}
+	for name, want := range map[string]string{"site": "dest", "css": "id-site", "vendor": "id-css", "index.html": "id-site", "x.css": "id-vendor", "spec": "id-docs"} {
+		if got := parentOf(name); got != want {
+			t.Fatalf("%s: parent %v, want %s (created %#v)", name, got, want, byName)
+		}
+	}
+	if byName["spec"]["mimeType"] != driveMimeGoogleDoc {
+		t.Fatalf("expected --convert to apply per file: %#v", byName["spec"])
+	}
+
+	var err error
+	_ = captureStderr(t, func() {
+		err = Execute([]string{"--account", "a@b.com", "drive", "upload", dir})
+	})
+	if err == nil || !strings.Contains(err.Error(), "--recursive") {
+		t.Fatalf("expected directory usage error, got %v", err)
+	}
+}
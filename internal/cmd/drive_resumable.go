@@ -0,0 +1,404 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+	gapi "google.golang.org/api/googleapi"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/googleapi"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+var newDriveHTTPClient = googleapi.NewDriveHTTPClient
+
+// Chunks must be multiples of 256 KiB. They start at 8 MiB and double or
+// halve depending on how long each chunk takes.
+const (
+	driveChunkQuantum   = 256 << 10
+	driveChunkMax       = 256 << 20
+	driveChunkFast      = 5 * time.Second
+	driveChunkSlow      = 30 * time.Second
+	driveUploadAttempts = 5
+)
+
+var (
+	// driveResumableThreshold is the size from which uploads use the
+	// resumable protocol even without --resume.
+	driveResumableThreshold int64 = 8 << 20
+	driveChunkInitial       int64 = 8 << 20
+	driveUploadRetryDelay         = time.Second
+)
+
+// driveUploadSession is persisted under the config dir so an interrupted
+// upload can continue with --resume.
+type driveUploadSession struct {
+	SessionURI string `json:"sessionUri"`
+	Path       string `json:"path"`
+	Size       int64  `json:"size"`
+	ModTime    string `json:"modTime"`
+	Target     string `json:"target"`
+	CreatedAt  string `json:"createdAt"`
+}
+
+// driveResumableUpload describes one upload: Meta is sent when the session
+// starts; FileID switches from create to replace.
+type driveResumableUpload struct {
+	Account             string
+	LocalPath           string
+	Meta                *drive.File
+	FileID              string
+	MimeType            string
+	KeepRevisionForever bool
+	Resume              bool
+}
+
+func (up driveResumableUpload) target() string {
+	if up.FileID != "" {
+		return "replace:" + up.FileID
+	}
+	return "create:" + strings.Join(up.Meta.Parents, ",") + "/" + up.Meta.Name
+}
+
+func driveUploadSessionPath(account, localPath, target string) (string, error) {
+	dir, err := config.EnsureDriveUploadsDir()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(strings.ToLower(account) + "\n" + localPath + "\n" + target))
+	return filepath.Join(dir, hex.EncodeToString(sum[:8])+".json"), nil
+}
+
+func loadDriveUploadSession(path string) (*driveUploadSession, error) {
+	b, err := os.ReadFile(path) //nolint:gosec // path is derived from the config dir
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var s driveUploadSession
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, fmt.Errorf("parse upload session %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+func saveDriveUploadSession(path string, s *driveUploadSession) error {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o600)
+}
+
+// uploadDriveResumable sends f using Drive's resumable protocol, persisting
+// the session URI so a later --resume continues from the server's offset.
+func uploadDriveResumable(ctx context.Context, svc *drive.Service, f *os.File, up driveResumableUpload) (*drive.File, error) {
+	u := ui.FromContext(ctx)
+	st, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := st.Size()
+	modTime := st.ModTime().UTC().Format(time.RFC3339Nano)
+	abs, err := filepath.Abs(up.LocalPath)
+	if err != nil {
+		return nil, err
+	}
+	statePath, err := driveUploadSessionPath(up.Account, abs, up.target())
+	if err != nil {
+		return nil, err
+	}
+	client, err := newDriveHTTPClient(ctx, up.Account)
+	if err != nil {
+		return nil, err
+	}
+	r := &driveResumer{ctx: ctx, client: client, size: size}
+
+	var offset int64
+	var session *driveUploadSession
+	if up.Resume {
+		session, err = loadDriveUploadSession(statePath)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case session == nil:
+			u.Err().Println("No saved upload session; starting from the beginning")
+		case session.Size != size || session.ModTime != modTime:
+			u.Err().Println("Local file changed since the saved session; starting from the beginning")
+			session = nil
+		default:
+			var done *drive.File
+			offset, done, err = r.status(session.SessionURI)
+			if errors.Is(err, errDriveSessionExpired) {
+				u.Err().Println("Saved upload session expired; starting from the beginning")
+				session = nil
+			} else if err != nil {
+				return nil, err
+			} else if done != nil {
+				_ = os.Remove(statePath)
+				return done, nil
+			} else {
+				u.Err().Printf("Resuming upload at %s of %s", formatDriveSize(offset), formatDriveSize(size))
+			}
+		}
+	}
+	if session == nil {
+		uri, startErr := r.start(svc, up)
+		if startErr != nil {
+			return nil, startErr
+		}
+		session = &driveUploadSession{
+			SessionURI: uri,
+			Path:       abs,
+			Size:       size,
+			ModTime:    modTime,
+			Target:     up.target(),
+			CreatedAt:  time.Now().UTC().Format(time.RFC3339),
+		}
+		offset = 0
+		if err := saveDriveUploadSession(statePath, session); err != nil {
+			return nil, err
+		}
+	}
+
+	done, err := r.send(f, session.SessionURI, offset, func(sent int64, rate float64) {
+		printDriveUploadProgress(u, sent, size, rate)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w (re-run with --resume to continue)", err)
+	}
+	_ = os.Remove(statePath)
+	return done, nil
+}
+
+var errDriveSessionExpired = errors.New("upload session expired")
+
+type driveResumer struct {
+	ctx    context.Context
+	client *http.Client
+	size   int64
+}
+
+func (r *driveResumer) start(svc *drive.Service, up driveResumableUpload) (string, error) {
+	q := url.Values{}
+	q.Set("uploadType", "resumable")
+	q.Set("supportsAllDrives", "true")
+	q.Set("fields", "id, name, mimeType, size, webViewLink")
+	if up.KeepRevisionForever {
+		q.Set("keepRevisionForever", "true")
+	}
+	endpoint := gapi.ResolveRelative(svc.BasePath, "/upload/drive/v3/files")
+	method := http.MethodPost
+	if up.FileID != "" {
+		endpoint += "/" + url.PathEscape(up.FileID)
+		method = http.MethodPatch
+	}
+	body, err := json.Marshal(up.Meta)
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(r.ctx, method, endpoint+"?"+q.Encode(), bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	req.Header.Set("X-Upload-Content-Type", up.MimeType)
+	req.Header.Set("X-Upload-Content-Length", strconv.FormatInt(r.size, 10))
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if err := gapi.CheckResponse(resp); err != nil {
+		return "", err
+	}
+	loc := resp.Header.Get("Location")
+	if loc == "" {
+		return "", errors.New("start resumable upload: missing session URI")
+	}
+	return loc, nil
+}
+
+// status asks the server how many bytes it has. It returns the finished
+// file when the upload already completed.
+func (r *driveResumer) status(sessionURI string) (int64, *drive.File, error) {
+	req, err := http.NewRequestWithContext(r.ctx, http.MethodPut, sessionURI, http.NoBody)
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("Content-Range", fmt.Sprintf("bytes */%d", r.size))
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	return r.handle(resp)
+}
+
+// handle interprets a chunk or status response: 308 carries the committed
+// range, 200/201 the final file, 404/410 an expired session.
+func (r *driveResumer) handle(resp *http.Response) (int64, *drive.File, error) {
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusPermanentRedirect:
+		return parseDriveUploadRange(resp.Header.Get("Range")), nil, nil
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return 0, nil, errDriveSessionExpired
+	case resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusCreated:
+		var f drive.File
+		if err := json.NewDecoder(resp.Body).Decode(&f); err != nil {
+			return 0, nil, fmt.Errorf("decode upload response: %w", err)
+		}
+		return r.size, &f, nil
+	default:
+		return 0, nil, gapi.CheckResponse(resp)
+	}
+}
+
+// parseDriveUploadRange turns "bytes=0-1048575" into the next offset.
+func parseDriveUploadRange(h string) int64 {
+	_, last, ok := strings.Cut(strings.TrimPrefix(strings.TrimSpace(h), "bytes="), "-")
+	if !ok {
+		return 0
+	}
+	n, err := strconv.ParseInt(last, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return n + 1
+}
+
+// send uploads from offset in adaptive chunks. After a failed chunk it asks
+// the server for its offset and retries, up to driveUploadAttempts times in a row.
+func (r *driveResumer) send(f *os.File, sessionURI string, offset int64, progress func(sent int64, rate float64)) (*drive.File, error) {
+	chunk := driveChunkInitial
+	buf := make([]byte, 0, chunk)
+	started := time.Now()
+	startOffset := offset
+	failures := 0
+	for {
+		n := min(chunk, r.size-offset)
+		buf = buf[:n]
+		if _, err := f.ReadAt(buf, offset); err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		req, err := http.NewRequestWithContext(r.ctx, http.MethodPut, sessionURI, bytes.NewReader(buf))
+		if err != nil {
+			return nil, err
+		}
+		req.ContentLength = n
+		if n > 0 {
+			req.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, offset+n-1, r.size))
+		} else {
+			req.Header.Set("Content-Range", fmt.Sprintf("bytes */%d", r.size))
+		}
+
+		chunkStart := time.Now()
+		resp, err := r.client.Do(req)
+		var next int64
+		var done *drive.File
+		if err == nil {
+			next, done, err = r.handle(resp)
+		}
+		if err != nil {
+			if errors.Is(err, errDriveSessionExpired) || r.ctx.Err() != nil || !driveUploadRetryable(err) {
+				return nil, err
+			}
+			failures++
+			if failures >= driveUploadAttempts {
+				return nil, err
+			}
+			if sleepErr := sleepContext(r.ctx, driveUploadRetryDelay<<(failures-1)); sleepErr != nil {
+				return nil, sleepErr
+			}
+			if next, done, err = r.status(sessionURI); err != nil {
+				return nil, err
+			}
+			if done != nil {
+				return done, nil
+			}
+			offset = next
+			continue
+		}
+		failures = 0
+		if done != nil {
+			progress(r.size, driveUploadRate(r.size-startOffset, started))
+			return done, nil
+		}
+		offset = next
+		progress(offset, driveUploadRate(offset-startOffset, started))
+		chunk = adaptDriveChunk(chunk, time.Since(chunkStart))
+		if int64(cap(buf)) < chunk {
+			buf = make([]byte, 0, chunk)
+		}
+	}
+}
+
+func driveUploadRate(sent int64, started time.Time) float64 {
+	elapsed := time.Since(started).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(sent) / elapsed
+}
+
+func adaptDriveChunk(chunk int64, took time.Duration) int64 {
+	switch {
+	case took < driveChunkFast && chunk < driveChunkMax:
+		return chunk * 2
+	case took > driveChunkSlow && chunk > driveChunkQuantum:
+		return max(chunk/2/driveChunkQuantum*driveChunkQuantum, driveChunkQuantum)
+	default:
+		return chunk
+	}
+}
+
+// driveUploadRetryable reports whether a chunk failure is worth retrying:
+// network errors and server-side failures, not 4xx client errors.
+func driveUploadRetryable(err error) bool {
+	var apiErr *gapi.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Code >= 500 || apiErr.Code == http.StatusTooManyRequests
+	}
+	return true
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+func printDriveUploadProgress(u *ui.UI, sent, total int64, rate float64) {
+	pct := 100.0
+	if total > 0 {
+		pct = float64(sent) * 100 / float64(total)
+	}
+	eta := "-"
+	if rate > 0 && sent < total {
+		eta = (time.Duration(float64(total-sent)/rate) * time.Second).Round(time.Second).String()
+	}
+	u.Err().Printf("upload\t%5.1f%%\t%s/%s\t%s/s\tETA %s", pct, formatDriveSize(sent), formatDriveSize(total), formatDriveSize(int64(rate)), eta)
+}
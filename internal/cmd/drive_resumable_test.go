@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+// driveResumableTestServer implements the resumable session protocol. When
+// failChunk is > 0 that chunk request (1-based) fails once with a 403.
+type driveResumableTestServer struct {
+	mu        sync.Mutex
+	data      []byte
+	starts    int
+	chunks    int
+	failChunk int
+	meta      map[string]any
+}
+
+func newDriveResumableTestServer(t *testing.T, failChunk int) *driveResumableTestServer {
+	t.Helper()
+	ts := &driveResumableTestServer{failChunk: failChunk}
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ts.mu.Lock()
+		defer ts.mu.Unlock()
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/upload/drive/v3/files":
+			if r.URL.Query().Get("uploadType") != "resumable" || r.Header.Get("X-Upload-Content-Length") == "" {
+				http.Error(w, "bad start", http.StatusBadRequest)
+				return
+			}
+			ts.starts++
+			ts.data = nil
+			_ = json.NewDecoder(r.Body).Decode(&ts.meta)
+			w.Header().Set("Location", srv.URL+"/session/1")
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodPut && r.URL.Path == "/session/1":
+			var start, end, total int64
+			cr := r.Header.Get("Content-Range")
+			if strings.HasPrefix(cr, "bytes */") {
+				ts.writeStatus(w)
+				return
+			}
+			if _, err := fmt.Sscanf(cr, "bytes %d-%d/%d", &start, &end, &total); err != nil {
+				http.Error(w, "bad range "+cr, http.StatusBadRequest)
+				return
+			}
+			ts.chunks++
+			body, _ := io.ReadAll(r.Body)
+			if ts.chunks == ts.failChunk {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = io.WriteString(w, `{"error":{"code":403,"message":"network went away"}}`)
+				return
+			}
+			if start != int64(len(ts.data)) {
+				http.Error(w, "unexpected offset", http.StatusBadRequest)
+				return
+			}
+			ts.data = append(ts.data, body...)
+			if int64(len(ts.data)) == total {
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(map[string]any{"id": "up1", "name": ts.meta["name"], "size": fmt.Sprint(total)})
+				return
+			}
+			ts.writeStatus(w)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	origNew, origClient := newDriveService, newDriveHTTPClient
+	origThreshold, origChunk := driveResumableThreshold, driveChunkInitial
+	t.Cleanup(func() {
+		newDriveService, newDriveHTTPClient = origNew, origClient
+		driveResumableThreshold, driveChunkInitial = origThreshold, origChunk
+	})
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+	newDriveHTTPClient = func(context.Context, string) (*http.Client, error) { return srv.Client(), nil }
+	driveResumableThreshold = 512 << 10
+	driveChunkInitial = driveChunkQuantum
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	return ts
+}
+
+func (ts *driveResumableTestServer) writeStatus(w http.ResponseWriter) {
+	if len(ts.data) > 0 {
+		w.Header().Set("Range", fmt.Sprintf("bytes=0-%d", len(ts.data)-1))
+	}
+	w.WriteHeader(http.StatusPermanentRedirect)
+}
+
+func writeDriveResumableTestFile(t *testing.T) (string, []byte) {
+	t.Helper()
+	content := bytes.Repeat([]byte("0123456789abcdef"), 600<<10/16)
+	p := filepath.Join(t.TempDir(), "big.bin")
+	if err := os.WriteFile(p, content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return p, content
+}
+
+func TestDriveUpload_ResumableChunks(t *testing.T) {
+	ts := newDriveResumableTestServer(t, 0)
+	p, content := writeDriveResumableTestFile(t)
+
+	var out string
+	errOut := captureStderr(t, func() {
+		out = captureStdout(t, func() {
+			if err := Execute([]string{"--json", "--account", "a@b.com", "drive", "upload", p, "--parent", "dest"}); err != nil {
+				t.Fatalf("Execute: %v", err)
+			}
+		})
+	})
+	if !bytes.Equal(ts.data, content) {
+		t.Fatalf("uploaded %d bytes, want %d", len(ts.data), len(content))
+	}
+	if ts.starts != 1 || ts.chunks < 2 {
+		t.Fatalf("expected one session with several chunks, got starts=%d chunks=%d", ts.starts, ts.chunks)
+	}
+	if parents, _ := ts.meta["parents"].([]any); len(parents) != 1 || parents[0] != "dest" {
+		t.Fatalf("unexpected metadata: %#v", ts.meta)
+	}
+	if !strings.Contains(out, `"id": "up1"`) {
+		t.Fatalf("unexpected output: %s", out)
+	}
+	if !strings.Contains(errOut, "100.0%") || !strings.Contains(errOut, "ETA") {
+		t.Fatalf("expected progress on stderr, got %q", errOut)
+	}
+}
+
+func TestDriveUpload_ResumeAfterFailure(t *testing.T) {
+	ts := newDriveResumableTestServer(t, 2)
+	p, content := writeDriveResumableTestFile(t)
+
+	var err error
+	_ = captureStderr(t, func() {
+		_ = captureStdout(t, func() {
+			err = Execute([]string{"--account", "a@b.com", "drive", "upload", p})
+		})
+	})
+	if err == nil || !strings.Contains(err.Error(), "--resume") {
+		t.Fatalf("expected failure hinting at --resume, got %v", err)
+	}
+	dir := filepath.Join(os.Getenv("XDG_CONFIG_HOME"), "gogcli", "state", "drive-uploads")
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Fatalf("expected a saved session in %s, got %v", dir, entries)
+	}
+
+	errOut := captureStderr(t, func() {
+		_ = captureStdout(t, func() {
+			if err := Execute([]string{"--account", "a@b.com", "drive", "upload", p, "--resume"}); err != nil {
+				t.Fatalf("Execute --resume: %v", err)
+			}
+		})
+	})
+	if ts.starts != 1 {
+		t.Fatalf("--resume should reuse the saved session, got %d starts", ts.starts)
+	}
+	if !bytes.Equal(ts.data, content) {
+		t.Fatalf("uploaded %d bytes, want %d", len(ts.data), len(content))
+	}
+	if !strings.Contains(errOut, "Resuming upload at 256") {
+		t.Fatalf("expected resume notice, got %q", errOut)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Fatalf("expected session state removed, got %v", entries)
+	}
+}
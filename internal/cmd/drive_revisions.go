@@ -0,0 +1,559 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+	gapi "google.golang.org/api/googleapi"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const driveRevisionFields = "id, mimeType, modifiedTime, keepForever, size, md5Checksum, originalFilename, lastModifyingUser(displayName, emailAddress), exportLinks"
+
+// DriveRevisionsCmd is the parent command for revision history subcommands.
+type DriveRevisionsCmd struct {
+	List        DriveRevisionsListCmd        `cmd:"" name:"list" aliases:"ls" default:"withargs" help:"List revisions of a file"`
+	Get         DriveRevisionsGetCmd         `cmd:"" name:"get" aliases:"info,show" help:"Get revision metadata"`
+	Download    DriveRevisionsDownloadCmd    `cmd:"" name:"download" aliases:"dl" help:"Download the content of a revision"`
+	KeepForever DriveRevisionsKeepForeverCmd `cmd:"" name:"keep-forever" aliases:"pin" help:"Keep a revision forever (binary files only)"`
+	Delete      DriveRevisionsDeleteCmd      `cmd:"" name:"delete" aliases:"rm,del" help:"Delete a revision (binary files only)"`
+	Restore     DriveRevisionsRestoreCmd     `cmd:"" name:"restore" help:"Restore a revision (binary: new head revision; Google Docs: restored copy)"`
+}
+
+type DriveRevisionsListCmd struct {
+	FileID string `arg:"" name:"fileId" help:"File ID or path (drive:/Folder/file)"`
+	Max    int64  `name:"max" aliases:"limit" help:"Max results" default:"100"`
+	Page   string `name:"page" aliases:"cursor" help:"Page token"`
+	All    bool   `name:"all" aliases:"all-pages,allpages" help:"Fetch all pages"`
+}
+
+func (c *DriveRevisionsListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	fileID := normalizeGoogleID(strings.TrimSpace(c.FileID))
+	if fileID == "" {
+		return usage("empty fileId")
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	if fileID, err = resolveDriveRef(ctx, svc, fileID); err != nil {
+		return err
+	}
+
+	fetch := func(pageToken string) ([]*drive.Revision, string, error) {
+		call := svc.Revisions.List(fileID).
+			PageSize(c.Max).
+			Fields(gapi.Field("nextPageToken, revisions(" + driveRevisionFields + ")")).
+			Context(ctx)
+		if strings.TrimSpace(pageToken) != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Revisions, resp.NextPageToken, nil
+	}
+
+	var revisions []*drive.Revision
+	nextPageToken := ""
+	if c.All {
+		revisions, err = collectAllPages(c.Page, fetch)
+	} else {
+		revisions, nextPageToken, err = fetch(c.Page)
+	}
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"fileId":        fileID,
+			"revisions":     revisions,
+			"nextPageToken": nextPageToken,
+		})
+	}
+	if len(revisions) == 0 {
+		u.Err().Println("No revisions")
+		return nil
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "ID\tMODIFIED\tAUTHOR\tSIZE\tKEEP")
+	for _, r := range revisions {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\n",
+			r.Id,
+			formatDateTime(r.ModifiedTime),
+			driveRevisionAuthor(r),
+			formatDriveSize(r.Size),
+			r.KeepForever,
+		)
+	}
+	printNextPageHint(u, nextPageToken)
+	return nil
+}
+
+type DriveRevisionsGetCmd struct {
+	FileID     string `arg:"" name:"fileId" help:"File ID or path (drive:/Folder/file)"`
+	RevisionID string `arg:"" name:"revisionId" help:"Revision ID"`
+}
+
+func (c *DriveRevisionsGetCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	fileID, revisionID, err := driveRevisionArgs(c.FileID, c.RevisionID)
+	if err != nil {
+		return err
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	if fileID, err = resolveDriveRef(ctx, svc, fileID); err != nil {
+		return err
+	}
+	rev, err := getDriveRevision(ctx, svc, fileID, revisionID)
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"fileId": fileID, "revision": rev})
+	}
+	u.Out().Printf("id\t%s", rev.Id)
+	u.Out().Printf("modified\t%s", formatDateTime(rev.ModifiedTime))
+	if author := driveRevisionAuthor(rev); author != "" {
+		u.Out().Printf("author\t%s", author)
+	}
+	if rev.MimeType != "" {
+		u.Out().Printf("mime\t%s", rev.MimeType)
+	}
+	if rev.Size > 0 {
+		u.Out().Printf("size\t%s", formatDriveSize(rev.Size))
+	}
+	if rev.OriginalFilename != "" {
+		u.Out().Printf("filename\t%s", rev.OriginalFilename)
+	}
+	u.Out().Printf("keepForever\t%t", rev.KeepForever)
+	return nil
+}
+
+type DriveRevisionsDownloadCmd struct {
+	FileID     string         `arg:"" name:"fileId" help:"File ID or path (drive:/Folder/file)"`
+	RevisionID string         `arg:"" name:"revisionId" help:"Revision ID"`
+	Output     OutputPathFlag `embed:""`
+	Format     string         `name:"format" help:"Export format for Google Docs files: pdf|csv|xlsx|pptx|txt|png|docx (default: auto)"`
+}
+
+func (c *DriveRevisionsDownloadCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	fileID, revisionID, err := driveRevisionArgs(c.FileID, c.RevisionID)
+	if err != nil {
+		return err
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	if fileID, err = resolveDriveRef(ctx, svc, fileID); err != nil {
+		return err
+	}
+	file, err := svc.Files.Get(fileID).
+		SupportsAllDrives(true).
+		Fields("id, name, mimeType").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+	rev, err := getDriveRevision(ctx, svc, fileID, revisionID)
+	if err != nil {
+		return err
+	}
+
+	name := file.Name
+	if rev.OriginalFilename != "" {
+		name = rev.OriginalFilename
+	}
+	destPath, err := resolveDriveDownloadDestPath(&drive.File{Id: fileID + "_" + revisionID, Name: name}, c.Output.Path)
+	if err != nil {
+		return err
+	}
+
+	body, exportExt, err := openDriveRevisionContent(ctx, svc, account, file, rev, c.Format)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	if exportExt != "" {
+		destPath = replaceExt(destPath, exportExt)
+	}
+	out, err := os.Create(destPath) //nolint:gosec // user-provided path
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	n, err := io.Copy(out, body)
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"path": destPath, "size": n, "revisionId": revisionID})
+	}
+	u.Out().Printf("path\t%s", destPath)
+	u.Out().Printf("size\t%s", formatDriveSize(n))
+	return nil
+}
+
+type DriveRevisionsKeepForeverCmd struct {
+	FileID     string `arg:"" name:"fileId" help:"File ID or path (drive:/Folder/file)"`
+	RevisionID string `arg:"" name:"revisionId" help:"Revision ID"`
+	Off        bool   `name:"off" aliases:"unset" help:"Stop keeping the revision forever (it may be purged after 30 days)"`
+}
+
+func (c *DriveRevisionsKeepForeverCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	fileID, revisionID, err := driveRevisionArgs(c.FileID, c.RevisionID)
+	if err != nil {
+		return err
+	}
+	keep := !c.Off
+
+	if err := dryRunExit(ctx, flags, "drive.revisions.update", map[string]any{
+		"fileId":      fileID,
+		"revisionId":  revisionID,
+		"keepForever": keep,
+	}); err != nil {
+		return err
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	if fileID, err = resolveDriveRef(ctx, svc, fileID); err != nil {
+		return err
+	}
+	updated, err := svc.Revisions.Update(fileID, revisionID, &drive.Revision{
+		KeepForever:     keep,
+		ForceSendFields: []string{"KeepForever"},
+	}).
+		Fields(gapi.Field(driveRevisionFields)).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"fileId": fileID, "revision": updated})
+	}
+	u.Out().Printf("id\t%s", updated.Id)
+	u.Out().Printf("keepForever\t%t", updated.KeepForever)
+	return nil
+}
+
+type DriveRevisionsDeleteCmd struct {
+	FileID     string `arg:"" name:"fileId" help:"File ID or path (drive:/Folder/file)"`
+	RevisionID string `arg:"" name:"revisionId" help:"Revision ID"`
+}
+
+func (c *DriveRevisionsDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	fileID, revisionID, err := driveRevisionArgs(c.FileID, c.RevisionID)
+	if err != nil {
+		return err
+	}
+
+	if confirmErr := confirmDestructive(ctx, flags, fmt.Sprintf("delete revision %s of file %s", revisionID, fileID)); confirmErr != nil {
+		return confirmErr
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	if fileID, err = resolveDriveRef(ctx, svc, fileID); err != nil {
+		return err
+	}
+	if err := svc.Revisions.Delete(fileID, revisionID).Context(ctx).Do(); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"deleted":    true,
+			"fileId":     fileID,
+			"revisionId": revisionID,
+		})
+	}
+	u.Out().Printf("deleted\t%t", true)
+	u.Out().Printf("fileId\t%s", fileID)
+	u.Out().Printf("revisionId\t%s", revisionID)
+	return nil
+}
+
+type DriveRevisionsRestoreCmd struct {
+	FileID      string `arg:"" name:"fileId" help:"File ID or path (drive:/Folder/file)"`
+	RevisionID  string `arg:"" name:"revisionId" help:"Revision ID"`
+	Name        string `name:"name" help:"Name of the restored copy (Google Docs files only)"`
+	KeepForever bool   `name:"keep-revision-forever" help:"Keep the restored head revision forever (binary files only)"`
+}
+
+// Run restores binary files by re-uploading the revision through the
+// --replace path of drive upload, so the file ID and sharing stay intact.
+// Google Docs revisions cannot become the head revision through the API;
+// they are exported and imported as a new file next to the original.
+func (c *DriveRevisionsRestoreCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	fileID, revisionID, err := driveRevisionArgs(c.FileID, c.RevisionID)
+	if err != nil {
+		return err
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	if fileID, err = resolveDriveRef(ctx, svc, fileID); err != nil {
+		return err
+	}
+	file, err := svc.Files.Get(fileID).
+		SupportsAllDrives(true).
+		Fields("id, name, mimeType, parents").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+	native := strings.HasPrefix(file.MimeType, "application/vnd.google-apps.")
+	importMime := ""
+	if native {
+		importMime = driveRevisionImportMimeType(file.MimeType)
+		if importMime == "" {
+			return usagef("cannot restore revisions of %s files", driveType(file.MimeType))
+		}
+	}
+
+	name := strings.TrimSpace(c.Name)
+	if !native && name != "" {
+		return usage("--name only applies to Google Docs files")
+	}
+	if native && name == "" {
+		name = fmt.Sprintf("%s (restored %s)", file.Name, revisionID)
+	}
+	if err := dryRunExit(ctx, flags, "drive.revisions.restore", map[string]any{
+		"fileId":     fileID,
+		"revisionId": revisionID,
+		"copy":       native,
+		"name":       name,
+	}); err != nil {
+		return err
+	}
+
+	rev, err := getDriveRevision(ctx, svc, fileID, revisionID)
+	if err != nil {
+		return err
+	}
+
+	if !native {
+		return restoreDriveBinaryRevision(ctx, flags, svc, file, rev, c.KeepForever)
+	}
+
+	body, _, err := openDriveRevisionExport(ctx, account, rev, importMime)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	created, err := svc.Files.Create(&drive.File{Name: name, MimeType: file.MimeType, Parents: file.Parents}).
+		SupportsAllDrives(true).
+		Media(body, gapi.ContentType(importMime)).
+		Fields("id, name, mimeType, webViewLink").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			strFile:        created,
+			"restoredFrom": map[string]string{"fileId": fileID, "revisionId": revisionID},
+		})
+	}
+	u.Out().Printf("id\t%s", created.Id)
+	u.Out().Printf("name\t%s", created.Name)
+	if created.WebViewLink != "" {
+		u.Out().Printf("link\t%s", created.WebViewLink)
+	}
+	return nil
+}
+
+func restoreDriveBinaryRevision(ctx context.Context, flags *RootFlags, svc *drive.Service, file *drive.File, rev *drive.Revision, keepForever bool) error {
+	resp, err := driveRevisionDownload(ctx, svc, file.Id, rev.Id)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("download revision failed: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	tmp, err := os.CreateTemp("", "gog-revision-*"+filepath.Ext(file.Name))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, resp.Body); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	mimeType := rev.MimeType
+	if mimeType == "" {
+		mimeType = file.MimeType
+	}
+	upload := &DriveUploadCmd{
+		LocalPath:           tmp.Name(),
+		ReplaceFileID:       file.Id,
+		MimeType:            mimeType,
+		KeepRevisionForever: keepForever,
+	}
+	return upload.Run(ctx, flags)
+}
+
+func driveRevisionArgs(fileID, revisionID string) (string, string, error) {
+	fileID = normalizeGoogleID(strings.TrimSpace(fileID))
+	revisionID = strings.TrimSpace(revisionID)
+	if fileID == "" {
+		return "", "", usage("empty fileId")
+	}
+	if revisionID == "" {
+		return "", "", usage("empty revisionId")
+	}
+	return fileID, revisionID, nil
+}
+
+func getDriveRevision(ctx context.Context, svc *drive.Service, fileID, revisionID string) (*drive.Revision, error) {
+	return svc.Revisions.Get(fileID, revisionID).
+		Fields(gapi.Field(driveRevisionFields)).
+		Context(ctx).
+		Do()
+}
+
+func driveRevisionAuthor(r *drive.Revision) string {
+	if r.LastModifyingUser == nil {
+		return ""
+	}
+	if r.LastModifyingUser.EmailAddress != "" {
+		return r.LastModifyingUser.EmailAddress
+	}
+	return r.LastModifyingUser.DisplayName
+}
+
+// driveRevisionImportMimeType is the Office format a native revision is
+// exported to when it is restored, chosen so Drive converts it back losslessly.
+func driveRevisionImportMimeType(nativeMimeType string) string {
+	switch nativeMimeType {
+	case driveMimeGoogleDoc:
+		return mimeDocx
+	case driveMimeGoogleSheet:
+		return mimeXlsx
+	case driveMimeGoogleSlides:
+		return mimePptx
+	default:
+		return ""
+	}
+}
+
+// openDriveRevisionContent streams a revision. Binary revisions download
+// directly; Google Docs revisions go through their export links.
+func openDriveRevisionContent(ctx context.Context, svc *drive.Service, account string, file *drive.File, rev *drive.Revision, format string) (io.ReadCloser, string, error) {
+	if strings.HasPrefix(file.MimeType, "application/vnd.google-apps.") {
+		exportMime, err := driveExportMimeTypeForFormat(file.MimeType, format)
+		if err != nil {
+			return nil, "", err
+		}
+		return openDriveRevisionExport(ctx, account, rev, exportMime)
+	}
+	resp, err := driveRevisionDownload(ctx, svc, file.Id, rev.Id)
+	if err != nil {
+		return nil, "", err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, "", fmt.Errorf("download failed: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return resp.Body, "", nil
+}
+
+func openDriveRevisionExport(ctx context.Context, account string, rev *drive.Revision, exportMime string) (io.ReadCloser, string, error) {
+	link := rev.ExportLinks[exportMime]
+	if link == "" {
+		return nil, "", fmt.Errorf("revision %s cannot be exported as %s", rev.Id, exportMime)
+	}
+	client, err := newDriveHTTPClient(ctx, account)
+	if err != nil {
+		return nil, "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, http.NoBody)
+	if err != nil {
+		return nil, "", err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, "", fmt.Errorf("export failed: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return resp.Body, driveExportExtension(exportMime), nil
+}
+
+var driveRevisionDownload = func(ctx context.Context, svc *drive.Service, fileID, revisionID string) (*http.Response, error) {
+	return svc.Revisions.Get(fileID, revisionID).Context(ctx).Download()
+}
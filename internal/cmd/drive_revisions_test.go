@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+type driveRevisionsTestUpload struct {
+	Method  string
+	Path    string
+	Meta    map[string]any
+	Content string
+}
+
+// driveRevisionsTestServer serves a binary file "bin" and a Google Doc "doc",
+// each with revision "r1".
+func driveRevisionsTestServer(t *testing.T) func() []driveRevisionsTestUpload {
+	t.Helper()
+	var mu sync.Mutex
+	var uploads []driveRevisionsTestUpload
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/files/bin/revisions":
+			_ = json.NewEncoder(w).Encode(map[string]any{"revisions": []map[string]any{
+				{"id": "r1", "modifiedTime": "2026-01-01T00:00:00Z", "size": "3", "lastModifyingUser": map[string]any{"emailAddress": "x@y.com"}},
+				{"id": "r2", "modifiedTime": "2026-02-01T00:00:00Z", "size": "4", "keepForever": true},
+			}})
+		case r.Method == http.MethodGet && r.URL.Path == "/files/bin/revisions/r1" && r.URL.Query().Get("alt") == "media":
+			_, _ = io.WriteString(w, "old")
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/files/") && strings.HasSuffix(r.URL.Path, "/revisions/r1"):
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"id": "r1", "mimeType": "application/octet-stream",
+				"exportLinks": map[string]string{mimeDocx: srv.URL + "/export/docx", mimePDF: srv.URL + "/export/pdf"},
+			})
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/export/"):
+			_, _ = io.WriteString(w, "export-"+strings.TrimPrefix(r.URL.Path, "/export/"))
+		case r.Method == http.MethodGet && r.URL.Path == "/files/bin":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "bin", "name": "data.bin", "mimeType": "application/octet-stream"})
+		case r.Method == http.MethodGet && r.URL.Path == "/files/doc":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "doc", "name": "Plan", "mimeType": driveMimeGoogleDoc, "parents": []string{"p1"}})
+		case strings.HasPrefix(r.URL.Path, "/upload/"):
+			up := driveRevisionsTestUpload{Method: r.Method, Path: r.URL.Path}
+			if _, params, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err == nil {
+				mr := multipart.NewReader(r.Body, params["boundary"])
+				if part, perr := mr.NextPart(); perr == nil {
+					_ = json.NewDecoder(part).Decode(&up.Meta)
+				}
+				if part, perr := mr.NextPart(); perr == nil {
+					b, _ := io.ReadAll(part)
+					up.Content = string(b)
+				}
+			}
+			mu.Lock()
+			uploads = append(uploads, up)
+			mu.Unlock()
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "new", "name": up.Meta["name"]})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	origNew, origClient := newDriveService, newDriveHTTPClient
+	t.Cleanup(func() { newDriveService, newDriveHTTPClient = origNew, origClient })
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+	newDriveHTTPClient = func(context.Context, string) (*http.Client, error) { return srv.Client(), nil }
+	return func() []driveRevisionsTestUpload {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]driveRevisionsTestUpload(nil), uploads...)
+	}
+}
+
+func TestDriveRevisions_ListAndDownload(t *testing.T) {
+	_ = driveRevisionsTestServer(t)
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--account", "a@b.com", "drive", "revisions", "bin"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	if !strings.Contains(out, "r1") || !strings.Contains(out, "x@y.com") || !strings.Contains(out, "true") {
+		t.Fatalf("unexpected list output: %q", out)
+	}
+
+	dir := t.TempDir()
+	_ = captureStdout(t, func() {
+		if err := Execute([]string{"--account", "a@b.com", "drive", "revisions", "download", "bin", "r1", "--out", filepath.Join(dir, "old.bin")}); err != nil {
+			t.Fatalf("download: %v", err)
+		}
+		if err := Execute([]string{"--account", "a@b.com", "drive", "revisions", "download", "doc", "r1", "--out", filepath.Join(dir, "plan")}); err != nil {
+			t.Fatalf("download native: %v", err)
+		}
+	})
+	for name, want := range map[string]string{"old.bin": "old", "plan.pdf": "export-pdf"} {
+		if b, err := os.ReadFile(filepath.Join(dir, name)); err != nil || string(b) != want {
+			t.Fatalf("%s: %q %v", name, b, err)
+		}
+	}
+}
+
+func TestDriveRevisions_Restore(t *testing.T) {
+	uploads := driveRevisionsTestServer(t)
+
+	_ = captureStdout(t, func() {
+		if err := Execute([]string{"--account", "a@b.com", "drive", "revisions", "restore", "bin", "r1"}); err != nil {
+			t.Fatalf("restore binary: %v", err)
+		}
+		if err := Execute([]string{"--account", "a@b.com", "drive", "revisions", "restore", "doc", "r1"}); err != nil {
+			t.Fatalf("restore native: %v", err)
+		}
+	})
+	got := uploads()
+	if len(got) != 2 {
+		t.Fatalf("expected two uploads, got %#v", got)
+	}
+	if got[0].Method != http.MethodPatch || got[0].Path != "/upload/drive/v3/files/bin" || got[0].Content != "old" {
+		t.Fatalf("binary restore should replace content in place: %#v", got[0])
+	}
+	native := got[1]
+	if native.Method != http.MethodPost || native.Content != "export-docx" || native.Meta["mimeType"] != driveMimeGoogleDoc || native.Meta["name"] != "Plan (restored r1)" {
+		t.Fatalf("native restore should import the docx export as a copy: %#v", native)
+	}
+	if parents, _ := native.Meta["parents"].([]any); len(parents) != 1 || parents[0] != "p1" {
+		t.Fatalf("restored copy should sit next to the original: %#v", native.Meta)
+	}
+}
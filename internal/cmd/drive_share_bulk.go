@@ -0,0 +1,557 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+	gapi "google.golang.org/api/googleapi"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/timeparse"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type driveShareResult struct {
+	Row          int    `json:"row,omitempty"`
+	FileID       string `json:"fileId"`
+	Path         string `json:"path,omitempty"`
+	Principal    string `json:"principal,omitempty"`
+	Role         string `json:"role,omitempty"`
+	PermissionID string `json:"permissionId,omitempty"`
+	Status       string `json:"status,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// parseDriveShareExpires accepts a duration from now (30d, 2w, 12h) or an
+// absolute date/time.
+func parseDriveShareExpires(raw string, now time.Time) (time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	expr := strings.ToLower(raw)
+	var d time.Duration
+	var err error
+	switch {
+	case strings.HasSuffix(expr, "d"), strings.HasSuffix(expr, "w"):
+		unit := 24 * time.Hour
+		if strings.HasSuffix(expr, "w") {
+			unit *= 7
+		}
+		var n int
+		n, err = strconv.Atoi(expr[:len(expr)-1])
+		d = time.Duration(n) * unit
+	default:
+		d, err = time.ParseDuration(expr)
+	}
+	if err == nil {
+		if d <= 0 {
+			return time.Time{}, usagef("invalid --expires %q (must be in the future)", expr)
+		}
+		return now.Add(d), nil
+	}
+	parsed, perr := timeparse.ParseDateTimeOrDate(raw, time.Local)
+	if perr != nil {
+		return time.Time{}, usagef("invalid --expires %q (e.g. 30d, 2w, 12h or 2026-12-31)", expr)
+	}
+	if !parsed.Time.After(now) {
+		return time.Time{}, usagef("invalid --expires %q (must be in the future)", expr)
+	}
+	return parsed.Time, nil
+}
+
+func createDrivePermission(ctx context.Context, svc *drive.Service, fileID string, perm *drive.Permission) (*drive.Permission, error) {
+	return svc.Permissions.Create(fileID, perm).
+		SupportsAllDrives(true).
+		SendNotificationEmail(false).
+		Fields("id, type, role, emailAddress, domain, allowFileDiscovery, expirationTime").
+		Context(ctx).
+		Do()
+}
+
+// listDriveDescendants lists every file and folder below folderID with its
+// path relative to the folder. Shortcuts are returned but not followed.
+func listDriveDescendants(ctx context.Context, svc *drive.Service, folderID string) ([]driveTreeFile, error) {
+	type pending struct{ id, dir string }
+	var out []driveTreeFile
+	queue := []pending{{id: folderID}}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		children, err := collectAllPages("", func(pageToken string) ([]*drive.File, string, error) {
+			call := svc.Files.List().
+				Q(buildDriveListQuery(cur.id, "")).
+				PageSize(1000).
+				SupportsAllDrives(true).
+				IncludeItemsFromAllDrives(true).
+				Fields("nextPageToken, files(id, name, mimeType, ownedByMe)").
+				Context(ctx)
+			if pageToken != "" {
+				call = call.PageToken(pageToken)
+			}
+			resp, err := call.Do()
+			if err != nil {
+				return nil, "", err
+			}
+			return resp.Files, resp.NextPageToken, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		sort.SliceStable(children, func(i, j int) bool { return children[i].Name < children[j].Name })
+		for _, f := range children {
+			rel := path.Join(cur.dir, f.Name)
+			out = append(out, driveTreeFile{Path: rel, File: f})
+			if f.MimeType == driveMimeFolder {
+				queue = append(queue, pending{id: f.Id, dir: rel})
+			}
+		}
+	}
+	return out, nil
+}
+
+// shareDriveDescendants grants perm on each listed item, carrying on past
+// individual failures.
+func shareDriveDescendants(ctx context.Context, svc *drive.Service, items []driveTreeFile, perm *drive.Permission) ([]driveShareResult, int) {
+	results := make([]driveShareResult, 0, len(items))
+	failed := 0
+	for _, it := range items {
+		r := driveShareResult{FileID: it.File.Id, Path: it.Path}
+		created, err := createDrivePermission(ctx, svc, it.File.Id, perm)
+		if err != nil {
+			r.Error = err.Error()
+			failed++
+		} else {
+			r.PermissionID = created.Id
+		}
+		results = append(results, r)
+	}
+	return results, failed
+}
+
+// unshareDriveDescendants removes permissionID from each listed item. A
+// principal keeps the same permission ID on every file, so the ID read from
+// the folder also names its direct grants below it; items without that grant
+// are reported as skipped.
+func unshareDriveDescendants(ctx context.Context, svc *drive.Service, items []driveTreeFile, permissionID string) ([]driveShareResult, int, int) {
+	results := make([]driveShareResult, 0, len(items))
+	skipped, failed := 0, 0
+	for _, it := range items {
+		r := driveShareResult{FileID: it.File.Id, Path: it.Path, PermissionID: permissionID, Status: "removed"}
+		err := svc.Permissions.Delete(it.File.Id, permissionID).SupportsAllDrives(true).Context(ctx).Do()
+		switch {
+		case err == nil:
+		case isNotFoundAPIError(err):
+			r.Status = "skipped"
+			skipped++
+		default:
+			r.Status = ""
+			r.Error = err.Error()
+			failed++
+		}
+		results = append(results, r)
+	}
+	return results, skipped, failed
+}
+
+// runCSV applies one grant per CSV row. The header names the columns: file is
+// required; email, domain or to pick the target as the matching flags do;
+// role and expires are optional.
+func (c *DriveShareCmd) runCSV(ctx context.Context, flags *RootFlags, account string) error {
+	u := ui.FromContext(ctx)
+	csvPath, err := config.ExpandPath(strings.TrimSpace(c.FromCSV))
+	if err != nil {
+		return err
+	}
+	grants, err := readDriveShareCSV(csvPath, c.Role)
+	if err != nil {
+		return err
+	}
+	if len(grants) == 0 {
+		return usagef("%s has no grants", csvPath)
+	}
+
+	now := time.Now()
+	results := make([]driveShareResult, 0, len(grants))
+	perms := make([]*drive.Permission, len(grants))
+	invalid := 0
+	for i, g := range grants {
+		g.cmd.Discoverable = c.Discoverable
+		if g.cmd.Expires == "" && driveShareCSVUserRow(g.cmd) {
+			g.cmd.Expires = strings.TrimSpace(c.Expires)
+		}
+		perm, permErr := g.cmd.permission(now)
+		r := driveShareResult{Row: g.row, FileID: g.cmd.FileID, Principal: driveShareCSVPrincipal(g.cmd), Role: g.cmd.Role}
+		if permErr != nil {
+			r.Error = permErr.Error()
+			invalid++
+		}
+		perms[i] = perm
+		results = append(results, r)
+	}
+	if invalid > 0 {
+		for _, r := range results {
+			if r.Error != "" {
+				u.Err().Printf("row %d: %s", r.Row, r.Error)
+			}
+		}
+		return usagef("%d of %d rows in %s are invalid", invalid, len(grants), csvPath)
+	}
+
+	// Resolve drive:/ and shared: paths up front so the dry-run plan shows
+	// the IDs that will be shared and a bad path stops the run before any
+	// grant is made.
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	unresolved := 0
+	for i := range results {
+		r := &results[i]
+		fileID, resolveErr := resolveDriveRef(ctx, svc, r.FileID)
+		if resolveErr != nil {
+			u.Err().Printf("row %d: %s", r.Row, resolveErr)
+			unresolved++
+			continue
+		}
+		r.FileID = fileID
+	}
+	if unresolved > 0 {
+		return usagef("%d of %d rows in %s name files that could not be resolved", unresolved, len(grants), csvPath)
+	}
+
+	if err := dryRunExit(ctx, flags, "drive.share.csv", map[string]any{"grants": results, "recursive": c.Recursive}); err != nil {
+		return err
+	}
+	if c.Recursive {
+		if err := confirmDestructive(ctx, flags, fmt.Sprintf("apply %d grants to the listed files and everything below them", len(results))); err != nil {
+			return err
+		}
+	}
+
+	failed := 0
+	for i := range results {
+		r := &results[i]
+		created, err := createDrivePermission(ctx, svc, r.FileID, perms[i])
+		if err == nil {
+			r.PermissionID = created.Id
+			r.Status = "shared"
+		}
+		if err == nil && c.Recursive {
+			var items []driveTreeFile
+			if items, err = listDriveDescendants(ctx, svc, r.FileID); err == nil {
+				below, belowFailed := shareDriveDescendants(ctx, svc, items, perms[i])
+				switch {
+				case belowFailed > 0:
+					err = fmt.Errorf("%d of %d items below could not be shared", belowFailed, len(below))
+				case len(below) > 0:
+					r.Status = fmt.Sprintf("shared (+%d below)", len(below))
+				}
+			}
+		}
+		if err != nil {
+			r.Error = err.Error()
+			r.Status = ""
+			failed++
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"grants": results, "failed": failed}); err != nil {
+			return err
+		}
+	} else {
+		w, flush := tableWriter(ctx)
+		fmt.Fprintln(w, "ROW\tFILE\tPRINCIPAL\tROLE\tSTATUS")
+		for _, r := range results {
+			status := r.Status
+			if r.Error != "" {
+				status = r.Error
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", r.Row, r.FileID, r.Principal, r.Role, sanitizeTab(status))
+		}
+		flush()
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d grants failed", failed, len(results))
+	}
+	return nil
+}
+
+// driveShareCSVUserRow reports whether a CSV row grants to a user, the only
+// target --expires applies to.
+func driveShareCSVUserRow(c *DriveShareCmd) bool {
+	to := strings.TrimSpace(c.To)
+	return to == driveShareToUser || (to == "" && strings.TrimSpace(c.Email) != "" && strings.TrimSpace(c.Domain) == "")
+}
+
+type driveShareCSVGrant struct {
+	row int
+	cmd *DriveShareCmd
+}
+
+func readDriveShareCSV(csvPath, defaultRole string) ([]driveShareCSVGrant, error) {
+	f, err := os.Open(csvPath) //nolint:gosec // user-provided path
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.TrimLeadingSpace = true
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", csvPath, err)
+	}
+	cols := map[string]int{}
+	for i, h := range header {
+		name := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+		switch name {
+		case "file", "email", "domain", "to", "role", "expires":
+			cols[name] = i
+		default:
+			return nil, usagef("unknown column %q in %s (expected file, email, domain, to, role, expires)", h, csvPath)
+		}
+	}
+	if _, ok := cols["file"]; !ok {
+		return nil, usagef("%s needs a file column", csvPath)
+	}
+
+	var grants []driveShareCSVGrant
+	for line := 2; ; line++ {
+		rec, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", csvPath, err)
+		}
+		get := func(name string) string {
+			if i, ok := cols[name]; ok && i < len(rec) {
+				return strings.TrimSpace(rec[i])
+			}
+			return ""
+		}
+		if strings.Join(rec, "") == "" {
+			continue
+		}
+		role := get("role")
+		if role == "" {
+			role = defaultRole
+		}
+		grants = append(grants, driveShareCSVGrant{
+			row: line,
+			cmd: &DriveShareCmd{
+				FileID:  get("file"),
+				To:      get("to"),
+				Email:   get("email"),
+				Domain:  get("domain"),
+				Role:    role,
+				Expires: get("expires"),
+			},
+		})
+	}
+	return grants, nil
+}
+
+func driveShareCSVPrincipal(c *DriveShareCmd) string {
+	switch {
+	case c.Email != "":
+		return c.Email
+	case c.Domain != "":
+		return c.Domain
+	default:
+		return c.To
+	}
+}
+
+type DriveTransferOwnershipCmd struct {
+	FileID    string `arg:"" name:"fileId" help:"File or folder ID or path"`
+	To        string `name:"to" required:"" help:"Email of the new owner"`
+	Recursive bool   `name:"recursive" short:"r" help:"Also transfer everything you own below a folder"`
+}
+
+func (c *DriveTransferOwnershipCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	fileID := strings.TrimSpace(c.FileID)
+	if fileID == "" {
+		return usage("empty fileId")
+	}
+	to := strings.TrimSpace(c.To)
+	if !strings.Contains(to, "@") {
+		return usagef("invalid --to %q (expected an email address)", c.To)
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	if fileID, err = resolveDriveRef(ctx, svc, fileID); err != nil {
+		return err
+	}
+	root, err := svc.Files.Get(fileID).
+		SupportsAllDrives(true).
+		Fields("id, name, mimeType, ownedByMe, driveId").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+	if root.DriveId != "" {
+		return usage("items in shared drives belong to the drive; move them instead of transferring ownership")
+	}
+
+	targets := []driveTreeFile{{Path: root.Name, File: root}}
+	if c.Recursive && root.MimeType == driveMimeFolder {
+		below, err := listDriveDescendants(ctx, svc, root.Id)
+		if err != nil {
+			return err
+		}
+		for _, it := range below {
+			it.Path = path.Join(root.Name, it.Path)
+			targets = append(targets, it)
+		}
+	}
+
+	results := make([]driveShareResult, 0, len(targets))
+	pending := make([]driveTreeFile, 0, len(targets))
+	for _, t := range targets {
+		if !t.File.OwnedByMe {
+			results = append(results, driveShareResult{FileID: t.File.Id, Path: t.Path, Status: "skipped (not owned by you)"})
+			continue
+		}
+		pending = append(pending, t)
+	}
+	if len(pending) == 0 {
+		return usagef("you do not own %s", root.Name)
+	}
+
+	if err := dryRunExit(ctx, flags, "drive.transfer_ownership", map[string]any{"to": to, "fileIds": driveTreeFileIDs(pending)}); err != nil {
+		return err
+	}
+	if err := confirmDestructive(ctx, flags, fmt.Sprintf("transfer ownership of %d items to %s", len(pending), to)); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, t := range pending {
+		r := driveShareResult{FileID: t.File.Id, Path: t.Path, Principal: to}
+		status, err := transferDriveOwnership(ctx, svc, t.File.Id, to)
+		if err != nil {
+			r.Error = err.Error()
+			failed++
+		}
+		r.Status = status
+		results = append(results, r)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"to": to, "items": results, "failed": failed}); err != nil {
+			return err
+		}
+	} else {
+		w, flush := tableWriter(ctx)
+		fmt.Fprintln(w, "ID\tPATH\tSTATUS")
+		for _, r := range results {
+			status := r.Status
+			if r.Error != "" {
+				status = r.Error
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", r.FileID, sanitizeTab(r.Path), sanitizeTab(status))
+		}
+		flush()
+		for _, r := range results {
+			if r.Status == driveOwnershipPending {
+				u.Err().Printf("%s must accept the pending ownership transfer in Drive", to)
+				break
+			}
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d ownership transfers failed", failed, len(pending))
+	}
+	return nil
+}
+
+const (
+	driveOwnershipTransferred = "transferred"
+	driveOwnershipPending     = "pending"
+)
+
+// transferDriveOwnership makes email the owner. Where the API requires the
+// recipient's consent (consumer accounts), it makes them the pending owner
+// instead; they then accept the transfer in Drive.
+func transferDriveOwnership(ctx context.Context, svc *drive.Service, fileID, email string) (string, error) {
+	_, err := svc.Permissions.Create(fileID, &drive.Permission{Type: "user", Role: "owner", EmailAddress: email}).
+		TransferOwnership(true).
+		SupportsAllDrives(true).
+		Fields("id").
+		Context(ctx).
+		Do()
+	if err == nil {
+		return driveOwnershipTransferred, nil
+	}
+	if !isDriveConsentRequired(err) {
+		return "", err
+	}
+
+	perms, err := listDriveFilePermissions(ctx, svc, fileID)
+	if err != nil {
+		return "", err
+	}
+	for _, p := range perms {
+		if p.Type == "user" && strings.EqualFold(p.EmailAddress, email) {
+			_, err = svc.Permissions.Update(fileID, p.Id, &drive.Permission{Role: drivePermRoleWriter, PendingOwner: true}).
+				SupportsAllDrives(true).
+				Fields("id").
+				Context(ctx).
+				Do()
+			if err != nil {
+				return "", err
+			}
+			return driveOwnershipPending, nil
+		}
+	}
+	_, err = svc.Permissions.Create(fileID, &drive.Permission{Type: "user", Role: drivePermRoleWriter, EmailAddress: email, PendingOwner: true}).
+		SupportsAllDrives(true).
+		Fields("id").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return "", err
+	}
+	return driveOwnershipPending, nil
+}
+
+func isDriveConsentRequired(err error) bool {
+	var apiErr *gapi.Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	for _, e := range apiErr.Errors {
+		if e.Reason == "consentRequiredForOwnershipTransfer" {
+			return true
+		}
+	}
+	return strings.Contains(apiErr.Message, "pendingOwner")
+}
+
+func driveTreeFileIDs(files []driveTreeFile) []string {
+	ids := make([]string, 0, len(files))
+	for _, f := range files {
+		ids = append(ids, f.File.Id)
+	}
+	return ids
+}
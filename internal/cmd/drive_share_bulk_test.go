@@ -0,0 +1,299 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+func TestParseDriveShareExpires(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		in   string
+		want time.Time
+	}{
+		{"30d", now.Add(30 * 24 * time.Hour)},
+		{"2w", now.Add(14 * 24 * time.Hour)},
+		{"12h", now.Add(12 * time.Hour)},
+		{"2026-12-31T10:00:00Z", time.Date(2026, 12, 31, 10, 0, 0, 0, time.UTC)},
+	} {
+		got, err := parseDriveShareExpires(tc.in, now)
+		if err != nil || !got.Equal(tc.want) {
+			t.Fatalf("parseDriveShareExpires(%q) = %v, %v; want %v", tc.in, got, err, tc.want)
+		}
+	}
+	for _, in := range []string{"0d", "-1h", "soon", "2020-01-01T00:00:00Z"} {
+		if _, err := parseDriveShareExpires(in, now); err == nil {
+			t.Fatalf("expected error for %q", in)
+		}
+	}
+}
+
+// driveShareBulkServer serves a small tree (f0 -> a, sub -> b) and records
+// permission writes as "METHOD fileId body".
+func driveShareBulkServer(t *testing.T) func() []string {
+	t.Helper()
+	children := map[string][]map[string]any{
+		"f0":  {{"id": "a", "name": "a.txt", "ownedByMe": true}, {"id": "sub", "name": "Sub", "mimeType": driveMimeFolder, "ownedByMe": false}},
+		"sub": {{"id": "b", "name": "b.txt", "ownedByMe": true}},
+	}
+	var mu sync.Mutex
+	var calls []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		switch {
+		case r.URL.Path == "/files":
+			parent := strings.TrimSuffix(strings.TrimPrefix(r.URL.Query().Get("q"), "'"), "' in parents and trashed = false")
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": children[parent]})
+		case len(parts) == 3 && parts[2] == "permissions" && r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(map[string]any{"permissions": []any{}})
+		case len(parts) >= 3 && parts[2] == "permissions":
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if v, ok := body["expirationTime"].(string); ok && v != "" {
+				body["expirationTime"] = "set"
+			}
+			b, _ := json.Marshal(body)
+			call := r.Method + " " + parts[1] + " " + string(b)
+			if r.URL.Query().Get("transferOwnership") == "true" {
+				call += " transfer"
+			}
+			mu.Lock()
+			calls = append(calls, call)
+			mu.Unlock()
+			if r.Method == http.MethodDelete && parts[1] == "a" {
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": 404, "message": "Permission not found: p1."}})
+				return
+			}
+			if r.URL.Query().Get("transferOwnership") == "true" && parts[1] == "b" {
+				w.WriteHeader(http.StatusForbidden)
+				_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": 403, "message": "Consent is required to transfer ownership of a file to another user.", "errors": []map[string]any{{"reason": "consentRequiredForOwnershipTransfer"}}}})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "p-" + parts[1]})
+		case len(parts) == 2:
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": parts[1], "name": "Team", "mimeType": driveMimeFolder, "ownedByMe": true, "webViewLink": "https://drive/" + parts[1]})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+
+	return func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		out := append([]string(nil), calls...)
+		calls = nil
+		return out
+	}
+}
+
+func runDriveShareBulk(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	var runErr error
+	out := captureStdout(t, func() {
+		_ = captureStderr(t, func() {
+			runErr = Execute(append([]string{"--json", "--force", "--account", "a@b.com", "drive"}, args...))
+		})
+	})
+	return out, runErr
+}
+
+func TestDriveShare_RecursiveWithExpiry(t *testing.T) {
+	calls := driveShareBulkServer(t)
+
+	if _, err := runDriveShareBulk(t, "share", "f0", "--email", "x@y.com", "--role", "writer", "--expires", "30d", "--recursive"); err != nil {
+		t.Fatalf("share: %v", err)
+	}
+	perm := `{"emailAddress":"x@y.com","expirationTime":"set","role":"writer","type":"user"}`
+	want := []string{"POST f0 " + perm, "POST sub " + perm, "POST a " + perm, "POST b " + perm}
+	if got := calls(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected calls:\n%s", strings.Join(got, "\n"))
+	}
+
+	if _, err := runDriveShareBulk(t, "share", "f0", "--to", "anyone", "--expires", "30d"); err == nil || !strings.Contains(err.Error(), "--expires") {
+		t.Fatalf("expected --expires to be rejected for anyone, got %v", err)
+	}
+}
+
+func TestDriveShare_RecursiveDryRun(t *testing.T) {
+	calls := driveShareBulkServer(t)
+
+	out, err := runDriveShareBulk(t, "--dry-run", "share", "f0", "--email", "x@y.com", "--recursive")
+	if err != nil {
+		t.Fatalf("share --dry-run: %v", err)
+	}
+	var parsed struct {
+		Op      string `json:"op"`
+		Request struct {
+			FileIDs []string `json:"fileIds"`
+		} `json:"request"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil || parsed.Op != "drive.share.recursive" || strings.Join(parsed.Request.FileIDs, ",") != "sub,a,b" {
+		t.Fatalf("unexpected dry-run output %q: %v", out, err)
+	}
+	if got := calls(); len(got) != 0 {
+		t.Fatalf("dry run must not share anything: %v", got)
+	}
+}
+
+func TestDriveUnshare_Recursive(t *testing.T) {
+	calls := driveShareBulkServer(t)
+
+	out, err := runDriveShareBulk(t, "unshare", "f0", "p1", "--recursive")
+	if err != nil {
+		t.Fatalf("unshare: %v", err)
+	}
+	want := []string{"DELETE f0 null", "DELETE sub null", "DELETE a null", "DELETE b null"}
+	if got := calls(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected calls:\n%s", strings.Join(got, "\n"))
+	}
+	var parsed struct {
+		Descendants []driveShareResult `json:"descendants"`
+		Skipped     int                `json:"skipped"`
+		Failed      int                `json:"failed"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil || len(parsed.Descendants) != 3 || parsed.Skipped != 1 || parsed.Failed != 0 {
+		t.Fatalf("unexpected output %q: %v", out, err)
+	}
+	if parsed.Descendants[1].Path != "a.txt" || parsed.Descendants[1].Status != "skipped" {
+		t.Fatalf("expected a.txt without the grant to be skipped: %#v", parsed.Descendants)
+	}
+}
+
+func TestDriveShare_FromCSV(t *testing.T) {
+	calls := driveShareBulkServer(t)
+	csvPath := filepath.Join(t.TempDir(), "grants.csv")
+	content := "file,email,domain,role,expires\n" +
+		"a,x@y.com,,writer,\n" +
+		"b,,example.com,,\n" +
+		"\n"
+	if err := os.WriteFile(csvPath, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runDriveShareBulk(t, "share", "--from-csv", csvPath)
+	if err != nil {
+		t.Fatalf("share --from-csv: %v", err)
+	}
+	want := []string{
+		`POST a {"emailAddress":"x@y.com","role":"writer","type":"user"}`,
+		`POST b {"domain":"example.com","role":"reader","type":"domain"}`,
+	}
+	if got := calls(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected calls:\n%s", strings.Join(got, "\n"))
+	}
+	var parsed struct {
+		Grants []driveShareResult `json:"grants"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil || len(parsed.Grants) != 2 || parsed.Grants[1].Row != 3 {
+		t.Fatalf("unexpected output %q: %v", out, err)
+	}
+
+	// --expires is the default for user rows; links resolve to IDs before
+	// the dry-run plan is printed.
+	linked := filepath.Join(t.TempDir(), "linked.csv")
+	if err := os.WriteFile(linked, []byte("file,email,domain\nhttps://drive.google.com/file/d/a/view,x@y.com,\nb,,example.com\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	out, err = runDriveShareBulk(t, "--dry-run", "share", "--from-csv", linked, "--expires", "30d")
+	if err != nil {
+		t.Fatalf("share --from-csv --dry-run: %v", err)
+	}
+	var plan struct {
+		Request struct {
+			Grants []driveShareResult `json:"grants"`
+		} `json:"request"`
+	}
+	if err := json.Unmarshal([]byte(out), &plan); err != nil || len(plan.Request.Grants) != 2 || plan.Request.Grants[0].FileID != "a" {
+		t.Fatalf("dry run should list resolved IDs, got %q: %v", out, err)
+	}
+	if _, err := runDriveShareBulk(t, "share", "--from-csv", linked, "--expires", "30d"); err != nil {
+		t.Fatalf("share --from-csv --expires: %v", err)
+	}
+	want = []string{
+		`POST a {"emailAddress":"x@y.com","expirationTime":"set","role":"reader","type":"user"}`,
+		`POST b {"domain":"example.com","role":"reader","type":"domain"}`,
+	}
+	if got := calls(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected calls:\n%s", strings.Join(got, "\n"))
+	}
+
+	missing := filepath.Join(t.TempDir(), "missing.csv")
+	if err := os.WriteFile(missing, []byte("file,email\na,x@y.com\ndrive:/Nope/file.txt,x@y.com\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runDriveShareBulk(t, "share", "--from-csv", missing); err == nil || !strings.Contains(err.Error(), "could not be resolved") {
+		t.Fatalf("expected unresolved path error, got %v", err)
+	}
+	if got := calls(); len(got) != 0 {
+		t.Fatalf("an unresolved row must stop the run before sharing: %v", got)
+	}
+
+	bad := filepath.Join(t.TempDir(), "bad.csv")
+	if err := os.WriteFile(bad, []byte("file,email\na,x@y.com\nb,\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runDriveShareBulk(t, "share", "--from-csv", bad); err == nil || !strings.Contains(err.Error(), "1 of 2 rows") {
+		t.Fatalf("expected invalid row error, got %v", err)
+	}
+	if got := calls(); len(got) != 0 {
+		t.Fatalf("invalid CSV must not share anything: %v", got)
+	}
+}
+
+func TestDriveTransferOwnership_RecursiveWithPendingOwner(t *testing.T) {
+	calls := driveShareBulkServer(t)
+
+	out, err := runDriveShareBulk(t, "transfer-ownership", "f0", "--to", "new@y.com", "--recursive")
+	if err != nil {
+		t.Fatalf("transfer-ownership: %v", err)
+	}
+	want := []string{
+		`POST f0 {"emailAddress":"new@y.com","role":"owner","type":"user"} transfer`,
+		`POST a {"emailAddress":"new@y.com","role":"owner","type":"user"} transfer`,
+		`POST b {"emailAddress":"new@y.com","role":"owner","type":"user"} transfer`,
+		`POST b {"emailAddress":"new@y.com","pendingOwner":true,"role":"writer","type":"user"}`,
+	}
+	if got := calls(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected calls:\n%s", strings.Join(got, "\n"))
+	}
+
+	var parsed struct {
+		Items []driveShareResult `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v", err)
+	}
+	status := map[string]string{}
+	for _, it := range parsed.Items {
+		status[it.FileID] = it.Status
+	}
+	if status["f0"] != driveOwnershipTransferred || status["b"] != driveOwnershipPending || !strings.HasPrefix(status["sub"], "skipped") {
+		t.Fatalf("unexpected statuses: %v", status)
+	}
+}
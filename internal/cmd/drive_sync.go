@@ -0,0 +1,537 @@
+package cmd
+
+import (
+	"context"
+	"crypto/md5" //nolint:gosec // Drive exposes md5Checksum; used for change detection only
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+	gapi "google.golang.org/api/googleapi"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const (
+	driveSyncPush   = "push"
+	driveSyncPull   = "pull"
+	driveSyncMirror = "mirror"
+
+	driveSyncUpload       = "upload"
+	driveSyncUpdate       = "update"
+	driveSyncDownload     = "download"
+	driveSyncDeleteRemote = "delete-remote"
+	driveSyncDeleteLocal  = "delete-local"
+
+	// driveSyncMtimeSlack absorbs filesystem and Drive timestamp rounding.
+	driveSyncMtimeSlack = time.Second
+)
+
+type DriveSyncCmd struct {
+	LocalDir    string   `arg:"" name:"localDir" help:"Local directory"`
+	Remote      string   `arg:"" name:"remote" help:"Drive folder: drive:FOLDER_ID, drive:/path, shared:<Drive>/path or a bare folder ID (drive:root for My Drive)"`
+	Direction   string   `name:"direction" help:"push (local -> Drive), pull (Drive -> local), mirror (both ways, newer wins)" enum:"push,pull,mirror" default:"push"`
+	Delete      bool     `name:"delete" help:"Delete destination files missing from the source (push: trash in Drive; pull: remove locally)"`
+	Exclude     []string `name:"exclude" help:"Glob matched against the relative path and the base name; can be repeated"`
+	Concurrency int      `name:"concurrency" help:"Parallel transfers" default:"4"`
+}
+
+// driveSyncEntry is one file on either side, keyed by its slash-separated
+// path relative to the sync root.
+type driveSyncEntry struct {
+	Path     string
+	Size     int64
+	ModTime  time.Time
+	Local    string // absolute local path
+	ID       string // Drive file ID
+	MimeType string
+	MD5      string
+	Native   bool // Google Docs/Sheets/Slides/Drawings; exported on pull
+}
+
+type driveSyncAction struct {
+	Action string `json:"action"`
+	Path   string `json:"path"`
+	FileID string `json:"fileId,omitempty"`
+	Size   int64  `json:"size,omitempty"`
+	Reason string `json:"reason"`
+	Error  string `json:"error,omitempty"`
+}
+
+func (c *DriveSyncCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	if c.Delete && c.Direction == driveSyncMirror {
+		return usage("--delete cannot be combined with --direction mirror (a missing file could be new or deleted)")
+	}
+	if c.Concurrency < 1 {
+		return usage("--concurrency must be at least 1")
+	}
+	localDir, err := config.ExpandPath(strings.TrimSpace(c.LocalDir))
+	if err != nil {
+		return err
+	}
+	if localDir == "" {
+		return usage("empty localDir")
+	}
+	if c.Direction == driveSyncPull {
+		if err = os.MkdirAll(localDir, 0o700); err != nil {
+			return err
+		}
+	}
+	if st, statErr := os.Stat(localDir); statErr != nil {
+		return statErr
+	} else if !st.IsDir() {
+		return usagef("%s is not a directory", localDir)
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	folderID, err := resolveDriveFolderRef(ctx, svc, c.Remote)
+	if err != nil {
+		return err
+	}
+	folder, err := svc.Files.Get(folderID).SupportsAllDrives(true).Fields("id, mimeType").Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	if folder.MimeType != driveMimeFolder {
+		return usagef("%s is not a Drive folder", folderID)
+	}
+	folderID = folder.Id
+
+	local, err := scanDriveSyncLocal(localDir, c.Exclude)
+	if err != nil {
+		return err
+	}
+	remote, folders, err := listDriveSyncRemote(ctx, svc, folderID, c.Exclude)
+	if err != nil {
+		return err
+	}
+
+	plan := planDriveSync(local, remote, c.Direction, c.Delete, fileMD5)
+	if dryRunErr := dryRunExit(ctx, flags, "drive.sync", map[string]any{
+		"direction": c.Direction,
+		"local":     localDir,
+		"folderId":  folderID,
+		"actions":   plan,
+	}); dryRunErr != nil {
+		return dryRunErr
+	}
+
+	deletes := 0
+	for _, a := range plan {
+		if a.Action == driveSyncDeleteLocal || a.Action == driveSyncDeleteRemote {
+			deletes++
+		}
+	}
+	if deletes > 0 {
+		if confirmErr := confirmDestructive(ctx, flags, fmt.Sprintf("delete %d files while syncing %s", deletes, localDir)); confirmErr != nil {
+			return confirmErr
+		}
+	}
+
+	s := &driveSyncer{svc: svc, root: localDir, folders: folders, local: local, remote: remote}
+	if err := s.ensureFolders(ctx, plan); err != nil {
+		return err
+	}
+	s.apply(ctx, plan, c.Concurrency)
+
+	failed := 0
+	for _, a := range plan {
+		if a.Error != "" {
+			failed++
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"direction": c.Direction,
+			"local":     localDir,
+			"folderId":  folderID,
+			"actions":   plan,
+			"failed":    failed,
+		}); err != nil {
+			return err
+		}
+	} else if len(plan) == 0 {
+		u.Err().Println("Already in sync")
+	} else {
+		w, flush := tableWriter(ctx)
+		fmt.Fprintln(w, "ACTION\tPATH\tSIZE\tRESULT")
+		for _, a := range plan {
+			result := "ok"
+			if a.Error != "" {
+				result = a.Error
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", a.Action, a.Path, formatDriveSize(a.Size), sanitizeTab(result))
+		}
+		flush()
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d sync actions failed", failed, len(plan))
+	}
+	return nil
+}
+
+func driveSyncExcluded(rel string, patterns []string) bool {
+	base := path.Base(rel)
+	for _, p := range patterns {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if ok, _ := path.Match(p, rel); ok {
+			return true
+		}
+		if ok, _ := path.Match(p, base); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func scanDriveSyncLocal(root string, exclude []string) (map[string]driveSyncEntry, error) {
+	out := map[string]driveSyncEntry{}
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == root {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if driveSyncExcluded(rel, exclude) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		out[rel] = driveSyncEntry{Path: rel, Size: info.Size(), ModTime: info.ModTime(), Local: p}
+		return nil
+	})
+	return out, err
+}
+
+// listDriveSyncRemote walks the folder tree breadth-first. Native Google
+// files are keyed by their export name (e.g. "Report" -> "Report.pdf").
+// The returned folder map resolves relative directories to folder IDs.
+func listDriveSyncRemote(ctx context.Context, svc *drive.Service, rootID string, exclude []string) (map[string]driveSyncEntry, map[string]string, error) {
+	u := ui.FromContext(ctx)
+	files := map[string]driveSyncEntry{}
+	folders := map[string]string{"": rootID}
+	queue := []string{""}
+	for len(queue) > 0 {
+		dir := queue[0]
+		queue = queue[1:]
+		children, err := listDriveChildren(ctx, svc, folders[dir])
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, f := range children {
+			name := driveSafeName(f.Name)
+			if name == "" {
+				continue
+			}
+			native := isDriveExportable(f.MimeType)
+			if native {
+				name = driveSyncExportName(name, f.MimeType)
+			}
+			rel := path.Join(dir, name)
+			if driveSyncExcluded(rel, exclude) {
+				continue
+			}
+			if f.MimeType == driveMimeFolder {
+				if _, dup := folders[rel]; !dup {
+					folders[rel] = f.Id
+					queue = append(queue, rel)
+				}
+				continue
+			}
+			if strings.HasPrefix(f.MimeType, "application/vnd.google-apps.") && !native {
+				continue
+			}
+			if prev, dup := files[rel]; dup {
+				u.Err().Printf("Warning: duplicate Drive name %s (%s, %s); syncing %s", rel, prev.ID, f.Id, prev.ID)
+				continue
+			}
+			mod, _ := time.Parse(time.RFC3339, f.ModifiedTime)
+			files[rel] = driveSyncEntry{
+				Path:     rel,
+				Size:     f.Size,
+				ModTime:  mod,
+				ID:       f.Id,
+				MimeType: f.MimeType,
+				MD5:      f.Md5Checksum,
+				Native:   native,
+			}
+		}
+	}
+	return files, folders, nil
+}
+
+func listDriveChildren(ctx context.Context, svc *drive.Service, folderID string) ([]*drive.File, error) {
+	return collectAllPages("", func(pageToken string) ([]*drive.File, string, error) {
+		call := svc.Files.List().
+			Q(buildDriveListQuery(folderID, "")).
+			PageSize(1000).
+			SupportsAllDrives(true).
+			IncludeItemsFromAllDrives(true).
+			Fields("nextPageToken, files(id, name, mimeType, size, modifiedTime, md5Checksum, shortcutDetails(targetId, targetMimeType))").
+			Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Files, resp.NextPageToken, nil
+	})
+}
+
+// isDriveExportable reports whether a Google-native file can be exported.
+func isDriveExportable(mimeType string) bool {
+	switch mimeType {
+	case driveMimeGoogleDoc, driveMimeGoogleSheet, driveMimeGoogleSlides, driveMimeGoogleDrawing:
+		return true
+	default:
+		return false
+	}
+}
+
+// driveSafeName makes a Drive name usable as a single path element; it
+// returns "" for names that cannot be used.
+func driveSafeName(name string) string {
+	name = strings.ReplaceAll(name, "/", "_")
+	if name == "." || name == ".." {
+		return ""
+	}
+	return name
+}
+
+// driveSyncExportName appends the default export extension unless the
+// Drive name already ends with it.
+func driveSyncExportName(name, mimeType string) string {
+	ext := driveExportExtension(driveExportMimeType(mimeType))
+	if strings.EqualFold(filepath.Ext(name), ext) {
+		return name
+	}
+	return name + ext
+}
+
+// planDriveSync compares both trees and returns the actions needed, sorted
+// by path. md5 computes a local file's checksum; it is only called when
+// sizes match and Drive reports a checksum.
+func planDriveSync(local, remote map[string]driveSyncEntry, direction string, del bool, md5 func(string) (string, error)) []driveSyncAction {
+	var plan []driveSyncAction
+	for rel, l := range local {
+		r, ok := remote[rel]
+		switch {
+		case !ok:
+			if direction == driveSyncPull {
+				if del {
+					plan = append(plan, driveSyncAction{Action: driveSyncDeleteLocal, Path: rel, Size: l.Size, Reason: "missing in Drive"})
+				}
+				continue
+			}
+			plan = append(plan, driveSyncAction{Action: driveSyncUpload, Path: rel, Size: l.Size, Reason: "new"})
+		case r.Native:
+			// Exports are never pushed back over the native file.
+			if direction != driveSyncPush && r.ModTime.After(l.ModTime.Add(driveSyncMtimeSlack)) {
+				plan = append(plan, driveSyncAction{Action: driveSyncDownload, Path: rel, FileID: r.ID, Reason: "newer in Drive"})
+			}
+		case !driveSyncSame(l, r, md5):
+			pushIt := direction == driveSyncPush ||
+				(direction == driveSyncMirror && l.ModTime.After(r.ModTime))
+			if pushIt {
+				plan = append(plan, driveSyncAction{Action: driveSyncUpdate, Path: rel, FileID: r.ID, Size: l.Size, Reason: "changed"})
+			} else {
+				plan = append(plan, driveSyncAction{Action: driveSyncDownload, Path: rel, FileID: r.ID, Size: r.Size, Reason: "changed"})
+			}
+		}
+	}
+	for rel, r := range remote {
+		if _, ok := local[rel]; ok {
+			continue
+		}
+		if direction == driveSyncPush {
+			// Native files only exist locally as exports; never trash them.
+			if del && !r.Native {
+				plan = append(plan, driveSyncAction{Action: driveSyncDeleteRemote, Path: rel, FileID: r.ID, Size: r.Size, Reason: "missing locally"})
+			}
+			continue
+		}
+		plan = append(plan, driveSyncAction{Action: driveSyncDownload, Path: rel, FileID: r.ID, Size: r.Size, Reason: "new"})
+	}
+	sort.Slice(plan, func(i, j int) bool { return plan[i].Path < plan[j].Path })
+	return plan
+}
+
+func driveSyncSame(l, r driveSyncEntry, md5 func(string) (string, error)) bool {
+	if l.Size != r.Size {
+		return false
+	}
+	if r.MD5 != "" {
+		sum, err := md5(l.Local)
+		return err == nil && sum == r.MD5
+	}
+	d := l.ModTime.Sub(r.ModTime)
+	return d <= driveSyncMtimeSlack && d >= -driveSyncMtimeSlack
+}
+
+func fileMD5(p string) (string, error) {
+	f, err := os.Open(p) //nolint:gosec // path comes from walking the sync root
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := md5.New() //nolint:gosec // matches Drive's md5Checksum
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+type driveSyncer struct {
+	svc     *drive.Service
+	root    string
+	folders map[string]string
+	local   map[string]driveSyncEntry
+	remote  map[string]driveSyncEntry
+}
+
+// ensureFolders creates missing Drive folders for uploads up front, parents
+// first, so the parallel phase only transfers files.
+func (s *driveSyncer) ensureFolders(ctx context.Context, plan []driveSyncAction) error {
+	var dirs []string
+	seen := map[string]bool{}
+	for _, a := range plan {
+		if a.Action != driveSyncUpload {
+			continue
+		}
+		for dir := path.Dir(a.Path); dir != "." && !seen[dir]; dir = path.Dir(dir) {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+	sort.Strings(dirs)
+	for _, dir := range dirs {
+		if _, ok := s.folders[dir]; ok {
+			continue
+		}
+		parent := path.Dir(dir)
+		if parent == "." {
+			parent = ""
+		}
+		created, err := createDriveFolder(ctx, s.svc, path.Base(dir), s.folders[parent])
+		if err != nil {
+			return fmt.Errorf("create folder %s: %w", dir, err)
+		}
+		s.folders[dir] = created.Id
+	}
+	return nil
+}
+
+func (s *driveSyncer) apply(ctx context.Context, plan []driveSyncAction, concurrency int) {
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := range plan {
+		wg.Add(1)
+		go func(a *driveSyncAction) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				a.Error = ctx.Err().Error()
+				return
+			}
+			if err := s.applyOne(ctx, a); err != nil {
+				a.Error = err.Error()
+			}
+		}(&plan[i])
+	}
+	wg.Wait()
+}
+
+func (s *driveSyncer) applyOne(ctx context.Context, a *driveSyncAction) error {
+	switch a.Action {
+	case driveSyncUpload, driveSyncUpdate:
+		l := s.local[a.Path]
+		f, err := os.Open(l.Local)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		meta := &drive.File{ModifiedTime: l.ModTime.UTC().Format(time.RFC3339)}
+		media := gapi.ContentType(guessMimeType(l.Local))
+		var done *drive.File
+		if a.Action == driveSyncUpdate {
+			done, err = s.svc.Files.Update(a.FileID, meta).SupportsAllDrives(true).Media(f, media).Fields("id").Context(ctx).Do()
+		} else {
+			parent := path.Dir(a.Path)
+			if parent == "." {
+				parent = ""
+			}
+			meta.Name = path.Base(a.Path)
+			meta.Parents = []string{s.folders[parent]}
+			done, err = s.svc.Files.Create(meta).SupportsAllDrives(true).Media(f, media).Fields("id").Context(ctx).Do()
+		}
+		if err != nil {
+			return err
+		}
+		a.FileID = done.Id
+		return nil
+	case driveSyncDownload:
+		r := s.remote[a.Path]
+		dest := filepath.Join(s.root, filepath.FromSlash(a.Path))
+		if err := os.MkdirAll(filepath.Dir(dest), 0o700); err != nil {
+			return err
+		}
+		_, n, err := downloadDriveFile(ctx, s.svc, &drive.File{Id: r.ID, MimeType: r.MimeType}, dest, "")
+		if err != nil {
+			return err
+		}
+		a.Size = n
+		if !r.ModTime.IsZero() {
+			return os.Chtimes(dest, r.ModTime, r.ModTime)
+		}
+		return nil
+	case driveSyncDeleteLocal:
+		return os.Remove(s.local[a.Path].Local)
+	case driveSyncDeleteRemote:
+		_, err := setDriveTrashed(ctx, s.svc, a.FileID, true)
+		return err
+	default:
+		return fmt.Errorf("unknown sync action %q", a.Action)
+	}
+}
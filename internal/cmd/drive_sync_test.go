@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+func TestPlanDriveSync(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	local := map[string]driveSyncEntry{
+		"same.txt":    {Path: "same.txt", Size: 5, ModTime: t0, Local: "same"},
+		"changed.txt": {Path: "changed.txt", Size: 5, ModTime: t0.Add(time.Hour), Local: "changed"},
+		"local.txt":   {Path: "local.txt", Size: 1, ModTime: t0},
+		"Report.pdf":  {Path: "Report.pdf", Size: 9, ModTime: t0},
+	}
+	remote := map[string]driveSyncEntry{
+		"same.txt":    {Path: "same.txt", Size: 5, ModTime: t0.Add(-time.Hour), ID: "s", MD5: "sum-same"},
+		"changed.txt": {Path: "changed.txt", Size: 5, ModTime: t0, ID: "c", MD5: "sum-remote"},
+		"remote.txt":  {Path: "remote.txt", Size: 2, ModTime: t0, ID: "r"},
+		"Report.pdf":  {Path: "Report.pdf", ModTime: t0.Add(time.Hour), ID: "doc", Native: true},
+		"Sheet.csv":   {Path: "Sheet.csv", ModTime: t0, ID: "sheet", Native: true},
+	}
+	md5 := func(p string) (string, error) { return "sum-" + p, nil }
+
+	summarize := func(plan []driveSyncAction) string {
+		parts := make([]string, 0, len(plan))
+		for _, a := range plan {
+			parts = append(parts, a.Action+":"+a.Path)
+		}
+		return strings.Join(parts, " ")
+	}
+	for _, tc := range []struct {
+		direction string
+		del       bool
+		want      string
+	}{
+		{driveSyncPush, false, "update:changed.txt upload:local.txt"},
+		{driveSyncPush, true, "update:changed.txt upload:local.txt delete-remote:remote.txt"},
+		{driveSyncPull, true, "download:Report.pdf download:Sheet.csv download:changed.txt delete-local:local.txt download:remote.txt"},
+		{driveSyncMirror, false, "download:Report.pdf download:Sheet.csv update:changed.txt upload:local.txt download:remote.txt"},
+	} {
+		if got := summarize(planDriveSync(local, remote, tc.direction, tc.del, md5)); got != tc.want {
+			t.Fatalf("%s delete=%v:\n got  %s\n want %s", tc.direction, tc.del, got, tc.want)
+		}
+	}
+
+	if !driveSyncExcluded("build/out.o", []string{"*.o"}) || !driveSyncExcluded("node_modules", []string{"node_modules"}) || driveSyncExcluded("a.txt", []string{"*.o"}) {
+		t.Fatalf("unexpected exclude matching")
+	}
+	if got := driveSyncExportName("Budget", driveMimeGoogleSheet); got != "Budget.csv" {
+		t.Fatalf("export name: %q", got)
+	}
+}
+
+type driveSyncTestCall struct {
+	Method string
+	Path   string
+	Meta   map[string]any
+}
+
+func driveSyncTestServer(t *testing.T) func() []driveSyncTestCall {
+	t.Helper()
+	var mu sync.Mutex
+	var calls []driveSyncTestCall
+	parentRe := regexp.MustCompile(`'([^']+)' in parents`)
+	children := map[string][]map[string]any{
+		"f1": {
+			{"id": "a1", "name": "a.txt", "mimeType": "text/plain", "size": "5", "md5Checksum": "5d41402abc4b2a76b9719d911017c592", "modifiedTime": "2026-01-01T00:00:00Z"},
+			{"id": "old1", "name": "old.txt", "mimeType": "text/plain", "size": "3", "modifiedTime": "2026-01-01T00:00:00Z"},
+			{"id": "doc1", "name": "Report", "mimeType": driveMimeGoogleDoc, "modifiedTime": "2026-01-01T00:00:00Z"},
+			{"id": "form1", "name": "Survey", "mimeType": "application/vnd.google-apps.form", "modifiedTime": "2026-01-01T00:00:00Z"},
+			{"id": "sub1", "name": "sub", "mimeType": driveMimeFolder},
+		},
+		"sub1": {
+			{"id": "b1", "name": "b.txt", "mimeType": "text/plain", "size": "3", "md5Checksum": "deadbeef", "modifiedTime": "2026-01-01T00:00:00Z"},
+		},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		path := r.URL.Path
+		if r.Method == http.MethodGet && path == "/files" {
+			m := parentRe.FindStringSubmatch(r.URL.Query().Get("q"))
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": children[m[1]]})
+			return
+		}
+		if r.Method == http.MethodGet && path == "/files/f1" {
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "f1", "mimeType": driveMimeFolder})
+			return
+		}
+		if r.Method == http.MethodGet && strings.HasSuffix(path, "/export") {
+			_, _ = io.WriteString(w, "%PDF")
+			return
+		}
+		if r.Method == http.MethodGet && r.URL.Query().Get("alt") == "media" {
+			_, _ = io.WriteString(w, "remote")
+			return
+		}
+
+		call := driveSyncTestCall{Method: r.Method, Path: r.URL.Path}
+		if mt, params, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err == nil && strings.HasPrefix(mt, "multipart/") {
+			part, perr := multipart.NewReader(r.Body, params["boundary"]).NextPart()
+			if perr == nil {
+				_ = json.NewDecoder(part).Decode(&call.Meta)
+			}
+		} else {
+			_ = json.NewDecoder(r.Body).Decode(&call.Meta)
+		}
+		mu.Lock()
+		calls = append(calls, call)
+		mu.Unlock()
+		id := "new-" + filepath.Base(r.URL.Path)
+		if name, ok := call.Meta["name"].(string); ok {
+			id = "new-" + name
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": id})
+	}))
+	t.Cleanup(srv.Close)
+
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+	return func() []driveSyncTestCall {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]driveSyncTestCall(nil), calls...)
+	}
+}
+
+func TestDriveSync_PushWithDelete(t *testing.T) {
+	calls := driveSyncTestServer(t)
+	dir := t.TempDir()
+	for name, body := range map[string]string{"a.txt": "hello", "sub/b.txt": "new", "new/c.txt": "c", "skip.log": "x"} {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--force", "--account", "a@b.com", "drive", "sync", dir, "drive:f1", "--delete", "--exclude", "*.log"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	var parsed struct {
+		Actions []driveSyncAction `json:"actions"`
+		Failed  int               `json:"failed"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if len(parsed.Actions) != 3 || parsed.Failed != 0 {
+		t.Fatalf("unexpected actions: %s", out)
+	}
+
+	byPath := map[string]driveSyncTestCall{}
+	for _, c := range calls() {
+		key := c.Method + " " + c.Path
+		if name, ok := c.Meta["name"].(string); ok {
+			key += " " + name
+		}
+		byPath[key] = c
+	}
+	folder, ok := byPath["POST /files new"]
+	if !ok || folder.Meta["mimeType"] != driveMimeFolder {
+		t.Fatalf("expected folder creation, got %#v", byPath)
+	}
+	upload, ok := byPath["POST /upload/drive/v3/files c.txt"]
+	if parents, _ := upload.Meta["parents"].([]any); !ok || len(parents) != 1 || parents[0] != "new-new" {
+		t.Fatalf("expected upload into new folder, got %#v", byPath)
+	}
+	if _, ok := byPath["PATCH /upload/drive/v3/files/b1"]; !ok {
+		t.Fatalf("expected content update for changed file, got %#v", byPath)
+	}
+	if trash, ok := byPath["PATCH /files/old1"]; !ok || trash.Meta["trashed"] != true {
+		t.Fatalf("expected old.txt trashed, got %#v", byPath)
+	}
+	if len(byPath) != 4 {
+		t.Fatalf("unexpected extra calls (unchanged a.txt or native Report must be left alone): %#v", byPath)
+	}
+}
+
+func TestDriveSync_PullExportsNative(t *testing.T) {
+	_ = driveSyncTestServer(t)
+	dir := filepath.Join(t.TempDir(), "mirror")
+
+	_ = captureStdout(t, func() {
+		if err := Execute([]string{"--account", "a@b.com", "drive", "sync", dir, "f1", "--direction", "pull"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	for name, want := range map[string]string{"a.txt": "remote", "Report.pdf": "%PDF", "sub/b.txt": "remote"} {
+		b, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil || string(b) != want {
+			t.Fatalf("%s: %q %v", name, b, err)
+		}
+	}
+	if matches, _ := filepath.Glob(filepath.Join(dir, "Survey*")); len(matches) != 0 {
+		t.Fatalf("forms cannot be exported and must be skipped: %v", matches)
+	}
+	st, err := os.Stat(filepath.Join(dir, "a.txt"))
+	if err != nil || !st.ModTime().Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected Drive modifiedTime on pulled file: %v %v", st, err)
+	}
+}
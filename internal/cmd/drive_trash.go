@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+// DriveTrashCmd is the parent command for trash subcommands.
+type DriveTrashCmd struct {
+	List    DriveTrashListCmd    `cmd:"" name:"list" aliases:"ls" default:"withargs" help:"List files in the trash"`
+	Restore DriveTrashRestoreCmd `cmd:"" name:"restore" aliases:"untrash" help:"Restore files from the trash"`
+	Empty   DriveTrashEmptyCmd   `cmd:"" name:"empty" help:"Permanently delete everything in the trash"`
+}
+
+type DriveTrashListCmd struct {
+	Query   string `name:"query" help:"Drive query filter (e.g. \"name contains 'report'\")"`
+	DriveID string `name:"drive" help:"List the trash of a shared drive"`
+	Max     int64  `name:"max" aliases:"limit" help:"Max results" default:"50"`
+	Page    string `name:"page" aliases:"cursor" help:"Page token"`
+	All     bool   `name:"all" aliases:"all-pages,allpages" help:"Fetch all pages"`
+}
+
+func (c *DriveTrashListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+
+	q := "trashed = true"
+	if userQuery := strings.TrimSpace(c.Query); userQuery != "" {
+		q = userQuery + " and " + q
+	}
+	driveID := strings.TrimSpace(c.DriveID)
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	fetch := func(pageToken string) ([]*drive.File, string, error) {
+		call := svc.Files.List().
+			Q(q).
+			PageSize(c.Max).
+			OrderBy("modifiedTime desc").
+			SupportsAllDrives(true).
+			IncludeItemsFromAllDrives(true).
+			Fields("nextPageToken, files(id, name, mimeType, size, trashedTime, trashingUser(displayName, emailAddress), parents)").
+			Context(ctx)
+		if driveID != "" {
+			call = call.Corpora("drive").DriveId(driveID)
+		}
+		if strings.TrimSpace(pageToken) != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Files, resp.NextPageToken, nil
+	}
+
+	var files []*drive.File
+	nextPageToken := ""
+	if c.All {
+		files, err = collectAllPages(c.Page, fetch)
+	} else {
+		files, nextPageToken, err = fetch(c.Page)
+	}
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"files":         files,
+			"nextPageToken": nextPageToken,
+		})
+	}
+	if len(files) == 0 {
+		u.Err().Println("Trash is empty")
+		return nil
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "ID\tNAME\tTYPE\tSIZE\tTRASHED\tBY")
+	for _, f := range files {
+		by := ""
+		if f.TrashingUser != nil {
+			by = f.TrashingUser.EmailAddress
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			f.Id,
+			f.Name,
+			driveType(f.MimeType),
+			formatDriveSize(f.Size),
+			formatDateTime(f.TrashedTime),
+			by,
+		)
+	}
+	printNextPageHint(u, nextPageToken)
+	return nil
+}
+
+type DriveTrashRestoreCmd struct {
+	FileIDs []string `arg:"" name:"fileId" help:"File IDs to restore"`
+}
+
+func (c *DriveTrashRestoreCmd) Run(ctx context.Context, flags *RootFlags) error {
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	ids := make([]string, 0, len(c.FileIDs))
+	for _, id := range c.FileIDs {
+		if id = normalizeGoogleID(strings.TrimSpace(id)); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return usage("missing fileId")
+	}
+
+	if err := dryRunExit(ctx, flags, "drive.trash.restore", map[string]any{"fileIds": ids}); err != nil {
+		return err
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	type restoreResult struct {
+		ID    string `json:"id"`
+		Name  string `json:"name,omitempty"`
+		Error string `json:"error,omitempty"`
+	}
+	results := make([]restoreResult, 0, len(ids))
+	failed := 0
+	for _, id := range ids {
+		f, restoreErr := setDriveTrashed(ctx, svc, id, false)
+		if restoreErr != nil {
+			failed++
+			results = append(results, restoreResult{ID: id, Error: restoreErr.Error()})
+			continue
+		}
+		results = append(results, restoreResult{ID: id, Name: f.Name})
+	}
+
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"restored": results, "failed": failed}); err != nil {
+			return err
+		}
+	} else {
+		w, flush := tableWriter(ctx)
+		fmt.Fprintln(w, "ID\tNAME\tSTATUS")
+		for _, r := range results {
+			status := "restored"
+			if r.Error != "" {
+				status = r.Error
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", r.ID, r.Name, status)
+		}
+		flush()
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d files could not be restored", failed, len(ids))
+	}
+	return nil
+}
+
+type DriveTrashEmptyCmd struct {
+	DriveID string `name:"drive" help:"Empty the trash of a shared drive instead of My Drive"`
+}
+
+func (c *DriveTrashEmptyCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	driveID := strings.TrimSpace(c.DriveID)
+
+	action := "permanently delete everything in the trash"
+	if driveID != "" {
+		action = fmt.Sprintf("permanently delete everything in the trash of shared drive %s", driveID)
+	}
+	if confirmErr := confirmDestructive(ctx, flags, action); confirmErr != nil {
+		return confirmErr
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	call := svc.Files.EmptyTrash().Context(ctx)
+	if driveID != "" {
+		call = call.DriveId(driveID)
+	}
+	if err := call.Do(); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"emptied": true, "driveId": driveID})
+	}
+	u.Out().Printf("emptied\ttrue")
+	return nil
+}
+
+// setDriveTrashed moves a file to or out of the trash.
+func setDriveTrashed(ctx context.Context, svc *drive.Service, fileID string, trashed bool) (*drive.File, error) {
+	return svc.Files.Update(fileID, &drive.File{Trashed: trashed, ForceSendFields: []string{"Trashed"}}).
+		SupportsAllDrives(true).
+		Fields("id, name, trashed").
+		Context(ctx).
+		Do()
+}
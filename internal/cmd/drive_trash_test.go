@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+func TestDriveTrash_ListRestoreEmptyAndDelete(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		call := r.Method + " " + r.URL.Path
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/files":
+			call += " q=" + r.URL.Query().Get("q") + " driveId=" + r.URL.Query().Get("driveId")
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": []map[string]any{
+				{"id": "t1", "name": "oops.txt", "mimeType": "text/plain", "trashedTime": "2026-10-01T10:00:00Z", "trashingUser": map[string]any{"emailAddress": "a@b.com"}},
+			}})
+		case r.Method == http.MethodPatch:
+			var meta map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&meta)
+			b, _ := json.Marshal(meta)
+			call += " " + string(b)
+			if r.URL.Path == "/files/missing" {
+				http.Error(w, `{"error":{"code":404,"message":"File not found"}}`, http.StatusNotFound)
+				break
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": strings.TrimPrefix(r.URL.Path, "/files/"), "name": "oops.txt"})
+		case r.Method == http.MethodDelete:
+			call += " driveId=" + r.URL.Query().Get("driveId")
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+		mu.Lock()
+		calls = append(calls, call)
+		mu.Unlock()
+	}))
+	t.Cleanup(srv.Close)
+
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+
+	run := func(args ...string) (string, error) {
+		var runErr error
+		out := captureStdout(t, func() {
+			_ = captureStderr(t, func() {
+				runErr = Execute(append([]string{"--force", "--account", "a@b.com"}, args...))
+			})
+		})
+		return out, runErr
+	}
+
+	out, err := run("drive", "trash", "--query", "name contains 'oops'")
+	if err != nil || !strings.Contains(out, "oops.txt") || !strings.Contains(out, "a@b.com") {
+		t.Fatalf("trash list: %q %v", out, err)
+	}
+	if _, err := run("drive", "trash", "restore", "t1", "missing"); err == nil || !strings.Contains(err.Error(), "1 of 2") {
+		t.Fatalf("expected partial restore failure, got %v", err)
+	}
+	if _, err := run("drive", "trash", "empty", "--drive", "sd1"); err != nil {
+		t.Fatalf("trash empty: %v", err)
+	}
+	if _, err := run("drive", "delete", "f1"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := run("drive", "delete", "f2", "--permanent"); err != nil {
+		t.Fatalf("delete --permanent: %v", err)
+	}
+
+	want := []string{
+		"GET /files q=name contains 'oops' and trashed = true driveId=",
+		`PATCH /files/t1 {"trashed":false}`,
+		`PATCH /files/missing {"trashed":false}`,
+		"DELETE /files/trash driveId=sd1",
+		`PATCH /files/f1 {"trashed":true}`,
+		"DELETE /files/f2 driveId=",
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if strings.Join(calls, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected calls:\n%s", strings.Join(calls, "\n"))
+	}
+}
@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/webdav"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+func driveDAVTestServer(t *testing.T, readOnly bool) (string, func() []string) {
+	t.Helper()
+	var mu sync.Mutex
+	var calls []string
+	record := func(s string) {
+		mu.Lock()
+		calls = append(calls, s)
+		mu.Unlock()
+	}
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		q := r.URL.Query()
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/files" && strings.Contains(q.Get("q"), "'root' in parents"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": []map[string]any{
+				{"id": "d1", "name": "Docs", "mimeType": driveMimeFolder},
+				{"id": "n1", "name": "notes.txt", "mimeType": "text/plain", "size": "5", "md5Checksum": "abc", "modifiedTime": "2026-10-18T10:00:00Z"},
+				{"id": "g1", "name": "Plan", "mimeType": driveMimeGoogleDoc},
+			}})
+		case r.Method == http.MethodGet && r.URL.Path == "/files":
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": []any{}})
+		case r.Method == http.MethodGet && r.URL.Path == "/files/n1" && q.Get("alt") == "media":
+			record("download n1")
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = io.WriteString(w, "hello")
+		case r.Method == http.MethodGet && r.URL.Path == "/files/g1/export":
+			record("export g1 " + q.Get("mimeType"))
+			_, _ = io.WriteString(w, "%PDF-")
+		case strings.HasPrefix(r.URL.Path, "/upload/"):
+			body, _ := io.ReadAll(r.Body)
+			content := "?"
+			if strings.Contains(string(body), "new content") {
+				content = "new content"
+			}
+			record(r.Method + " " + strings.TrimPrefix(r.URL.Path, "/upload/drive/v3") + " " + content)
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "up1"})
+		case r.Method == http.MethodPost && r.URL.Path == "/files":
+			var meta map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&meta)
+			record("mkdir " + meta["name"].(string))
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "d2"})
+		case r.Method == http.MethodPatch:
+			var meta map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&meta)
+			b, _ := json.Marshal(meta)
+			record("patch " + r.URL.Path + " " + string(b) + " add=" + q.Get("addParents") + " remove=" + q.Get("removeParents"))
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": strings.TrimPrefix(r.URL.Path, "/files/")})
+		default:
+			http.Error(w, `{"error":{"code":404,"message":"not found"}}`, http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(api.Close)
+
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(api.Client()),
+		option.WithEndpoint(api.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	formats, err := parseDriveDAVFormats([]string{"doc=pdf"})
+	if err != nil {
+		t.Fatalf("formats: %v", err)
+	}
+	dav := httptest.NewServer(&webdav.Handler{
+		FileSystem: newDriveDAVFS(svc, "root", readOnly, formats, time.Minute),
+		LockSystem: webdav.NewMemLS(),
+	})
+	t.Cleanup(dav.Close)
+	return dav.URL, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), calls...)
+	}
+}
+
+func davDo(t *testing.T, method, url, body string, headers ...string) (int, string) {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, url, err)
+	}
+	defer resp.Body.Close()
+	b, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(b)
+}
+
+func TestDriveWebDAV_ReadWriteOperations(t *testing.T) {
+	base, calls := driveDAVTestServer(t, false)
+
+	status, body := davDo(t, "PROPFIND", base+"/", "", "Depth", "1")
+	if status != http.StatusMultiStatus {
+		t.Fatalf("PROPFIND status %d: %s", status, body)
+	}
+	for _, want := range []string{"/Docs/", "/notes.txt", "/Plan.pdf", "application/pdf", `"abc"`} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("PROPFIND missing %q:\n%s", want, body)
+		}
+	}
+	if len(calls()) != 0 {
+		t.Fatalf("PROPFIND must not download content: %v", calls())
+	}
+
+	if status, body = davDo(t, http.MethodGet, base+"/notes.txt", ""); status != http.StatusOK || body != "hello" {
+		t.Fatalf("GET notes.txt: %d %q", status, body)
+	}
+	if status, body = davDo(t, http.MethodGet, base+"/Plan.pdf", ""); status != http.StatusOK || body != "%PDF-" {
+		t.Fatalf("GET Plan.pdf: %d %q", status, body)
+	}
+	if status, _ = davDo(t, http.MethodPut, base+"/notes.txt", "new content"); status != http.StatusCreated {
+		t.Fatalf("PUT existing: %d", status)
+	}
+	if status, _ = davDo(t, http.MethodPut, base+"/Docs/fresh.txt", "new content"); status != http.StatusCreated {
+		t.Fatalf("PUT new: %d", status)
+	}
+	if status, _ = davDo(t, http.MethodPut, base+"/Plan.pdf", "new content"); status == http.StatusCreated {
+		t.Fatalf("PUT over a Google Doc export must be rejected")
+	}
+	if status, _ = davDo(t, "MKCOL", base+"/Archive", ""); status != http.StatusCreated {
+		t.Fatalf("MKCOL: %d", status)
+	}
+	if status, _ = davDo(t, "MOVE", base+"/Plan.pdf", "", "Destination", base+"/Docs/Roadmap.pdf"); status != http.StatusCreated {
+		t.Fatalf("MOVE: %d", status)
+	}
+	if status, _ = davDo(t, http.MethodDelete, base+"/notes.txt", ""); status != http.StatusNoContent {
+		t.Fatalf("DELETE: %d", status)
+	}
+
+	want := []string{
+		"download n1",
+		"export g1 application/pdf",
+		"PATCH /files/n1 new content",
+		"POST /files new content",
+		"mkdir Archive",
+		`patch /files/g1 {"name":"Roadmap"} add=d1 remove=root`,
+		`patch /files/n1 {"trashed":true} add= remove=`,
+	}
+	if got := strings.Join(calls(), "\n"); got != strings.Join(want, "\n") {
+		t.Fatalf("unexpected calls:\n%s", got)
+	}
+}
+
+func TestDriveWebDAV_ReadOnlyRejectsWrites(t *testing.T) {
+	base, calls := driveDAVTestServer(t, true)
+
+	if status, body := davDo(t, http.MethodGet, base+"/notes.txt", ""); status != http.StatusOK || body != "hello" {
+		t.Fatalf("GET: %d %q", status, body)
+	}
+	for _, tc := range []struct{ method, path string }{
+		{http.MethodPut, "/notes.txt"},
+		{"MKCOL", "/Archive"},
+		{http.MethodDelete, "/notes.txt"},
+	} {
+		if status, _ := davDo(t, tc.method, base+tc.path, "x"); status < 400 {
+			t.Fatalf("%s %s should fail in read-only mode, got %d", tc.method, tc.path, status)
+		}
+	}
+	if got := calls(); len(got) != 1 {
+		t.Fatalf("read-only server must not modify Drive: %v", got)
+	}
+}
+
+func TestDriveServeWebDAV_RequiresPasswordOffLoopback(t *testing.T) {
+	var err error
+	_ = captureStderr(t, func() {
+		err = Execute([]string{"--account", "a@b.com", "drive", "serve", "webdav", "--bind", "0.0.0.0:8080"})
+	})
+	if err == nil || !strings.Contains(err.Error(), "--password") {
+		t.Fatalf("expected password error, got %v", err)
+	}
+}
+
+func TestResolveDriveDAVPassword(t *testing.T) {
+	t.Setenv("GOG_WEBDAV_PASSWORD", "from-env")
+	if got, err := resolveDriveDAVPassword("", ""); err != nil || got != "from-env" {
+		t.Fatalf("env: %q %v", got, err)
+	}
+	if got, err := resolveDriveDAVPassword("from-flag", ""); err != nil || got != "from-flag" {
+		t.Fatalf("flag: %q %v", got, err)
+	}
+
+	file := filepath.Join(t.TempDir(), "dav-password")
+	if err := os.WriteFile(file, []byte("s3cret\r\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := resolveDriveDAVPassword("", file); err != nil || got != "s3cret" {
+		t.Fatalf("file: %q %v", got, err)
+	}
+	if _, err := resolveDriveDAVPassword("from-flag", file); err == nil {
+		t.Fatalf("expected --password and --password-file to conflict")
+	}
+	if err := os.WriteFile(file, []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := resolveDriveDAVPassword("", file); err == nil {
+		t.Fatalf("expected an empty password file to be rejected")
+	}
+}
@@ -19,13 +19,15 @@ import (
 var newGmailService = googleapi.NewGmail
 
 type GmailCmd struct {
-	Search     GmailSearchCmd     `cmd:"" name:"search" aliases:"find,query,ls,list" group:"Read" help:"Search threads using Gmail query syntax"`
-	Messages   GmailMessagesCmd   `cmd:"" name:"messages" aliases:"message,msg,msgs" group:"Read" help:"Message operations"`
-	Thread     GmailThreadCmd     `cmd:"" name:"thread" aliases:"threads,read" group:"Organize" help:"Thread operations (get, modify)"`
-	Get        GmailGetCmd        `cmd:"" name:"get" aliases:"info,show" group:"Read" help:"Get a message (full|metadata|raw)"`
-	Attachment GmailAttachmentCmd `cmd:"" name:"attachment" group:"Read" help:"Download a single attachment"`
-	URL        GmailURLCmd        `cmd:"" name:"url" group:"Read" help:"Print Gmail web URLs for threads"`
-	History    GmailHistoryCmd    `cmd:"" name:"history" group:"Read" help:"Gmail history"`
+	Search      GmailSearchCmd      `cmd:"" name:"search" aliases:"find,query,ls,list" group:"Read" help:"Search threads using Gmail query syntax"`
+	Messages    GmailMessagesCmd    `cmd:"" name:"messages" aliases:"message,msg,msgs" group:"Read" help:"Message operations"`
+	Thread      GmailThreadCmd      `cmd:"" name:"thread" aliases:"threads,read" group:"Organize" help:"Thread operations (get, modify)"`
+	Get         GmailGetCmd         `cmd:"" name:"get" aliases:"info,show" group:"Read" help:"Get a message (full|metadata|raw)"`
+	Attachment  GmailAttachmentCmd  `cmd:"" name:"attachment" group:"Read" help:"Download a single attachment"`
+	Attachments GmailAttachmentsCmd `cmd:"" name:"attachments" group:"Read" help:"Bulk attachment operations (harvest by query)"`
+	URL         GmailURLCmd         `cmd:"" name:"url" group:"Read" help:"Print Gmail web URLs for threads"`
+	History     GmailHistoryCmd     `cmd:"" name:"history" group:"Read" help:"Gmail history"`
+	Stats       GmailStatsCmd       `cmd:"" name:"stats" group:"Read" help:"Mailbox analytics (top senders, sizes, busiest hours, labels)"`
 
 	Labels GmailLabelsCmd `cmd:"" name:"labels" aliases:"label" group:"Organize" help:"Label operations"`
 	Batch  GmailBatchCmd  `cmd:"" name:"batch" group:"Organize" help:"Batch operations"`
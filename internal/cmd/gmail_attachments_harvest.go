@@ -0,0 +1,448 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5" //nolint:gosec // Drive exposes md5Checksum; used for dedupe only
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/gmail/v1"
+	gapi "google.golang.org/api/googleapi"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const defaultHarvestNameTemplate = "{date}_{sender}_{filename}"
+
+var harvestUnsafeChars = regexp.MustCompile(`[^\p{L}\p{N}._@+-]+`)
+
+type GmailAttachmentsCmd struct {
+	Harvest GmailAttachmentsHarvestCmd `cmd:"" name:"harvest" aliases:"collect" help:"Download all attachments of matching messages (dedupes by content hash)"`
+}
+
+type GmailAttachmentsHarvestCmd struct {
+	Query        string `name:"query" short:"q" required:"" help:"Gmail search query (e.g. 'has:attachment from:billing@')"`
+	Out          string `name:"out" aliases:"out-dir,dir" help:"Local directory to save attachments to"`
+	ToDrive      string `name:"to-drive" help:"Drive folder ID to upload attachments to (no local files written)"`
+	NameTemplate string `name:"name-template" aliases:"template" help:"Filename template: {date} {sender} {subject} {filename} {messageId}" default:"{date}_{sender}_{filename}"`
+	Max          int64  `name:"max" aliases:"limit" help:"Max messages to scan (0 = all matches)" default:"0"`
+	MimeType     string `name:"mime-type" help:"Only harvest attachments whose MIME type starts with this prefix (e.g. application/pdf)"`
+}
+
+type harvestMessage struct {
+	ID       string
+	Date     time.Time
+	Sender   string
+	Subject  string
+	Attached []attachmentInfo
+}
+
+type harvestItem struct {
+	MessageID    string `json:"messageId"`
+	AttachmentID string `json:"attachmentId"`
+	Filename     string `json:"filename"`
+	Name         string `json:"name"`
+	MimeType     string `json:"mimeType,omitempty"`
+	Size         int64  `json:"size"`
+	SHA256       string `json:"sha256"`
+	Path         string `json:"path,omitempty"`
+	FileID       string `json:"fileId,omitempty"`
+	Duplicate    bool   `json:"duplicate,omitempty"`
+	DuplicateOf  string `json:"duplicateOf,omitempty"`
+}
+
+func (c *GmailAttachmentsHarvestCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	query := strings.TrimSpace(c.Query)
+	if query == "" {
+		return usage("missing --query")
+	}
+	outDir := strings.TrimSpace(c.Out)
+	folderID := strings.TrimSpace(c.ToDrive)
+	if (outDir == "") == (folderID == "") {
+		return usage("specify exactly one of --out or --to-drive")
+	}
+	tmpl := strings.TrimSpace(c.NameTemplate)
+	if tmpl == "" {
+		tmpl = defaultHarvestNameTemplate
+	}
+	if !strings.Contains(tmpl, "{filename}") && !strings.Contains(tmpl, "{messageId}") {
+		return usage("--name-template must include {filename} or {messageId}")
+	}
+	if c.Max < 0 {
+		return usage("--max must be >= 0")
+	}
+	if outDir != "" {
+		expanded, err := config.ExpandPath(outDir)
+		if err != nil {
+			return err
+		}
+		outDir = filepath.Clean(expanded)
+	}
+
+	if err := dryRunExit(ctx, flags, "gmail.attachments.harvest", map[string]any{
+		"query":         query,
+		"out":           outDir,
+		"to_drive":      folderID,
+		"name_template": tmpl,
+		"max":           c.Max,
+	}); err != nil {
+		return err
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newGmailService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	var driveSvc *drive.Service
+	var sink harvestSink
+	if folderID != "" {
+		driveSvc, err = newDriveService(ctx, account)
+		if err != nil {
+			return err
+		}
+		sink, err = newDriveHarvestSink(ctx, driveSvc, folderID)
+	} else {
+		sink, err = newLocalHarvestSink(outDir)
+	}
+	if err != nil {
+		return err
+	}
+
+	ids, _, err := listMessageIDs(ctx, svc, query, c.Max)
+	if err != nil {
+		return err
+	}
+	messages, err := fetchHarvestMessages(ctx, svc, ids)
+	if err != nil {
+		return err
+	}
+
+	mimePrefix := strings.ToLower(strings.TrimSpace(c.MimeType))
+	items := make([]harvestItem, 0)
+	for _, m := range messages {
+		for _, a := range m.Attached {
+			if mimePrefix != "" && !strings.HasPrefix(strings.ToLower(a.MimeType), mimePrefix) {
+				continue
+			}
+			data, fetchErr := fetchAttachmentBytes(ctx, svc, m.ID, a.AttachmentID)
+			if fetchErr != nil {
+				return fmt.Errorf("message %s attachment %s: %w", m.ID, a.Filename, fetchErr)
+			}
+			sum := sha256.Sum256(data)
+			item := harvestItem{
+				MessageID:    m.ID,
+				AttachmentID: a.AttachmentID,
+				Filename:     a.Filename,
+				Name:         renderHarvestName(tmpl, m, a),
+				MimeType:     a.MimeType,
+				Size:         int64(len(data)),
+				SHA256:       hex.EncodeToString(sum[:]),
+			}
+			if dup, ok := sink.Duplicate(data); ok {
+				item.Duplicate = true
+				item.DuplicateOf = dup
+			} else if storeErr := sink.Store(ctx, &item, data); storeErr != nil {
+				return storeErr
+			}
+			items = append(items, item)
+			if !outfmt.IsJSON(ctx) {
+				printHarvestItem(u, item)
+			}
+		}
+	}
+
+	saved := 0
+	for _, it := range items {
+		if !it.Duplicate {
+			saved++
+		}
+	}
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"query":       query,
+			"messages":    len(messages),
+			"saved":       saved,
+			"duplicates":  len(items) - saved,
+			"attachments": items,
+		})
+	}
+	u.Err().Printf("Scanned %d message(s): %d saved, %d duplicate(s) skipped", len(messages), saved, len(items)-saved)
+	return nil
+}
+
+func printHarvestItem(u *ui.UI, it harvestItem) {
+	switch {
+	case it.Duplicate:
+		u.Out().Printf("duplicate\t%s\t%s", it.Name, it.DuplicateOf)
+	case it.FileID != "":
+		u.Out().Printf("uploaded\t%s\t%s\t%s", it.Name, formatBytes(it.Size), it.FileID)
+	default:
+		u.Out().Printf("saved\t%s\t%s", it.Path, formatBytes(it.Size))
+	}
+}
+
+// fetchHarvestMessages loads message payloads with bounded parallelism and
+// returns them oldest first so dedupe keeps the earliest copy.
+func fetchHarvestMessages(ctx context.Context, svc *gmail.Service, ids []string) ([]harvestMessage, error) {
+	const maxConcurrency = 10
+	sem := make(chan struct{}, maxConcurrency)
+	out := make([]harvestMessage, len(ids))
+	errs := make([]error, len(ids))
+
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func(idx int, messageID string) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				errs[idx] = ctx.Err()
+				return
+			}
+			msg, err := svc.Users.Messages.Get("me", messageID).
+				Format("full").
+				Fields("id,internalDate,payload").
+				Context(ctx).
+				Do()
+			if err != nil {
+				errs[idx] = err
+				return
+			}
+			out[idx] = harvestMessageFrom(msg)
+		}(i, id)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	sortHarvestMessages(out)
+	return out, nil
+}
+
+func harvestMessageFrom(msg *gmail.Message) harvestMessage {
+	m := harvestMessage{ID: msg.Id, Attached: collectAttachments(msg.Payload)}
+	if msg.InternalDate > 0 {
+		m.Date = time.UnixMilli(msg.InternalDate)
+	} else if t, err := mailParseDate(headerValue(msg.Payload, "Date")); err == nil {
+		m.Date = t
+	}
+	from := headerValue(msg.Payload, "From")
+	if addr, err := mail.ParseAddress(from); err == nil {
+		m.Sender = addr.Address
+	} else {
+		m.Sender = strings.TrimSpace(from)
+	}
+	m.Subject = strings.TrimSpace(headerValue(msg.Payload, "Subject"))
+	return m
+}
+
+func sortHarvestMessages(msgs []harvestMessage) {
+	sort.SliceStable(msgs, func(i, j int) bool { return msgs[i].Date.Before(msgs[j].Date) })
+}
+
+func renderHarvestName(tmpl string, m harvestMessage, a attachmentInfo) string {
+	date := ""
+	if !m.Date.IsZero() {
+		date = m.Date.Format("2006-01-02")
+	}
+	subject := m.Subject
+	if r := []rune(subject); len(r) > 60 {
+		subject = string(r[:60])
+	}
+	filename := sanitizeAttachmentFilename(a.Filename, "attachment")
+	name := strings.NewReplacer(
+		"{date}", harvestNamePart(date),
+		"{sender}", harvestNamePart(m.Sender),
+		"{subject}", harvestNamePart(subject),
+		"{filename}", harvestNamePart(filename),
+		"{messageId}", harvestNamePart(m.ID),
+	).Replace(tmpl)
+	name = sanitizeAttachmentFilename(name, filename)
+	return strings.Trim(name, "_")
+}
+
+func harvestNamePart(s string) string {
+	s = harvestUnsafeChars.ReplaceAllString(strings.TrimSpace(s), "_")
+	return strings.Trim(s, "_.")
+}
+
+// harvestSink stores harvested attachments and reports content duplicates,
+// including files already present at the destination.
+type harvestSink interface {
+	Duplicate(data []byte) (string, bool)
+	Store(ctx context.Context, item *harvestItem, data []byte) error
+}
+
+type localHarvestSink struct {
+	dir    string
+	hashes map[string]string
+}
+
+func newLocalHarvestSink(dir string) (*localHarvestSink, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	s := &localHarvestSink{dir: dir, hashes: map[string]string{}}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if !e.Type().IsRegular() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		sum, err := sha256File(path)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := s.hashes[sum]; !ok {
+			s.hashes[sum] = path
+		}
+	}
+	return s, nil
+}
+
+func sha256File(path string) (string, error) {
+	f, err := os.Open(path) //nolint:gosec // path comes from the user-selected output dir
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func (s *localHarvestSink) Duplicate(data []byte) (string, bool) {
+	sum := sha256.Sum256(data)
+	path, ok := s.hashes[hex.EncodeToString(sum[:])]
+	return path, ok
+}
+
+func (s *localHarvestSink) Store(_ context.Context, item *harvestItem, data []byte) error {
+	path := uniqueLocalPath(filepath.Join(s.dir, item.Name))
+	if err := writeFileAtomic(path, data); err != nil {
+		return err
+	}
+	item.Path = path
+	s.hashes[item.SHA256] = path
+	return nil
+}
+
+func uniqueLocalPath(path string) string {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return path
+	}
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(path, ext)
+	for i := 1; ; i++ {
+		candidate := fmt.Sprintf("%s-%d%s", base, i, ext)
+		if _, err := os.Stat(candidate); os.IsNotExist(err) {
+			return candidate
+		}
+	}
+}
+
+type driveHarvestSink struct {
+	svc      *drive.Service
+	folderID string
+	hashes   map[string]string
+	names    map[string]bool
+}
+
+func newDriveHarvestSink(ctx context.Context, svc *drive.Service, folderID string) (*driveHarvestSink, error) {
+	s := &driveHarvestSink{svc: svc, folderID: folderID, hashes: map[string]string{}, names: map[string]bool{}}
+	q := fmt.Sprintf("'%s' in parents and trashed = false", escapeDriveQueryString(folderID))
+	fetch := func(pageToken string) ([]*drive.File, string, error) {
+		call := svc.Files.List().
+			Q(q).
+			PageSize(1000).
+			SupportsAllDrives(true).
+			IncludeItemsFromAllDrives(true).
+			Fields("nextPageToken, files(id, name, md5Checksum)").
+			Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Files, resp.NextPageToken, nil
+	}
+	files, err := collectAllPages("", fetch)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		s.names[f.Name] = true
+		if f.Md5Checksum != "" {
+			s.hashes[f.Md5Checksum] = f.Id
+		}
+	}
+	return s, nil
+}
+
+func (s *driveHarvestSink) Duplicate(data []byte) (string, bool) {
+	sum := md5.Sum(data) //nolint:gosec // matches Drive md5Checksum
+	id, ok := s.hashes[hex.EncodeToString(sum[:])]
+	return id, ok
+}
+
+func (s *driveHarvestSink) Store(ctx context.Context, item *harvestItem, data []byte) error {
+	name := item.Name
+	if s.names[name] {
+		ext := filepath.Ext(name)
+		base := strings.TrimSuffix(name, ext)
+		for i := 1; s.names[name]; i++ {
+			name = fmt.Sprintf("%s-%d%s", base, i, ext)
+		}
+	}
+	mimeType := item.MimeType
+	if mimeType == "" {
+		mimeType = guessMimeType(name)
+	}
+	created, err := s.svc.Files.Create(&drive.File{Name: name, Parents: []string{s.folderID}}).
+		SupportsAllDrives(true).
+		Media(bytes.NewReader(data), gapi.ContentType(mimeType)).
+		Fields("id, name").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+	item.Name = created.Name
+	item.FileID = created.Id
+	s.names[created.Name] = true
+	sum := md5.Sum(data) //nolint:gosec // matches Drive md5Checksum
+	s.hashes[hex.EncodeToString(sum[:])] = created.Id
+	return nil
+}
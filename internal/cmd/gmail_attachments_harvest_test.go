@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"context"
+	"crypto/md5" //nolint:gosec // matches Drive md5Checksum
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/option"
+)
+
+func TestRenderHarvestName(t *testing.T) {
+	m := harvestMessage{
+		ID:      "m1",
+		Date:    time.Date(2025, 3, 4, 10, 0, 0, 0, time.UTC),
+		Sender:  "billing@example.com",
+		Subject: "Invoice #42 / March",
+	}
+	a := attachmentInfo{Filename: "../inv 42.pdf"}
+	if got := renderHarvestName(defaultHarvestNameTemplate, m, a); got != "2025-03-04_billing@example.com_inv_42.pdf" {
+		t.Fatalf("unexpected name: %q", got)
+	}
+	if got := renderHarvestName("{subject}-{filename}", m, a); got != "Invoice_42_March-inv_42.pdf" {
+		t.Fatalf("unexpected subject name: %q", got)
+	}
+}
+
+// harvestGmailServer serves two messages with an invoice.pdf attachment each;
+// data maps attachment IDs (a1 on m1, a2 on m2) to their content.
+func harvestGmailServer(t *testing.T, data map[string]string) {
+	t.Helper()
+	origNew := newGmailService
+	t.Cleanup(func() { newGmailService = origNew })
+
+	enc := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+	message := func(id string, date string, attID string) map[string]any {
+		return map[string]any{
+			"id":           id,
+			"internalDate": date,
+			"payload": map[string]any{
+				"mimeType": "multipart/mixed",
+				"headers": []map[string]any{
+					{"name": "From", "value": "Billing <billing@example.com>"},
+					{"name": "Subject", "value": "Invoice"},
+				},
+				"parts": []map[string]any{
+					{"mimeType": "text/plain", "body": map[string]any{"data": enc("see attached")}},
+					{"filename": "invoice.pdf", "mimeType": "application/pdf", "body": map[string]any{"attachmentId": attID, "size": len(data[attID])}},
+				},
+			},
+		}
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/gmail/v1")
+		w.Header().Set("Content-Type", "application/json")
+		switch path {
+		case "/users/me/messages":
+			if r.URL.Query().Get("q") != "has:attachment" {
+				t.Errorf("unexpected query: %q", r.URL.Query().Get("q"))
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"messages": []map[string]any{{"id": "m2"}, {"id": "m1"}},
+			})
+		case "/users/me/messages/m1":
+			_ = json.NewEncoder(w).Encode(message("m1", "1735725600000", "a1"))
+		case "/users/me/messages/m2":
+			_ = json.NewEncoder(w).Encode(message("m2", "1738404000000", "a2"))
+		case "/users/me/messages/m1/attachments/a1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": enc(data["a1"])})
+		case "/users/me/messages/m2/attachments/a2":
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": enc(data["a2"])})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	svc, err := gmail.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newGmailService = func(context.Context, string) (*gmail.Service, error) { return svc, nil }
+}
+
+func TestGmailAttachmentsHarvest_LocalDedupe(t *testing.T) {
+	harvestGmailServer(t, map[string]string{"a1": "pdfdata", "a2": "pdfdata"})
+
+	outDir := t.TempDir()
+	out := captureStdout(t, func() {
+		_ = captureStderr(t, func() {
+			if err := Execute([]string{"--json", "--account", "a@b.com", "gmail", "attachments", "harvest", "--query", "has:attachment", "--out", outDir}); err != nil {
+				t.Fatalf("Execute harvest: %v", err)
+			}
+		})
+	})
+
+	var payload struct {
+		Saved       int           `json:"saved"`
+		Duplicates  int           `json:"duplicates"`
+		Attachments []harvestItem `json:"attachments"`
+	}
+	if err := json.Unmarshal([]byte(out), &payload); err != nil {
+		t.Fatalf("decode: %v\n%s", err, out)
+	}
+	if payload.Saved != 1 || payload.Duplicates != 1 || len(payload.Attachments) != 2 {
+		t.Fatalf("unexpected payload: %#v", payload)
+	}
+	first := payload.Attachments[0]
+	if first.MessageID != "m1" || first.Duplicate {
+		t.Fatalf("expected oldest message to be saved first: %#v", first)
+	}
+	if filepath.Base(first.Path) != "2025-01-01_billing@example.com_invoice.pdf" {
+		t.Fatalf("unexpected path: %q", first.Path)
+	}
+	if data, readErr := os.ReadFile(first.Path); readErr != nil || string(data) != "pdfdata" {
+		t.Fatalf("unexpected file content: %q %v", data, readErr)
+	}
+	if !payload.Attachments[1].Duplicate || payload.Attachments[1].DuplicateOf != first.Path {
+		t.Fatalf("expected duplicate of first file: %#v", payload.Attachments[1])
+	}
+
+	// A second run finds the existing file by hash and writes nothing new.
+	out = captureStdout(t, func() {
+		_ = captureStderr(t, func() {
+			if err := Execute([]string{"--json", "--account", "a@b.com", "gmail", "attachments", "harvest", "--query", "has:attachment", "--out", outDir}); err != nil {
+				t.Fatalf("Execute harvest rerun: %v", err)
+			}
+		})
+	})
+	if err := json.Unmarshal([]byte(out), &payload); err != nil {
+		t.Fatalf("decode rerun: %v", err)
+	}
+	if payload.Saved != 0 || payload.Duplicates != 2 {
+		t.Fatalf("unexpected rerun payload: %#v", payload)
+	}
+	entries, _ := os.ReadDir(outDir)
+	if len(entries) != 1 {
+		t.Fatalf("expected single file in out dir, got %d", len(entries))
+	}
+}
+
+func TestGmailAttachmentsHarvest_DriveSink(t *testing.T) {
+	harvestGmailServer(t, map[string]string{"a1": "new invoice", "a2": "old invoice"})
+
+	oldSum := md5.Sum([]byte("old invoice"))
+	var mu sync.Mutex
+	var uploads []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/files":
+			if q := r.URL.Query().Get("q"); q != "'folder1' in parents and trashed = false" {
+				t.Errorf("unexpected query: %q", q)
+			}
+			// The folder already holds a file with the first name and the
+			// second attachment's content.
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": []map[string]any{
+				{"id": "existing", "name": "2025-01-01_billing@example.com_invoice.pdf", "md5Checksum": hex.EncodeToString(oldSum[:])},
+			}})
+		case r.Method == http.MethodPost && r.URL.Path == "/upload/drive/v3/files":
+			_, params, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			mr := multipart.NewReader(r.Body, params["boundary"])
+			var meta struct {
+				Name    string   `json:"name"`
+				Parents []string `json:"parents"`
+			}
+			if part, err := mr.NextPart(); err == nil {
+				_ = json.NewDecoder(part).Decode(&meta)
+			}
+			var body []byte
+			if part, err := mr.NextPart(); err == nil {
+				body, _ = io.ReadAll(part)
+			}
+			mu.Lock()
+			uploads = append(uploads, meta.Name+" "+strings.Join(meta.Parents, ",")+" "+string(body))
+			mu.Unlock()
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "new1", "name": meta.Name})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	driveSvc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	origDrive := newDriveService
+	t.Cleanup(func() { newDriveService = origDrive })
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return driveSvc, nil }
+
+	out := captureStdout(t, func() {
+		_ = captureStderr(t, func() {
+			if err := Execute([]string{"--json", "--account", "a@b.com", "gmail", "attachments", "harvest", "--query", "has:attachment", "--to-drive", "folder1"}); err != nil {
+				t.Fatalf("Execute harvest: %v", err)
+			}
+		})
+	})
+
+	var payload struct {
+		Saved       int           `json:"saved"`
+		Duplicates  int           `json:"duplicates"`
+		Attachments []harvestItem `json:"attachments"`
+	}
+	if err := json.Unmarshal([]byte(out), &payload); err != nil {
+		t.Fatalf("decode: %v\n%s", err, out)
+	}
+	if payload.Saved != 1 || payload.Duplicates != 1 || len(payload.Attachments) != 2 {
+		t.Fatalf("unexpected payload: %#v", payload)
+	}
+	want := "2025-01-01_billing@example.com_invoice-1.pdf folder1 new invoice"
+	if len(uploads) != 1 || uploads[0] != want {
+		t.Fatalf("expected one upload renamed around the existing file, got %q", uploads)
+	}
+	if first := payload.Attachments[0]; first.FileID != "new1" || first.Name != "2025-01-01_billing@example.com_invoice-1.pdf" {
+		t.Fatalf("unexpected uploaded item: %#v", first)
+	}
+	if second := payload.Attachments[1]; !second.Duplicate || second.DuplicateOf != "existing" {
+		t.Fatalf("expected content already in the folder to be skipped: %#v", second)
+	}
+}
+
+func TestGmailAttachmentsHarvest_Validation(t *testing.T) {
+	err := Execute([]string{"--account", "a@b.com", "gmail", "attachments", "harvest", "--query", "x"})
+	if err == nil || !strings.Contains(err.Error(), "exactly one of --out or --to-drive") {
+		t.Fatalf("expected destination error, got %v", err)
+	}
+}
@@ -291,3 +291,45 @@ func truncateRunes(s string, maxLen int) string {
 	}
 	return string(runes[:maxLen-3]) + "..."
 }
+
+// listMessageIDs returns the IDs of all messages matching query, stopping
+// after maxMessages when it is positive; truncated reports that more matches
+// were left out.
+func listMessageIDs(ctx context.Context, svc *gmail.Service, query string, maxMessages int64) (ids []string, truncated bool, err error) {
+	pageSize := int64(500)
+	if maxMessages > 0 && maxMessages < pageSize {
+		pageSize = maxMessages
+	}
+	fetch := func(pageToken string) ([]*gmail.Message, string, error) {
+		call := svc.Users.Messages.List("me").
+			Q(query).
+			MaxResults(pageSize).
+			Fields("messages(id),nextPageToken").
+			Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		next := resp.NextPageToken
+		if maxMessages > 0 && int64(len(ids)+len(resp.Messages)) >= maxMessages {
+			truncated = next != "" || int64(len(ids)+len(resp.Messages)) > maxMessages
+			next = ""
+		}
+		for _, m := range resp.Messages {
+			if m != nil && m.Id != "" {
+				ids = append(ids, m.Id)
+			}
+		}
+		return resp.Messages, next, nil
+	}
+	if _, err := collectAllPages("", fetch); err != nil {
+		return nil, false, err
+	}
+	if maxMessages > 0 && int64(len(ids)) > maxMessages {
+		ids = ids[:maxMessages]
+	}
+	return ids, truncated, nil
+}
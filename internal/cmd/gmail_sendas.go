@@ -22,6 +22,7 @@ type GmailSendAsCmd struct {
 	Verify GmailSendAsVerifyCmd `cmd:"" name:"verify" aliases:"resend" help:"Resend verification email for a send-as alias"`
 	Delete GmailSendAsDeleteCmd `cmd:"" name:"delete" aliases:"rm,del,remove" help:"Delete a send-as alias"`
 	Update GmailSendAsUpdateCmd `cmd:"" name:"update" aliases:"edit,set" help:"Update a send-as alias"`
+	Smime  GmailSmimeCmd        `cmd:"" name:"smime" aliases:"s-mime" help:"Manage S/MIME certificates for send-as aliases"`
 }
 
 type GmailSendAsListCmd struct{}
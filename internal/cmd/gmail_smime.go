@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"golang.org/x/term"
+	"google.golang.org/api/gmail/v1"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/input"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type GmailSmimeCmd struct {
+	List       GmailSmimeListCmd       `cmd:"" name:"list" aliases:"ls" help:"List S/MIME certificates for a send-as alias"`
+	Insert     GmailSmimeInsertCmd     `cmd:"" name:"insert" aliases:"add,upload" help:"Upload a PKCS#12 certificate for a send-as alias"`
+	Delete     GmailSmimeDeleteCmd     `cmd:"" name:"delete" aliases:"rm,del,remove" help:"Delete an S/MIME certificate"`
+	SetDefault GmailSmimeSetDefaultCmd `cmd:"" name:"set-default" aliases:"default" help:"Make an S/MIME certificate the default for a send-as alias"`
+}
+
+type GmailSmimeListCmd struct {
+	Email string `arg:"" name:"email" help:"Send-as email"`
+}
+
+func (c *GmailSmimeListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	sendAsEmail := strings.TrimSpace(c.Email)
+	if sendAsEmail == "" {
+		return errors.New("email is required")
+	}
+
+	svc, err := newGmailService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	resp, err := svc.Users.Settings.SendAs.SmimeInfo.List("me", sendAsEmail).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"email":     sendAsEmail,
+			"smimeInfo": resp.SmimeInfo,
+		})
+	}
+
+	if len(resp.SmimeInfo) == 0 {
+		u.Err().Printf("No S/MIME certificates for %s", sendAsEmail)
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tISSUER\tEXPIRES\tDEFAULT")
+	for _, info := range resp.SmimeInfo {
+		isDefault := ""
+		if info.IsDefault {
+			isDefault = sendAsYes
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", info.Id, info.IssuerCn, formatSmimeExpiration(info.Expiration), isDefault)
+	}
+	_ = tw.Flush()
+	return nil
+}
+
+type GmailSmimeInsertCmd struct {
+	Email         string `arg:"" name:"email" help:"Send-as email"`
+	File          string `name:"file" short:"f" required:"" help:"Path to a PKCS#12 (.p12/.pfx) file with certificate and private key"`
+	PasswordStdin bool   `name:"password-stdin" help:"Read the PKCS#12 password from stdin"`
+	NoPassword    bool   `name:"no-password" help:"The PKCS#12 file is not password protected"`
+	Default       bool   `name:"default" aliases:"make-default" help:"Make the uploaded certificate the default for this alias"`
+}
+
+func (c *GmailSmimeInsertCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	sendAsEmail := strings.TrimSpace(c.Email)
+	if sendAsEmail == "" {
+		return errors.New("email is required")
+	}
+	if c.PasswordStdin && c.NoPassword {
+		return usage("--password-stdin cannot be combined with --no-password")
+	}
+
+	path, err := config.ExpandPath(strings.TrimSpace(c.File))
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // user-provided path
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		return fmt.Errorf("empty PKCS#12 file: %s", path)
+	}
+
+	if dryRunErr := dryRunExit(ctx, flags, "gmail.sendas.smime.insert", map[string]any{
+		"email":   sendAsEmail,
+		"file":    path,
+		"bytes":   len(data),
+		"default": c.Default,
+	}); dryRunErr != nil {
+		return dryRunErr
+	}
+
+	password, err := c.readPassword(ctx, flags)
+	if err != nil {
+		return err
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+
+	svc, err := newGmailService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	info := &gmail.SmimeInfo{
+		Pkcs12:               base64.URLEncoding.EncodeToString(data),
+		EncryptedKeyPassword: password,
+	}
+	created, err := svc.Users.Settings.SendAs.SmimeInfo.Insert("me", sendAsEmail, info).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+
+	if c.Default && created.Id != "" {
+		if err := svc.Users.Settings.SendAs.SmimeInfo.SetDefault("me", sendAsEmail, created.Id).Context(ctx).Do(); err != nil {
+			return err
+		}
+		created.IsDefault = true
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"email":     sendAsEmail,
+			"smimeInfo": created,
+		})
+	}
+
+	u.Out().Printf("id\t%s", created.Id)
+	u.Out().Printf("issuer\t%s", created.IssuerCn)
+	u.Out().Printf("expires\t%s", formatSmimeExpiration(created.Expiration))
+	u.Out().Printf("default\t%t", created.IsDefault)
+	return nil
+}
+
+func (c *GmailSmimeInsertCmd) readPassword(ctx context.Context, flags *RootFlags) (string, error) {
+	if c.NoPassword {
+		return "", nil
+	}
+	if c.PasswordStdin {
+		line, err := input.ReadLine(os.Stdin)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", fmt.Errorf("read password: %w", err)
+		}
+		return strings.TrimRight(line, "\r\n"), nil
+	}
+	if flags.NoInput || !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", usage("PKCS#12 password required: use --password-stdin or --no-password (non-interactive)")
+	}
+	return input.PromptPassword(ctx, "PKCS#12 password: ")
+}
+
+type GmailSmimeDeleteCmd struct {
+	Email string `arg:"" name:"email" help:"Send-as email"`
+	ID    string `arg:"" name:"smimeId" help:"S/MIME certificate ID"`
+}
+
+func (c *GmailSmimeDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	sendAsEmail := strings.TrimSpace(c.Email)
+	smimeID := strings.TrimSpace(c.ID)
+	if sendAsEmail == "" || smimeID == "" {
+		return errors.New("email and smimeId are required")
+	}
+
+	if confirmErr := confirmDestructive(ctx, flags, fmt.Sprintf("delete S/MIME certificate %s for %s", smimeID, sendAsEmail)); confirmErr != nil {
+		return confirmErr
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+
+	svc, err := newGmailService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	if err := svc.Users.Settings.SendAs.SmimeInfo.Delete("me", sendAsEmail, smimeID).Context(ctx).Do(); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"email":   sendAsEmail,
+			"id":      smimeID,
+			"deleted": true,
+		})
+	}
+
+	u.Out().Printf("Deleted S/MIME certificate %s for %s", smimeID, sendAsEmail)
+	return nil
+}
+
+type GmailSmimeSetDefaultCmd struct {
+	Email string `arg:"" name:"email" help:"Send-as email"`
+	ID    string `arg:"" name:"smimeId" help:"S/MIME certificate ID"`
+}
+
+func (c *GmailSmimeSetDefaultCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	sendAsEmail := strings.TrimSpace(c.Email)
+	smimeID := strings.TrimSpace(c.ID)
+	if sendAsEmail == "" || smimeID == "" {
+		return errors.New("email and smimeId are required")
+	}
+
+	if err := dryRunExit(ctx, flags, "gmail.sendas.smime.set_default", map[string]any{
+		"email": sendAsEmail,
+		"id":    smimeID,
+	}); err != nil {
+		return err
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+
+	svc, err := newGmailService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	if err := svc.Users.Settings.SendAs.SmimeInfo.SetDefault("me", sendAsEmail, smimeID).Context(ctx).Do(); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"email":   sendAsEmail,
+			"id":      smimeID,
+			"default": true,
+		})
+	}
+
+	u.Out().Printf("Default S/MIME certificate for %s: %s", sendAsEmail, smimeID)
+	return nil
+}
+
+func formatSmimeExpiration(ms int64) string {
+	if ms <= 0 {
+		return ""
+	}
+	return time.UnixMilli(ms).UTC().Format("2006-01-02")
+}
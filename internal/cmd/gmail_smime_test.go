@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/option"
+)
+
+func TestGmailSmimeCommands(t *testing.T) {
+	origNew := newGmailService
+	t.Cleanup(func() { newGmailService = origNew })
+
+	var inserted map[string]any
+	var defaultID string
+	var deletedID string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/gmail/v1")
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && path == "/users/me/settings/sendAs/alias@example.com/smimeInfo":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"smimeInfo": []map[string]any{
+					{"id": "cert1", "issuerCn": "Example CA", "expiration": "1798761600000", "isDefault": true},
+				},
+			})
+		case r.Method == http.MethodPost && path == "/users/me/settings/sendAs/alias@example.com/smimeInfo":
+			_ = json.NewDecoder(r.Body).Decode(&inserted)
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "cert2", "issuerCn": "Example CA"})
+		case r.Method == http.MethodPost && path == "/users/me/settings/sendAs/alias@example.com/smimeInfo/cert2/setDefault":
+			defaultID = "cert2"
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete && path == "/users/me/settings/sendAs/alias@example.com/smimeInfo/cert1":
+			deletedID = "cert1"
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	svc, err := gmail.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newGmailService = func(context.Context, string) (*gmail.Service, error) { return svc, nil }
+
+	listOut := captureStdout(t, func() {
+		if err := Execute([]string{"--account", "a@b.com", "gmail", "settings", "sendas", "smime", "list", "alias@example.com"}); err != nil {
+			t.Fatalf("list: %v", err)
+		}
+	})
+	if !strings.Contains(listOut, "cert1") || !strings.Contains(listOut, "Example CA") || !strings.Contains(listOut, "2027-01-01") {
+		t.Fatalf("unexpected list output: %q", listOut)
+	}
+
+	p12 := filepath.Join(t.TempDir(), "cert.p12")
+	if err := os.WriteFile(p12, []byte("p12-bytes"), 0o600); err != nil {
+		t.Fatalf("write p12: %v", err)
+	}
+	insertOut := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "gmail", "settings", "sendas", "smime", "insert", "alias@example.com", "--file", p12, "--no-password", "--default"}); err != nil {
+			t.Fatalf("insert: %v", err)
+		}
+	})
+	if got := inserted["pkcs12"]; got != base64.URLEncoding.EncodeToString([]byte("p12-bytes")) {
+		t.Fatalf("unexpected pkcs12 payload: %#v", inserted)
+	}
+	if _, ok := inserted["encryptedKeyPassword"]; ok {
+		t.Fatalf("password should be omitted with --no-password: %#v", inserted)
+	}
+	if defaultID != "cert2" || !strings.Contains(insertOut, `"isDefault": true`) {
+		t.Fatalf("expected default to be set: %q %q", defaultID, insertOut)
+	}
+
+	_ = captureStdout(t, func() {
+		if err := Execute([]string{"--force", "--account", "a@b.com", "gmail", "settings", "sendas", "smime", "delete", "alias@example.com", "cert1"}); err != nil {
+			t.Fatalf("delete: %v", err)
+		}
+	})
+	if deletedID != "cert1" {
+		t.Fatalf("expected cert1 deleted")
+	}
+}
+
+func TestGmailSmimeInsert_NonInteractiveNeedsPassword(t *testing.T) {
+	p12 := filepath.Join(t.TempDir(), "cert.p12")
+	if err := os.WriteFile(p12, []byte("p12-bytes"), 0o600); err != nil {
+		t.Fatalf("write p12: %v", err)
+	}
+	var err error
+	_ = captureStderr(t, func() {
+		err = Execute([]string{"--no-input", "--account", "a@b.com", "gmail", "settings", "sendas", "smime", "insert", "alias@example.com", "--file", p12})
+	})
+	if err == nil || !strings.Contains(err.Error(), "--password-stdin") {
+		t.Fatalf("expected password usage error, got %v", err)
+	}
+}
@@ -0,0 +1,442 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/api/gmail/v1"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/timeparse"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const (
+	statsGroupSender = "sender"
+	statsGroupDomain = "domain"
+	statsGroupLabel  = "label"
+	statsGroupDay    = "day"
+)
+
+var gmailRelativeSincePattern = regexp.MustCompile(`^\d+[dmy]$`)
+
+type GmailStatsCmd struct {
+	Query    string `name:"query" short:"q" help:"Gmail search query to restrict the analyzed messages"`
+	Since    string `name:"since" help:"Only messages newer than this (Gmail style 90d/6m/1y, a duration like 72h, or a date)" default:"30d"`
+	GroupBy  string `name:"group-by" aliases:"by" help:"Group messages by: sender|domain|label|day" default:"sender"`
+	Top      int    `name:"top" help:"Rows per section" default:"20"`
+	Max      int64  `name:"max" aliases:"limit" help:"Max messages to analyze (0 = all matches)" default:"5000"`
+	Timezone string `name:"timezone" short:"z" help:"Timezone for day/hour buckets (IANA name). Default: local"`
+	Local    bool   `name:"local" help:"Use local timezone (default behavior, useful to override --timezone)"`
+}
+
+type gmailStatMessage struct {
+	ID       string
+	ThreadID string
+	From     string
+	Subject  string
+	Labels   []string
+	Size     int64
+	Date     time.Time
+}
+
+type gmailStatGroup struct {
+	Key    string `json:"key"`
+	Count  int    `json:"count"`
+	Bytes  int64  `json:"bytes"`
+	Unread int    `json:"unread"`
+}
+
+type gmailStatHour struct {
+	Hour  int `json:"hour"`
+	Count int `json:"count"`
+}
+
+type gmailStatLabel struct {
+	Label  string `json:"label"`
+	Total  int    `json:"total"`
+	Unread int    `json:"unread"`
+}
+
+type gmailStatThread struct {
+	ThreadID string `json:"threadId"`
+	Subject  string `json:"subject,omitempty"`
+	Messages int    `json:"messages"`
+	Bytes    int64  `json:"bytes"`
+}
+
+type gmailStats struct {
+	Messages       int               `json:"messages"`
+	TotalBytes     int64             `json:"totalBytes"`
+	Unread         int               `json:"unread"`
+	GroupBy        string            `json:"groupBy"`
+	Groups         []gmailStatGroup  `json:"groups"`
+	TopByBytes     []gmailStatGroup  `json:"topByBytes"`
+	Hours          []gmailStatHour   `json:"hours"`
+	Labels         []gmailStatLabel  `json:"labels"`
+	LargestThreads []gmailStatThread `json:"largestThreads"`
+}
+
+func (c *GmailStatsCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	groupBy := strings.ToLower(strings.TrimSpace(c.GroupBy))
+	switch groupBy {
+	case statsGroupSender, statsGroupDomain, statsGroupLabel, statsGroupDay:
+	default:
+		return usagef("invalid --group-by: %q (expected sender|domain|label|day)", c.GroupBy)
+	}
+	if c.Top <= 0 {
+		return usage("--top must be > 0")
+	}
+	if c.Max < 0 {
+		return usage("--max must be >= 0")
+	}
+	loc, err := resolveOutputLocation(c.Timezone, c.Local)
+	if err != nil {
+		return err
+	}
+	query, err := buildGmailStatsQuery(c.Query, c.Since, time.Now(), loc)
+	if err != nil {
+		return err
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newGmailService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	ids, truncated, err := listMessageIDs(ctx, svc, query, c.Max)
+	if err != nil {
+		return err
+	}
+	if truncated {
+		u.Err().Printf("Warning: more than %d messages match; stats cover only the newest %d (raise --max, or --max 0 for all)", c.Max, c.Max)
+	}
+	msgs, err := fetchGmailStatMessages(ctx, svc, ids)
+	if err != nil {
+		return err
+	}
+	idToName, err := fetchLabelIDToName(svc)
+	if err != nil {
+		return err
+	}
+
+	stats := aggregateGmailStats(msgs, groupBy, idToName, loc, c.Top)
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"query":     query,
+			"truncated": truncated,
+			"stats":     stats,
+		})
+	}
+
+	if stats.Messages == 0 {
+		u.Err().Println("No messages")
+		return nil
+	}
+
+	u.Err().Printf("%d message(s), %s total, %d unread (query: %s)", stats.Messages, formatBytes(stats.TotalBytes), stats.Unread, query)
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+
+	fmt.Fprintf(w, "%s\tCOUNT\tBYTES\tUNREAD\n", strings.ToUpper(groupBy))
+	for _, g := range stats.Groups {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%d\n", sanitizeTab(g.Key), g.Count, formatBytes(g.Bytes), g.Unread)
+	}
+	fmt.Fprintln(w, "")
+	fmt.Fprintf(w, "%s (BY SIZE)\tCOUNT\tBYTES\tUNREAD\n", strings.ToUpper(groupBy))
+	for _, g := range stats.TopByBytes {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%d\n", sanitizeTab(g.Key), g.Count, formatBytes(g.Bytes), g.Unread)
+	}
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "HOUR\tCOUNT")
+	for _, h := range busiestHours(stats.Hours, 5) {
+		fmt.Fprintf(w, "%02d:00\t%d\n", h.Hour, h.Count)
+	}
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "LABEL\tTOTAL\tUNREAD")
+	for _, l := range stats.Labels {
+		fmt.Fprintf(w, "%s\t%d\t%d\n", sanitizeTab(l.Label), l.Total, l.Unread)
+	}
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "THREAD\tMESSAGES\tBYTES\tSUBJECT")
+	for _, t := range stats.LargestThreads {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", t.ThreadID, t.Messages, formatBytes(t.Bytes), sanitizeTab(t.Subject))
+	}
+	return nil
+}
+
+// buildGmailStatsQuery combines the user query with a --since bound. Gmail's
+// own relative syntax (90d, 6m, 1y) is passed through as newer_than.
+func buildGmailStatsQuery(query, since string, now time.Time, loc *time.Location) (string, error) {
+	query = strings.TrimSpace(query)
+	since = strings.ToLower(strings.TrimSpace(since))
+	if since == "" {
+		return query, nil
+	}
+	var bound string
+	if gmailRelativeSincePattern.MatchString(since) {
+		bound = "newer_than:" + since
+	} else {
+		parsed, err := timeparse.ParseSince(since, now, loc)
+		if err != nil {
+			return "", usagef("invalid --since: %q (try 90d, 72h or 2026-01-01)", since)
+		}
+		bound = "after:" + strconv.FormatInt(parsed.Time.Unix(), 10)
+	}
+	if query == "" {
+		return bound, nil
+	}
+	return query + " " + bound, nil
+}
+
+// fetchGmailStatMessages loads message metadata with bounded parallelism.
+func fetchGmailStatMessages(ctx context.Context, svc *gmail.Service, ids []string) ([]gmailStatMessage, error) {
+	const maxConcurrency = 10
+	sem := make(chan struct{}, maxConcurrency)
+	out := make([]gmailStatMessage, len(ids))
+	errs := make([]error, len(ids))
+
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func(idx int, messageID string) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				errs[idx] = ctx.Err()
+				return
+			}
+			msg, err := svc.Users.Messages.Get("me", messageID).
+				Format("metadata").
+				MetadataHeaders("From", "Subject").
+				Fields("id,threadId,labelIds,sizeEstimate,internalDate,payload/headers").
+				Context(ctx).
+				Do()
+			if err != nil {
+				errs[idx] = err
+				return
+			}
+			out[idx] = gmailStatMessage{
+				ID:       msg.Id,
+				ThreadID: msg.ThreadId,
+				From:     headerValue(msg.Payload, "From"),
+				Subject:  headerValue(msg.Payload, "Subject"),
+				Labels:   msg.LabelIds,
+				Size:     msg.SizeEstimate,
+				Date:     time.UnixMilli(msg.InternalDate),
+			}
+		}(i, id)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func aggregateGmailStats(msgs []gmailStatMessage, groupBy string, idToName map[string]string, loc *time.Location, top int) gmailStats {
+	if loc == nil {
+		loc = time.Local
+	}
+	stats := gmailStats{GroupBy: groupBy, Hours: make([]gmailStatHour, 24)}
+	for h := range stats.Hours {
+		stats.Hours[h].Hour = h
+	}
+
+	groups := map[string]*gmailStatGroup{}
+	labels := map[string]*gmailStatLabel{}
+	threads := map[string]*gmailStatThread{}
+
+	labelName := func(id string) string {
+		if name, ok := idToName[id]; ok {
+			return name
+		}
+		return id
+	}
+
+	for _, m := range msgs {
+		if m.ID == "" {
+			continue
+		}
+		stats.Messages++
+		stats.TotalBytes += m.Size
+		unread := hasLabelID(m.Labels, "UNREAD")
+		if unread {
+			stats.Unread++
+		}
+		stats.Hours[m.Date.In(loc).Hour()].Count++
+
+		for _, key := range gmailStatKeys(m, groupBy, labelName, loc) {
+			g := groups[key]
+			if g == nil {
+				g = &gmailStatGroup{Key: key}
+				groups[key] = g
+			}
+			g.Count++
+			g.Bytes += m.Size
+			if unread {
+				g.Unread++
+			}
+		}
+
+		for _, id := range m.Labels {
+			if id == "UNREAD" {
+				continue
+			}
+			name := labelName(id)
+			l := labels[name]
+			if l == nil {
+				l = &gmailStatLabel{Label: name}
+				labels[name] = l
+			}
+			l.Total++
+			if unread {
+				l.Unread++
+			}
+		}
+
+		if m.ThreadID != "" {
+			t := threads[m.ThreadID]
+			if t == nil {
+				t = &gmailStatThread{ThreadID: m.ThreadID, Subject: m.Subject}
+				threads[m.ThreadID] = t
+			}
+			t.Messages++
+			t.Bytes += m.Size
+		}
+	}
+
+	byCount := make([]gmailStatGroup, 0, len(groups))
+	for _, g := range groups {
+		byCount = append(byCount, *g)
+	}
+	byBytes := append([]gmailStatGroup(nil), byCount...)
+	if groupBy == statsGroupDay {
+		sort.Slice(byCount, func(i, j int) bool { return byCount[i].Key < byCount[j].Key })
+	} else {
+		sort.Slice(byCount, func(i, j int) bool {
+			if byCount[i].Count != byCount[j].Count {
+				return byCount[i].Count > byCount[j].Count
+			}
+			return byCount[i].Key < byCount[j].Key
+		})
+		byCount = truncateStats(byCount, top)
+	}
+	sort.Slice(byBytes, func(i, j int) bool {
+		if byBytes[i].Bytes != byBytes[j].Bytes {
+			return byBytes[i].Bytes > byBytes[j].Bytes
+		}
+		return byBytes[i].Key < byBytes[j].Key
+	})
+	stats.Groups = byCount
+	stats.TopByBytes = truncateStats(byBytes, top)
+
+	stats.Labels = make([]gmailStatLabel, 0, len(labels))
+	for _, l := range labels {
+		stats.Labels = append(stats.Labels, *l)
+	}
+	sort.Slice(stats.Labels, func(i, j int) bool {
+		if stats.Labels[i].Unread != stats.Labels[j].Unread {
+			return stats.Labels[i].Unread > stats.Labels[j].Unread
+		}
+		return stats.Labels[i].Label < stats.Labels[j].Label
+	})
+	stats.Labels = truncateStats(stats.Labels, top)
+
+	stats.LargestThreads = make([]gmailStatThread, 0, len(threads))
+	for _, t := range threads {
+		stats.LargestThreads = append(stats.LargestThreads, *t)
+	}
+	sort.Slice(stats.LargestThreads, func(i, j int) bool {
+		a, b := stats.LargestThreads[i], stats.LargestThreads[j]
+		if a.Bytes != b.Bytes {
+			return a.Bytes > b.Bytes
+		}
+		return a.ThreadID < b.ThreadID
+	})
+	stats.LargestThreads = truncateStats(stats.LargestThreads, top)
+	return stats
+}
+
+func gmailStatKeys(m gmailStatMessage, groupBy string, labelName func(string) string, loc *time.Location) []string {
+	switch groupBy {
+	case statsGroupDomain:
+		addr := senderAddress(m.From)
+		if i := strings.LastIndex(addr, "@"); i >= 0 {
+			return []string{addr[i+1:]}
+		}
+		return []string{addr}
+	case statsGroupLabel:
+		if len(m.Labels) == 0 {
+			return []string{"(none)"}
+		}
+		keys := make([]string, 0, len(m.Labels))
+		for _, id := range m.Labels {
+			keys = append(keys, labelName(id))
+		}
+		return keys
+	case statsGroupDay:
+		return []string{m.Date.In(loc).Format("2006-01-02")}
+	default:
+		return []string{senderAddress(m.From)}
+	}
+}
+
+func senderAddress(from string) string {
+	if addr, err := mail.ParseAddress(from); err == nil {
+		return strings.ToLower(addr.Address)
+	}
+	from = strings.TrimSpace(from)
+	if from == "" {
+		return "(unknown)"
+	}
+	return strings.ToLower(from)
+}
+
+func hasLabelID(labels []string, id string) bool {
+	for _, l := range labels {
+		if l == id {
+			return true
+		}
+	}
+	return false
+}
+
+func busiestHours(hours []gmailStatHour, n int) []gmailStatHour {
+	sorted := append([]gmailStatHour(nil), hours...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Count > sorted[j].Count })
+	out := make([]gmailStatHour, 0, n)
+	for _, h := range sorted {
+		if h.Count == 0 || len(out) == n {
+			break
+		}
+		out = append(out, h)
+	}
+	return out
+}
+
+func truncateStats[T any](items []T, n int) []T {
+	if n > 0 && len(items) > n {
+		return items[:n]
+	}
+	return items
+}
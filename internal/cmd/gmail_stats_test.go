@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/option"
+)
+
+func TestBuildGmailStatsQuery(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		query, since, want string
+	}{
+		{"from:a@b.com", "90d", "from:a@b.com newer_than:90d"},
+		{"", "1Y", "newer_than:1y"},
+		{"in:inbox", "", "in:inbox"},
+		{"", "24h", "after:1767960000"},
+		{"", "2026-01-01", "after:1767225600"},
+	}
+	for _, tc := range cases {
+		got, err := buildGmailStatsQuery(tc.query, tc.since, now, time.UTC)
+		if err != nil {
+			t.Fatalf("buildGmailStatsQuery(%q, %q): %v", tc.query, tc.since, err)
+		}
+		if got != tc.want {
+			t.Fatalf("buildGmailStatsQuery(%q, %q) = %q, want %q", tc.query, tc.since, got, tc.want)
+		}
+	}
+	if _, err := buildGmailStatsQuery("", "soon", now, time.UTC); err == nil || !strings.Contains(err.Error(), "invalid --since") {
+		t.Fatalf("expected invalid since error, got %v", err)
+	}
+}
+
+func TestAggregateGmailStats(t *testing.T) {
+	day1 := time.Date(2026, 1, 5, 9, 30, 0, 0, time.UTC)
+	day2 := time.Date(2026, 1, 6, 14, 0, 0, 0, time.UTC)
+	msgs := []gmailStatMessage{
+		{ID: "1", ThreadID: "t1", From: "Alice <alice@example.com>", Subject: "Big", Labels: []string{"INBOX", "UNREAD"}, Size: 5000, Date: day1},
+		{ID: "2", ThreadID: "t1", From: "alice@example.com", Labels: []string{"INBOX"}, Size: 3000, Date: day1},
+		{ID: "3", ThreadID: "t2", From: "Bob <bob@other.org>", Subject: "Small", Labels: []string{"Label_1", "UNREAD"}, Size: 100, Date: day2},
+		{ID: ""},
+	}
+	names := map[string]string{"Label_1": "Receipts", "INBOX": "INBOX"}
+
+	stats := aggregateGmailStats(msgs, statsGroupSender, names, time.UTC, 10)
+	if stats.Messages != 3 || stats.TotalBytes != 8100 || stats.Unread != 2 {
+		t.Fatalf("unexpected totals: %+v", stats)
+	}
+	if len(stats.Groups) != 2 || stats.Groups[0].Key != "alice@example.com" || stats.Groups[0].Count != 2 || stats.Groups[0].Unread != 1 {
+		t.Fatalf("unexpected sender groups: %+v", stats.Groups)
+	}
+	if stats.Hours[9].Count != 2 || stats.Hours[14].Count != 1 {
+		t.Fatalf("unexpected hours: %+v", stats.Hours)
+	}
+	if len(stats.LargestThreads) != 2 || stats.LargestThreads[0].ThreadID != "t1" || stats.LargestThreads[0].Bytes != 8000 || stats.LargestThreads[0].Subject != "Big" {
+		t.Fatalf("unexpected threads: %+v", stats.LargestThreads)
+	}
+	if len(stats.Labels) != 2 || stats.Labels[0].Unread != 1 {
+		t.Fatalf("unexpected labels: %+v", stats.Labels)
+	}
+
+	byDomain := aggregateGmailStats(msgs, statsGroupDomain, names, time.UTC, 1)
+	if len(byDomain.Groups) != 1 || byDomain.Groups[0].Key != "example.com" {
+		t.Fatalf("unexpected domain groups: %+v", byDomain.Groups)
+	}
+
+	byDay := aggregateGmailStats(msgs, statsGroupDay, names, time.UTC, 1)
+	if len(byDay.Groups) != 2 || byDay.Groups[0].Key != "2026-01-05" || byDay.Groups[1].Key != "2026-01-06" {
+		t.Fatalf("day groups should be chronological and untruncated: %+v", byDay.Groups)
+	}
+
+	byLabel := aggregateGmailStats(msgs, statsGroupLabel, names, time.UTC, 10)
+	found := false
+	for _, g := range byLabel.Groups {
+		if g.Key == "Receipts" && g.Count == 1 {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected label name mapping: %+v", byLabel.Groups)
+	}
+
+	if got := busiestHours(stats.Hours, 5); len(got) != 2 || got[0].Hour != 9 {
+		t.Fatalf("unexpected busiest hours: %+v", got)
+	}
+}
+
+func TestGmailStats_Execute(t *testing.T) {
+	origNew := newGmailService
+	t.Cleanup(func() { newGmailService = origNew })
+
+	message := func(id, from string, size int64, labels ...string) map[string]any {
+		return map[string]any{
+			"id":           id,
+			"threadId":     "t-" + id,
+			"labelIds":     labels,
+			"sizeEstimate": size,
+			"internalDate": "1767949200000",
+			"payload": map[string]any{"headers": []map[string]any{
+				{"name": "From", "value": from},
+				{"name": "Subject", "value": "Hello " + id},
+			}},
+		}
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/gmail/v1")
+		w.Header().Set("Content-Type", "application/json")
+		switch path {
+		case "/users/me/messages":
+			if r.URL.Query().Get("q") != "in:inbox newer_than:7d" {
+				t.Errorf("unexpected query: %q", r.URL.Query().Get("q"))
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"messages": []map[string]any{{"id": "m1"}, {"id": "m2"}}})
+		case "/users/me/messages/m1":
+			_ = json.NewEncoder(w).Encode(message("m1", "Alice <alice@example.com>", 300, "INBOX", "UNREAD"))
+		case "/users/me/messages/m2":
+			_ = json.NewEncoder(w).Encode(message("m2", "bob@other.org", 100, "INBOX"))
+		case "/users/me/labels":
+			_ = json.NewEncoder(w).Encode(map[string]any{"labels": []map[string]any{{"id": "INBOX", "name": "INBOX"}}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	svc, err := gmail.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newGmailService = func(context.Context, string) (*gmail.Service, error) { return svc, nil }
+
+	out := captureStdout(t, func() {
+		_ = captureStderr(t, func() {
+			if err := Execute([]string{"--json", "--account", "a@b.com", "gmail", "stats", "--query", "in:inbox", "--since", "7d", "--group-by", "domain"}); err != nil {
+				t.Fatalf("Execute stats: %v", err)
+			}
+		})
+	})
+
+	var payload struct {
+		Query string     `json:"query"`
+		Stats gmailStats `json:"stats"`
+	}
+	if err := json.Unmarshal([]byte(out), &payload); err != nil {
+		t.Fatalf("decode: %v\n%s", err, out)
+	}
+	if payload.Stats.Messages != 2 || payload.Stats.Unread != 1 || payload.Stats.TotalBytes != 400 {
+		t.Fatalf("unexpected totals: %+v", payload.Stats)
+	}
+	if len(payload.Stats.Groups) != 2 || payload.Stats.Groups[0].Key != "example.com" {
+		t.Fatalf("unexpected groups: %+v", payload.Stats.Groups)
+	}
+
+	var truncated struct {
+		Truncated bool       `json:"truncated"`
+		Stats     gmailStats `json:"stats"`
+	}
+	var stderr string
+	out = captureStdout(t, func() {
+		stderr = captureStderr(t, func() {
+			if err := Execute([]string{"--json", "--account", "a@b.com", "gmail", "stats", "--query", "in:inbox", "--since", "7d", "--max", "1"}); err != nil {
+				t.Fatalf("Execute stats --max: %v", err)
+			}
+		})
+	})
+	if err := json.Unmarshal([]byte(out), &truncated); err != nil {
+		t.Fatalf("decode: %v\n%s", err, out)
+	}
+	if !truncated.Truncated || truncated.Stats.Messages != 1 || !strings.Contains(stderr, "more than 1 messages match") {
+		t.Fatalf("expected a truncation warning, got %+v stderr=%q", truncated, stderr)
+	}
+}
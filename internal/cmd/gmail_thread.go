@@ -56,6 +56,7 @@ type GmailThreadGetCmd struct {
 	ThreadID  string        `arg:"" name:"threadId" help:"Thread ID"`
 	Download  bool          `name:"download" help:"Download attachments"`
 	Full      bool          `name:"full" help:"Show full message bodies"`
+	Format    string        `name:"format" help:"Output format: text|markdown|text-clean (markdown/text-clean strip quoted replies and signatures)" default:"text"`
 	OutputDir OutputDirFlag `embed:""`
 }
 
@@ -70,6 +71,10 @@ func (c *GmailThreadGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if threadID == "" {
 		return usage("empty threadId")
 	}
+	format, err := validateThreadFormat(c.Format)
+	if err != nil {
+		return err
+	}
 
 	svc, err := newGmailService(ctx, account)
 	if err != nil {
@@ -109,16 +114,42 @@ func (c *GmailThreadGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 				downloadedFiles = append(downloadedFiles, attachmentDownloadSummaries(downloads)...)
 			}
 		}
-		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+		payload := map[string]any{
 			"thread":     thread,
 			"downloaded": downloadedFiles,
-		})
+		}
+		if format != threadFormatText {
+			payload["format"] = format
+			payload["rendered"] = renderThread(thread, format, nil)
+		}
+		return outfmt.WriteJSON(ctx, os.Stdout, payload)
 	}
 	if thread == nil || len(thread.Messages) == 0 {
 		u.Err().Println("Empty thread")
 		return nil
 	}
 
+	if format != threadFormatText {
+		if _, err := io.WriteString(os.Stdout, renderThread(thread, format, nil)); err != nil {
+			return err
+		}
+		if c.Download {
+			for _, msg := range thread.Messages {
+				if msg == nil || msg.Id == "" {
+					continue
+				}
+				downloads, err := downloadAttachmentOutputs(ctx, svc, msg.Id, collectAttachments(msg.Payload), attachDir)
+				if err != nil {
+					return err
+				}
+				for _, a := range downloads {
+					u.Err().Printf("Saved: %s", a.Path)
+				}
+			}
+		}
+		return nil
+	}
+
 	// Show message count upfront so users know how many messages to expect
 	u.Out().Printf("Thread contains %d message(s)", len(thread.Messages))
 	u.Out().Println("")
@@ -0,0 +1,472 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"google.golang.org/api/gmail/v1"
+)
+
+const (
+	threadFormatText      = "text"
+	threadFormatMarkdown  = "markdown"
+	threadFormatTextClean = "text-clean"
+)
+
+const quotedTextMarker = "[quoted text hidden]"
+
+var (
+	// "On Mon, Jan 1, 2025 at 10:00 AM Alice <a@example.com> wrote:" (Gmail, Apple Mail, Thunderbird).
+	quoteHeaderPattern = regexp.MustCompile(`(?i)^on\s.+\swrote:$`)
+	// Outlook-style separators that precede the quoted original.
+	originalMessagePattern = regexp.MustCompile(`(?i)^-{2,}\s*original message\s*-{2,}$`)
+	outlookFromPattern     = regexp.MustCompile(`(?i)^\*{0,2}from:\*{0,2}\s.+`)
+	outlookSentPattern     = regexp.MustCompile(`(?i)^\*{0,2}(sent|date):\*{0,2}\s.+`)
+	// Mobile client footers and legal boilerplate that never carry content.
+	signatureFooterPattern = regexp.MustCompile(`(?i)^(sent from my \w+|sent from (mail|outlook) for \w+|get outlook for \w+|sent via \w+)`)
+	disclaimerPattern      = regexp.MustCompile(`(?i)^\W*(confidentiality notice|disclaimer|this (e-?mail|message|communication)( and any (attachments|files)( transmitted with it)?)? (is|are|may contain|contains) (confidential|intended|privileged))`)
+	blankLinesPattern      = regexp.MustCompile(`\n{3,}`)
+)
+
+func validateThreadFormat(format string) (string, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		return threadFormatText, nil
+	}
+	switch format {
+	case threadFormatText, threadFormatMarkdown, threadFormatTextClean:
+		return format, nil
+	case "md":
+		return threadFormatMarkdown, nil
+	default:
+		return "", usagef("invalid --format: %q (expected text|markdown|text-clean)", format)
+	}
+}
+
+// renderThread renders a thread as readable Markdown or cleaned plain text,
+// with quoted history, signatures and disclaimers removed from each message.
+func renderThread(thread *gmail.Thread, format string, loc *time.Location) string {
+	if thread == nil || len(thread.Messages) == 0 {
+		return ""
+	}
+	markdown := format == threadFormatMarkdown
+
+	var b strings.Builder
+	subject := strings.TrimSpace(headerValue(firstMessagePayload(thread), "Subject"))
+	if subject == "" {
+		subject = "(no subject)"
+	}
+	if markdown {
+		fmt.Fprintf(&b, "# %s\n\n", subject)
+		fmt.Fprintf(&b, "_%d message(s) · thread %s_\n\n", len(thread.Messages), thread.Id)
+	} else {
+		fmt.Fprintf(&b, "Subject: %s\n", subject)
+		fmt.Fprintf(&b, "Messages: %d\n\n", len(thread.Messages))
+	}
+
+	for i, msg := range thread.Messages {
+		if msg == nil {
+			continue
+		}
+		if markdown && i > 0 {
+			b.WriteString("---\n\n")
+		}
+		from := strings.TrimSpace(headerValue(msg.Payload, "From"))
+		date := formatGmailDateInLocation(headerValue(msg.Payload, "Date"), loc)
+		to := strings.TrimSpace(headerValue(msg.Payload, "To"))
+		cc := strings.TrimSpace(headerValue(msg.Payload, "Cc"))
+
+		body, quoted := cleanMessageBody(msg.Payload, markdown)
+		attachments := collectAttachments(msg.Payload)
+
+		if markdown {
+			fmt.Fprintf(&b, "## %s — %s\n\n", markdownEscapeInline(from), date)
+			if to != "" {
+				fmt.Fprintf(&b, "**To:** %s  \n", markdownEscapeInline(to))
+			}
+			if cc != "" {
+				fmt.Fprintf(&b, "**Cc:** %s  \n", markdownEscapeInline(cc))
+			}
+			b.WriteString("\n")
+		} else {
+			fmt.Fprintf(&b, "=== %s | %s ===\n", from, date)
+			if to != "" {
+				fmt.Fprintf(&b, "To: %s\n", to)
+			}
+			if cc != "" {
+				fmt.Fprintf(&b, "Cc: %s\n", cc)
+			}
+			b.WriteString("\n")
+		}
+
+		if body != "" {
+			b.WriteString(body)
+			b.WriteString("\n\n")
+		}
+		if quoted && markdown {
+			fmt.Fprintf(&b, "_%s_\n\n", quotedTextMarker)
+		}
+
+		if len(attachments) > 0 {
+			if markdown {
+				b.WriteString("**Attachments:**\n\n")
+				for _, a := range attachments {
+					fmt.Fprintf(&b, "- `%s` (%s, %s)\n", a.Filename, formatBytes(a.Size), a.MimeType)
+				}
+			} else {
+				b.WriteString("Attachments:\n")
+				for _, a := range attachments {
+					fmt.Fprintf(&b, "  %s (%s)\n", a.Filename, formatBytes(a.Size))
+				}
+			}
+			b.WriteString("\n")
+		}
+	}
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+func firstMessagePayload(thread *gmail.Thread) *gmail.MessagePart {
+	if msg := firstMessage(thread); msg != nil {
+		return msg.Payload
+	}
+	return nil
+}
+
+// cleanMessageBody picks the best body for rendering and strips quoted replies
+// and signatures. It reports whether quoted history was removed.
+func cleanMessageBody(p *gmail.MessagePart, markdown bool) (string, bool) {
+	var text string
+	plain := findPartBody(p, "text/plain")
+	htmlBody := findPartBody(p, "text/html")
+	switch {
+	case markdown && htmlBody != "":
+		text = htmlToMarkdown(htmlBody)
+	case plain != "" && !looksLikeHTML(plain):
+		text = plain
+	case plain != "":
+		text = htmlToMarkdown(plain)
+	case htmlBody != "":
+		text = htmlToMarkdown(htmlBody)
+	}
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text, quoted := stripQuotedReply(text)
+	text = stripSignature(text)
+	return strings.TrimSpace(blankLinesPattern.ReplaceAllString(text, "\n\n")), quoted
+}
+
+// stripQuotedReply removes "On … wrote:" blocks, Outlook "Original Message"
+// blocks and ">"-prefixed lines.
+func stripQuotedReply(text string) (string, bool) {
+	lines := strings.Split(text, "\n")
+	out := make([]string, 0, len(lines))
+	quoted := false
+	for i := 0; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if isQuoteHeader(lines, i) {
+			quoted = true
+			break
+		}
+		if strings.HasPrefix(trimmed, ">") {
+			quoted = true
+			continue
+		}
+		out = append(out, lines[i])
+	}
+	return strings.Join(out, "\n"), quoted
+}
+
+func isQuoteHeader(lines []string, i int) bool {
+	line := strings.TrimSpace(lines[i])
+	if originalMessagePattern.MatchString(line) {
+		return true
+	}
+	if strings.HasPrefix(strings.ToLower(line), "on ") {
+		// Clients wrap long attributions, so allow "wrote:" on the next line.
+		if quoteHeaderPattern.MatchString(line) {
+			return true
+		}
+		if i+1 < len(lines) && quoteHeaderPattern.MatchString(line+" "+strings.TrimSpace(lines[i+1])) {
+			return true
+		}
+	}
+	if outlookFromPattern.MatchString(line) {
+		for j := i + 1; j < len(lines) && j <= i+4; j++ {
+			if outlookSentPattern.MatchString(strings.TrimSpace(lines[j])) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// stripSignature cuts the body at the conventional "-- " delimiter, mobile
+// client footers and common legal disclaimers.
+func stripSignature(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if i == 0 {
+			continue
+		}
+		if trimmed == "--" || line == "-- " ||
+			signatureFooterPattern.MatchString(trimmed) ||
+			disclaimerPattern.MatchString(trimmed) {
+			return strings.Join(lines[:i], "\n")
+		}
+	}
+	return text
+}
+
+func markdownEscapeInline(s string) string {
+	replacer := strings.NewReplacer("<", "&lt;", ">", "&gt;", "*", `\*`, "_", `\_`)
+	return replacer.Replace(s)
+}
+
+// htmlToMarkdown converts an HTML email body into Markdown. It falls back to
+// stripHTMLTags when the input cannot be parsed.
+func htmlToMarkdown(s string) string {
+	doc, err := html.Parse(strings.NewReader(s))
+	if err != nil {
+		return stripHTMLTags(s)
+	}
+	w := &markdownWriter{}
+	w.walkChildren(doc)
+	return normalizeMarkdown(w.String())
+}
+
+func normalizeMarkdown(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t ")
+	}
+	s = strings.Join(lines, "\n")
+	s = blankLinesPattern.ReplaceAllString(s, "\n\n")
+	return strings.TrimSpace(s)
+}
+
+type markdownList struct {
+	ordered bool
+	n       int
+}
+
+type markdownWriter struct {
+	b     strings.Builder
+	lists []markdownList
+	pre   int
+}
+
+func (w *markdownWriter) String() string {
+	return w.b.String()
+}
+
+func (w *markdownWriter) atLineStart() bool {
+	s := w.b.String()
+	return s == "" || strings.HasSuffix(s, "\n")
+}
+
+func (w *markdownWriter) newline() {
+	if !w.atLineStart() {
+		w.b.WriteString("\n")
+	}
+}
+
+func (w *markdownWriter) blankLine() {
+	w.newline()
+	if s := w.b.String(); s != "" && !strings.HasSuffix(s, "\n\n") {
+		w.b.WriteString("\n")
+	}
+}
+
+func (w *markdownWriter) text(s string) {
+	if w.pre > 0 {
+		w.b.WriteString(s)
+		return
+	}
+	s = whitespacePattern.ReplaceAllString(strings.ReplaceAll(s, " ", " "), " ")
+	if s == "" || (s == " " && w.atLineStart()) {
+		return
+	}
+	if w.atLineStart() || strings.HasSuffix(w.b.String(), " ") {
+		s = strings.TrimLeft(s, " ")
+	}
+	w.b.WriteString(s)
+}
+
+// inner renders the children of n into a separate buffer.
+func (w *markdownWriter) inner(n *html.Node) string {
+	sub := &markdownWriter{lists: w.lists, pre: w.pre}
+	sub.walkChildren(n)
+	return sub.String()
+}
+
+func (w *markdownWriter) walkChildren(n *html.Node) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		w.walk(c)
+	}
+}
+
+func (w *markdownWriter) walk(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		w.text(n.Data)
+		return
+	case html.ElementNode:
+	default:
+		w.walkChildren(n)
+		return
+	}
+
+	switch n.DataAtom {
+	case atom.Script, atom.Style, atom.Head, atom.Title, atom.Meta, atom.Link:
+		return
+	case atom.Br:
+		w.b.WriteString("\n")
+	case atom.Hr:
+		w.blankLine()
+		w.b.WriteString("---")
+		w.blankLine()
+	case atom.P:
+		w.blankLine()
+		w.walkChildren(n)
+		w.blankLine()
+	case atom.Div, atom.Section, atom.Article, atom.Header, atom.Footer, atom.Table, atom.Tbody, atom.Thead, atom.Center:
+		w.newline()
+		w.walkChildren(n)
+		w.newline()
+	case atom.Tr:
+		w.newline()
+		w.walkChildren(n)
+		w.newline()
+	case atom.Td, atom.Th:
+		if !w.atLineStart() {
+			w.b.WriteString(" | ")
+		}
+		w.walkChildren(n)
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		level := int(n.Data[1] - '0')
+		text := strings.TrimSpace(whitespacePattern.ReplaceAllString(w.inner(n), " "))
+		if text == "" {
+			return
+		}
+		w.blankLine()
+		w.b.WriteString(strings.Repeat("#", level) + " " + text)
+		w.blankLine()
+	case atom.Strong, atom.B:
+		w.wrapInline(n, "**")
+	case atom.Em, atom.I:
+		w.wrapInline(n, "_")
+	case atom.Code:
+		if w.pre > 0 {
+			w.walkChildren(n)
+			return
+		}
+		w.wrapInline(n, "`")
+	case atom.Pre:
+		w.blankLine()
+		w.b.WriteString("```\n")
+		w.pre++
+		w.walkChildren(n)
+		w.pre--
+		w.newline()
+		w.b.WriteString("```")
+		w.blankLine()
+	case atom.A:
+		w.link(n)
+	case atom.Img:
+		if alt := strings.TrimSpace(htmlAttr(n, "alt")); alt != "" {
+			w.text("[image: " + alt + "]")
+		}
+	case atom.Ul, atom.Ol:
+		w.lists = append(w.lists, markdownList{ordered: n.DataAtom == atom.Ol})
+		if len(w.lists) == 1 {
+			w.blankLine()
+		}
+		w.walkChildren(n)
+		w.lists = w.lists[:len(w.lists)-1]
+		if len(w.lists) == 0 {
+			w.blankLine()
+		} else {
+			w.newline()
+		}
+	case atom.Li:
+		w.listItem(n)
+	case atom.Blockquote:
+		inner := normalizeMarkdown(w.inner(n))
+		if inner == "" {
+			return
+		}
+		w.blankLine()
+		for _, line := range strings.Split(inner, "\n") {
+			w.b.WriteString(strings.TrimRight("> "+line, " ") + "\n")
+		}
+		w.blankLine()
+	default:
+		w.walkChildren(n)
+	}
+}
+
+func (w *markdownWriter) wrapInline(n *html.Node, marker string) {
+	inner := w.inner(n)
+	trimmed := strings.TrimSpace(inner)
+	if trimmed == "" {
+		w.text(inner)
+		return
+	}
+	if strings.HasPrefix(inner, " ") {
+		w.text(" ")
+	}
+	w.b.WriteString(marker + trimmed + marker)
+	if strings.HasSuffix(inner, " ") {
+		w.b.WriteString(" ")
+	}
+}
+
+func (w *markdownWriter) link(n *html.Node) {
+	href := strings.TrimSpace(htmlAttr(n, "href"))
+	text := strings.TrimSpace(whitespacePattern.ReplaceAllString(w.inner(n), " "))
+	switch {
+	case text == "" && href == "":
+		return
+	case text == "":
+		w.text(href)
+	case href == "" || strings.HasPrefix(href, "#") || strings.EqualFold(href, text) ||
+		strings.EqualFold(strings.TrimPrefix(href, "mailto:"), text):
+		w.text(text)
+	default:
+		if !w.atLineStart() && !strings.HasSuffix(w.b.String(), " ") {
+			w.b.WriteString(" ")
+		}
+		w.b.WriteString("[" + text + "](" + href + ")")
+	}
+}
+
+func (w *markdownWriter) listItem(n *html.Node) {
+	w.newline()
+	marker := "- "
+	depth := len(w.lists)
+	if depth > 0 {
+		top := &w.lists[depth-1]
+		top.n++
+		if top.ordered {
+			marker = fmt.Sprintf("%d. ", top.n)
+		}
+	} else {
+		depth = 1
+	}
+	w.b.WriteString(strings.Repeat("  ", depth-1) + marker)
+	w.walkChildren(n)
+	w.newline()
+}
+
+func htmlAttr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if strings.EqualFold(a.Key, key) {
+			return a.Val
+		}
+	}
+	return ""
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/gmail/v1"
+)
+
+func TestHTMLToMarkdown(t *testing.T) {
+	in := `<html><head><style>p{}</style></head><body>
+<h2>Status</h2>
+<p>Hello <b>team</b>, see <a href="https://example.com/r">the report</a>.</p>
+<ul><li>one</li><li>two<ol><li>nested</li></ol></li></ul>
+<blockquote>quoted line</blockquote>
+<pre>code  block</pre>
+</body></html>`
+	got := htmlToMarkdown(in)
+	for _, want := range []string{
+		"## Status",
+		"Hello **team**, see [the report](https://example.com/r).",
+		"- one\n- two\n  1. nested",
+		"> quoted line",
+		"```\ncode  block\n```",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("missing %q in:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "p{}") {
+		t.Fatalf("style content leaked: %q", got)
+	}
+}
+
+func TestStripQuotedReply(t *testing.T) {
+	in := "Sounds good.\n\nOn Mon, Jan 1, 2025 at 10:00 AM Alice <\na@example.com> wrote:\n> earlier\n> text"
+	got, quoted := stripQuotedReply(in)
+	if !quoted || strings.TrimSpace(got) != "Sounds good." {
+		t.Fatalf("unexpected: %q quoted=%v", got, quoted)
+	}
+
+	in = "Reply\n> inline quote\nmore"
+	got, quoted = stripQuotedReply(in)
+	if !quoted || got != "Reply\nmore" {
+		t.Fatalf("unexpected inline quote strip: %q", got)
+	}
+
+	in = "Thanks\n\nFrom: Bob <b@example.com>\nSent: Tuesday\nTo: Alice\n\nold"
+	got, quoted = stripQuotedReply(in)
+	if !quoted || strings.TrimSpace(got) != "Thanks" {
+		t.Fatalf("unexpected outlook strip: %q", got)
+	}
+}
+
+func TestStripSignature(t *testing.T) {
+	cases := map[string]string{
+		"Body\n-- \nAlice\nCEO":                          "Body",
+		"Body\n\nSent from my iPhone":                    "Body\n",
+		"Body\nCONFIDENTIALITY NOTICE: this is private.": "Body",
+		"Body\nThis email and any attachments are confidential and intended only for": "Body",
+		"No signature here": "No signature here",
+	}
+	for in, want := range cases {
+		if got := stripSignature(in); got != want {
+			t.Fatalf("stripSignature(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestRenderThreadMarkdown(t *testing.T) {
+	enc := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+	thread := &gmail.Thread{
+		Id: "t1",
+		Messages: []*gmail.Message{
+			{
+				Id: "m1",
+				Payload: &gmail.MessagePart{
+					MimeType: "multipart/mixed",
+					Headers: []*gmail.MessagePartHeader{
+						{Name: "From", Value: "Alice <a@example.com>"},
+						{Name: "To", Value: "b@example.com"},
+						{Name: "Subject", Value: "Invoice"},
+						{Name: "Date", Value: "Mon, 6 Jan 2025 10:00:00 +0000"},
+					},
+					Parts: []*gmail.MessagePart{
+						{MimeType: "text/plain", Body: &gmail.MessagePartBody{Data: enc("Here it is.\n-- \nAlice")}},
+						{MimeType: "text/html", Body: &gmail.MessagePartBody{Data: enc("<p>Here it <b>is</b>.</p>")}},
+						{Filename: "inv.pdf", MimeType: "application/pdf", Body: &gmail.MessagePartBody{AttachmentId: "a1", Size: 2048}},
+					},
+				},
+			},
+			{
+				Id: "m2",
+				Payload: &gmail.MessagePart{
+					MimeType: "text/plain",
+					Headers: []*gmail.MessagePartHeader{
+						{Name: "From", Value: "Bob <b@example.com>"},
+						{Name: "Date", Value: "Mon, 6 Jan 2025 11:00:00 +0000"},
+					},
+					Body: &gmail.MessagePartBody{Data: enc("Thanks!\n\nOn Mon, Jan 6, 2025 Alice wrote:\n> Here it is.")},
+				},
+			},
+		},
+	}
+
+	md := renderThread(thread, threadFormatMarkdown, time.UTC)
+	for _, want := range []string{
+		"# Invoice",
+		"## Alice &lt;a@example.com&gt; — 2025-01-06 10:00",
+		"Here it **is**.",
+		"- `inv.pdf` (2.0 KB, application/pdf)",
+		"---",
+		"Thanks!",
+		"_[quoted text hidden]_",
+	} {
+		if !strings.Contains(md, want) {
+			t.Fatalf("missing %q in:\n%s", want, md)
+		}
+	}
+	if strings.Contains(md, "> Here it is.") {
+		t.Fatalf("quoted text not stripped:\n%s", md)
+	}
+
+	text := renderThread(thread, threadFormatTextClean, time.UTC)
+	for _, want := range []string{
+		"Subject: Invoice",
+		"=== Alice <a@example.com> | 2025-01-06 10:00 ===",
+		"Here it is.",
+		"inv.pdf (2.0 KB)",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("missing %q in:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "Alice\n\nAttachments") || strings.Contains(text, quotedTextMarker) {
+		t.Fatalf("unexpected text-clean output:\n%s", text)
+	}
+}
+
+func TestValidateThreadFormat(t *testing.T) {
+	if got, err := validateThreadFormat("MD"); err != nil || got != threadFormatMarkdown {
+		t.Fatalf("unexpected: %q %v", got, err)
+	}
+	if _, err := validateThreadFormat("pdf"); err == nil {
+		t.Fatalf("expected error")
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/alecthomas/kong"
@@ -16,6 +18,7 @@ import (
 type GmailVacationCmd struct {
 	Get    GmailVacationGetCmd    `cmd:"" name:"get" aliases:"info,show" help:"Get current vacation responder settings"`
 	Update GmailVacationUpdateCmd `cmd:"" name:"update" aliases:"edit,set" help:"Update vacation responder settings"`
+	Sync   GmailVacationSyncCmd   `cmd:"" name:"sync-from-calendar" aliases:"sync" help:"Set the vacation responder window from out-of-office calendar events"`
 }
 
 type GmailVacationGetCmd struct{}
@@ -61,8 +64,11 @@ type GmailVacationUpdateCmd struct {
 	Disable      bool   `name:"disable" help:"Disable vacation responder"`
 	Subject      string `name:"subject" help:"Subject line for auto-reply"`
 	Body         string `name:"body" help:"HTML body of the auto-reply message"`
-	Start        string `name:"start" help:"Start time in RFC3339 format (e.g., 2024-12-20T00:00:00Z)"`
-	End          string `name:"end" help:"End time in RFC3339 format (e.g., 2024-12-31T23:59:59Z)"`
+	BodyFile     string `name:"body-file" help:"Body file path ('-' for stdin); .md files are converted from Markdown to HTML"`
+	Markdown     bool   `name:"markdown" aliases:"md" help:"Treat --body/--body-file as Markdown and convert to HTML"`
+	Start        string `name:"start" aliases:"from" help:"Start time: RFC3339, YYYY-MM-DD, or relative (today, tomorrow, monday)"`
+	End          string `name:"end" aliases:"until" help:"End time: RFC3339, YYYY-MM-DD (end of day), or relative (friday)"`
+	Timezone     string `name:"timezone" short:"z" help:"Timezone for date-only/relative --start/--end (IANA name). Default: local"`
 	ContactsOnly bool   `name:"contacts-only" help:"Only respond to contacts"`
 	DomainOnly   bool   `name:"domain-only" help:"Only respond to same domain"`
 }
@@ -73,7 +79,33 @@ func (c *GmailVacationUpdateCmd) Run(ctx context.Context, kctx *kong.Context, fl
 		return errors.New("cannot specify both --enable and --disable")
 	}
 
-	var err error
+	bodyProvided := flagProvidedAny(kctx, "body", "body-file")
+	bodyHTML, err := resolveVacationBody(c.Body, c.BodyFile, c.Markdown)
+	if err != nil {
+		return err
+	}
+	loc, err := resolveOutputLocation(c.Timezone, false)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	var startMillis, endMillis int64
+	if flagProvided(kctx, "start") {
+		startMillis, err = parseVacationTimeMillis(c.Start, false, now, loc)
+		if err != nil {
+			return err
+		}
+	}
+	if flagProvided(kctx, "end") {
+		endMillis, err = parseVacationTimeMillis(c.End, true, now, loc)
+		if err != nil {
+			return err
+		}
+	}
+	if startMillis != 0 && endMillis != 0 && endMillis <= startMillis {
+		return usage("--end must be after --start")
+	}
+
 	updates := map[string]any{}
 	if c.Enable {
 		updates["enable_auto_reply"] = true
@@ -84,25 +116,15 @@ func (c *GmailVacationUpdateCmd) Run(ctx context.Context, kctx *kong.Context, fl
 	if flagProvided(kctx, "subject") {
 		updates["response_subject"] = c.Subject
 	}
-	if flagProvided(kctx, "body") {
-		updates["response_body_html"] = c.Body
-		updates["response_body_plain_text"] = stripHTML(c.Body)
+	if bodyProvided {
+		updates["response_body_html"] = bodyHTML
+		updates["response_body_plain_text"] = stripHTML(bodyHTML)
 	}
 	if flagProvided(kctx, "start") {
-		var t int64
-		t, err = parseRFC3339ToMillis(c.Start)
-		if err != nil {
-			return err
-		}
-		updates["start_time"] = t
+		updates["start_time"] = startMillis
 	}
 	if flagProvided(kctx, "end") {
-		var t int64
-		t, err = parseRFC3339ToMillis(c.End)
-		if err != nil {
-			return err
-		}
-		updates["end_time"] = t
+		updates["end_time"] = endMillis
 	}
 	if flagProvided(kctx, "contacts-only") {
 		updates["restrict_to_contacts"] = c.ContactsOnly
@@ -155,25 +177,15 @@ func (c *GmailVacationUpdateCmd) Run(ctx context.Context, kctx *kong.Context, fl
 	if flagProvided(kctx, "subject") {
 		vacation.ResponseSubject = c.Subject
 	}
-	if flagProvided(kctx, "body") {
-		vacation.ResponseBodyHtml = c.Body
-		vacation.ResponseBodyPlainText = stripHTML(c.Body)
+	if bodyProvided {
+		vacation.ResponseBodyHtml = bodyHTML
+		vacation.ResponseBodyPlainText = stripHTML(bodyHTML)
 	}
 	if flagProvided(kctx, "start") {
-		var t int64
-		t, err = parseRFC3339ToMillis(c.Start)
-		if err != nil {
-			return err
-		}
-		vacation.StartTime = t
+		vacation.StartTime = startMillis
 	}
 	if flagProvided(kctx, "end") {
-		var t int64
-		t, err = parseRFC3339ToMillis(c.End)
-		if err != nil {
-			return err
-		}
-		vacation.EndTime = t
+		vacation.EndTime = endMillis
 	}
 	if flagProvided(kctx, "contacts-only") {
 		vacation.RestrictToContacts = c.ContactsOnly
@@ -217,6 +229,42 @@ func parseRFC3339ToMillis(rfc3339 string) (int64, error) {
 	return t.UnixMilli(), nil
 }
 
+// parseVacationTimeMillis parses a vacation window bound. Date-only and
+// relative day values for the end bound mean "through the end of that day".
+func parseVacationTimeMillis(expr string, end bool, now time.Time, loc *time.Location) (int64, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return 0, nil
+	}
+	var (
+		t   time.Time
+		err error
+	)
+	if end {
+		t, err = parseTimeExprEndOfDay(expr, now, loc)
+	} else {
+		t, err = parseTimeExpr(expr, now, loc)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return t.UnixMilli(), nil
+}
+
+// resolveVacationBody returns the HTML body from --body/--body-file, converting
+// Markdown when requested or when the file has a .md/.markdown extension.
+func resolveVacationBody(body, bodyFile string, markdown bool) (string, error) {
+	resolved, err := resolveBodyInput(body, bodyFile)
+	if err != nil {
+		return "", err
+	}
+	ext := strings.ToLower(filepath.Ext(strings.TrimSpace(bodyFile)))
+	if markdown || ext == ".md" || ext == ".markdown" {
+		return markdownToHTML(resolved), nil
+	}
+	return resolved, nil
+}
+
 func stripHTML(html string) string {
 	// Very basic HTML stripping for plain text fallback
 	inTag := false
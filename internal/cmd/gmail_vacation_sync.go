@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/kong"
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/gmail/v1"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type GmailVacationSyncCmd struct {
+	CalendarID    string `name:"calendar" default:"primary" help:"Calendar to read out-of-office events from"`
+	Days          int    `name:"days" default:"60" help:"How many days ahead to look for out-of-office events"`
+	Subject       string `name:"subject" help:"Subject line for auto-reply (default: event title)"`
+	Body          string `name:"body" help:"HTML body of the auto-reply message (default: event decline message)"`
+	BodyFile      string `name:"body-file" help:"Body file path ('-' for stdin); .md files are converted from Markdown to HTML"`
+	Markdown      bool   `name:"markdown" aliases:"md" help:"Treat --body/--body-file as Markdown and convert to HTML"`
+	ContactsOnly  bool   `name:"contacts-only" help:"Only respond to contacts"`
+	DomainOnly    bool   `name:"domain-only" help:"Only respond to same domain"`
+	DisableIfNone bool   `name:"disable-if-none" help:"Disable the vacation responder when no out-of-office event is found"`
+}
+
+// vacationWindow is a merged run of overlapping or adjacent out-of-office events.
+type vacationWindow struct {
+	Start          time.Time
+	End            time.Time
+	Summary        string
+	DeclineMessage string
+	EventIDs       []string
+}
+
+func (c *GmailVacationSyncCmd) Run(ctx context.Context, kctx *kong.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	if c.Days <= 0 {
+		return usage("--days must be positive")
+	}
+
+	bodyProvided := flagProvidedAny(kctx, "body", "body-file")
+	bodyHTML, err := resolveVacationBody(c.Body, c.BodyFile, c.Markdown)
+	if err != nil {
+		return err
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+
+	calSvc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+	calendarID, err := resolveCalendarID(ctx, calSvc, c.CalendarID)
+	if err != nil {
+		return err
+	}
+
+	tz, _, err := getCalendarLocation(ctx, calSvc, calendarID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	events, err := listOutOfOfficeEvents(ctx, calSvc, calendarID, now, now.AddDate(0, 0, c.Days))
+	if err != nil {
+		return err
+	}
+	window, ok := nextVacationWindow(events, tz, now)
+
+	if !ok {
+		if !c.DisableIfNone {
+			if outfmt.IsJSON(ctx) {
+				return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"found": false})
+			}
+			u.Err().Printf("No out-of-office events in the next %d days", c.Days)
+			return nil
+		}
+		return c.disable(ctx, flags, account)
+	}
+
+	subject := strings.TrimSpace(c.Subject)
+	if !flagProvided(kctx, "subject") {
+		subject = window.Summary
+	}
+	if !bodyProvided {
+		if strings.TrimSpace(window.DeclineMessage) == "" {
+			return usage("event has no decline message; pass --body or --body-file")
+		}
+		bodyHTML = markdownToHTML(window.DeclineMessage)
+	}
+
+	vacation := &gmail.VacationSettings{
+		EnableAutoReply:       true,
+		ResponseSubject:       subject,
+		ResponseBodyHtml:      bodyHTML,
+		ResponseBodyPlainText: stripHTML(bodyHTML),
+		StartTime:             window.Start.UnixMilli(),
+		EndTime:               window.End.UnixMilli(),
+		RestrictToContacts:    c.ContactsOnly,
+		RestrictToDomain:      c.DomainOnly,
+	}
+
+	if dryRunErr := dryRunExit(ctx, flags, "gmail.vacation.sync_from_calendar", map[string]any{
+		"calendar_id": calendarID,
+		"event_ids":   window.EventIDs,
+		"vacation":    vacation,
+	}); dryRunErr != nil {
+		return dryRunErr
+	}
+
+	svc, err := newGmailService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	updated, err := svc.Users.Settings.UpdateVacation("me", vacation).Do()
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"found":     true,
+			"event_ids": window.EventIDs,
+			"vacation":  updated,
+		})
+	}
+
+	u.Out().Println("Vacation responder synced from calendar")
+	u.Out().Printf("enable_auto_reply\t%t", updated.EnableAutoReply)
+	u.Out().Printf("response_subject\t%s", updated.ResponseSubject)
+	u.Out().Printf("start\t%s", window.Start.Format(time.RFC3339))
+	u.Out().Printf("end\t%s", window.End.Format(time.RFC3339))
+	u.Out().Printf("events\t%s", strings.Join(window.EventIDs, ","))
+	return nil
+}
+
+func (c *GmailVacationSyncCmd) disable(ctx context.Context, flags *RootFlags, account string) error {
+	u := ui.FromContext(ctx)
+	if dryRunErr := dryRunExit(ctx, flags, "gmail.vacation.sync_from_calendar", map[string]any{
+		"updates": map[string]any{"enable_auto_reply": false},
+	}); dryRunErr != nil {
+		return dryRunErr
+	}
+
+	svc, err := newGmailService(ctx, account)
+	if err != nil {
+		return err
+	}
+	current, err := svc.Users.Settings.GetVacation("me").Do()
+	if err != nil {
+		return err
+	}
+	current.EnableAutoReply = false
+	updated, err := svc.Users.Settings.UpdateVacation("me", current).Do()
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"found": false, "vacation": updated})
+	}
+	u.Out().Println("No out-of-office events found; vacation responder disabled")
+	return nil
+}
+
+func listOutOfOfficeEvents(ctx context.Context, svc *calendar.Service, calendarID string, from, to time.Time) ([]*calendar.Event, error) {
+	var out []*calendar.Event
+	pageToken := ""
+	for {
+		call := svc.Events.List(calendarID).
+			EventTypes(eventTypeOutOfOffice).
+			SingleEvents(true).
+			OrderBy("startTime").
+			TimeMin(from.Format(time.RFC3339)).
+			TimeMax(to.Format(time.RFC3339)).
+			MaxResults(250).
+			Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, resp.Items...)
+		if resp.NextPageToken == "" {
+			return out, nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+// nextVacationWindow picks the current or next out-of-office event ending after
+// now and extends it with any events that overlap or directly follow it.
+// All-day events are interpreted in the calendar timezone tz.
+func nextVacationWindow(events []*calendar.Event, tz string, now time.Time) (vacationWindow, bool) {
+	type span struct {
+		start, end time.Time
+		event      *calendar.Event
+	}
+	spans := make([]span, 0, len(events))
+	for _, ev := range events {
+		if ev == nil || ev.Status == "cancelled" {
+			continue
+		}
+		start, end, ok := eventBounds(ev, tz)
+		if !ok || !end.After(now) {
+			continue
+		}
+		spans = append(spans, span{start: start, end: end, event: ev})
+	}
+	if len(spans) == 0 {
+		return vacationWindow{}, false
+	}
+	sort.SliceStable(spans, func(i, j int) bool { return spans[i].start.Before(spans[j].start) })
+
+	first := spans[0]
+	w := vacationWindow{
+		Start:    first.start,
+		End:      first.end,
+		Summary:  strings.TrimSpace(first.event.Summary),
+		EventIDs: []string{first.event.Id},
+	}
+	if props := first.event.OutOfOfficeProperties; props != nil {
+		w.DeclineMessage = props.DeclineMessage
+	}
+	for _, s := range spans[1:] {
+		if s.start.After(w.End) {
+			break
+		}
+		if s.end.After(w.End) {
+			w.End = s.end
+		}
+		w.EventIDs = append(w.EventIDs, s.event.Id)
+	}
+	return w, true
+}
+
+// eventBounds returns an event's start and end; all-day end dates are exclusive.
+func eventBounds(ev *calendar.Event, tz string) (time.Time, time.Time, bool) {
+	if ev.Start == nil || ev.End == nil {
+		return time.Time{}, time.Time{}, false
+	}
+	if ev.Start.DateTime != "" {
+		start, ok := parseEventTime(ev.Start.DateTime, ev.Start.TimeZone)
+		if !ok {
+			return time.Time{}, time.Time{}, false
+		}
+		end, ok := parseEventTime(ev.End.DateTime, ev.End.TimeZone)
+		if !ok {
+			return time.Time{}, time.Time{}, false
+		}
+		return start, end, true
+	}
+	if ev.Start.TimeZone != "" {
+		tz = ev.Start.TimeZone
+	}
+	start, ok := parseEventDate(ev.Start.Date, tz)
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+	end, ok := parseEventDate(ev.End.Date, tz)
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+	return start, end, true
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/option"
+)
+
+func TestNextVacationWindow(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	events := []*calendar.Event{
+		{Id: "past", Start: &calendar.EventDateTime{Date: "2026-02-01"}, End: &calendar.EventDateTime{Date: "2026-02-03"}},
+		{Id: "later", Start: &calendar.EventDateTime{Date: "2026-04-01"}, End: &calendar.EventDateTime{Date: "2026-04-02"}},
+		{
+			Id: "trip", Summary: "Vacation",
+			Start:                 &calendar.EventDateTime{Date: "2026-03-05"},
+			End:                   &calendar.EventDateTime{Date: "2026-03-09"},
+			OutOfOfficeProperties: &calendar.EventOutOfOfficeProperties{DeclineMessage: "Back soon"},
+		},
+		{Id: "extra", Start: &calendar.EventDateTime{DateTime: "2026-03-09T00:00:00Z"}, End: &calendar.EventDateTime{DateTime: "2026-03-10T12:00:00Z"}},
+	}
+	w, ok := nextVacationWindow(events, "UTC", now)
+	if !ok {
+		t.Fatalf("expected a window")
+	}
+	if w.Summary != "Vacation" || w.DeclineMessage != "Back soon" {
+		t.Fatalf("unexpected window metadata: %+v", w)
+	}
+	if !w.Start.Equal(time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)) || !w.End.Equal(time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected window bounds: %s - %s", w.Start, w.End)
+	}
+	if strings.Join(w.EventIDs, ",") != "trip,extra" {
+		t.Fatalf("unexpected event ids: %v", w.EventIDs)
+	}
+	if _, ok := nextVacationWindow(events[:1], "UTC", now); ok {
+		t.Fatalf("past events should not produce a window")
+	}
+}
+
+func TestGmailVacationSyncFromCalendar(t *testing.T) {
+	origCal := newCalendarService
+	origGmail := newGmailService
+	t.Cleanup(func() {
+		newCalendarService = origCal
+		newGmailService = origGmail
+	})
+
+	start := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	end := start.Add(72 * time.Hour)
+	decline := "I'm away"
+	calSrv := httptest.NewServer(withPrimaryCalendar(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/calendars/primary/events") {
+			if got := r.URL.Query().Get("eventTypes"); got != "outOfOffice" {
+				t.Fatalf("expected outOfOffice filter, got %q", got)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"items": []map[string]any{{
+					"id":                    "ooo1",
+					"summary":               "Out of office",
+					"eventType":             "outOfOffice",
+					"start":                 map[string]any{"dateTime": start.Format(time.RFC3339)},
+					"end":                   map[string]any{"dateTime": end.Format(time.RFC3339)},
+					"outOfOfficeProperties": map[string]any{"declineMessage": decline},
+				}},
+			})
+			return
+		}
+		http.NotFound(w, r)
+	})))
+	defer calSrv.Close()
+
+	var got map[string]any
+	gmailSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/users/me/settings/vacation") {
+			_ = json.NewDecoder(r.Body).Decode(&got)
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(got)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer gmailSrv.Close()
+
+	calSvc, err := calendar.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(calSrv.Client()),
+		option.WithEndpoint(calSrv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("calendar.NewService: %v", err)
+	}
+	gmailSvc, err := gmail.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(gmailSrv.Client()),
+		option.WithEndpoint(gmailSrv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("gmail.NewService: %v", err)
+	}
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return calSvc, nil }
+	newGmailService = func(context.Context, string) (*gmail.Service, error) { return gmailSvc, nil }
+
+	_ = captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "gmail", "settings", "vacation", "sync-from-calendar"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	if got["enableAutoReply"] != true || got["responseSubject"] != "Out of office" {
+		t.Fatalf("unexpected vacation update: %#v", got)
+	}
+	if got["startTime"] != strconv.FormatInt(start.UnixMilli(), 10) || got["endTime"] != strconv.FormatInt(end.UnixMilli(), 10) {
+		t.Fatalf("unexpected window: %#v", got)
+	}
+	if html, _ := got["responseBodyHtml"].(string); !strings.Contains(html, "I&#39;m away") {
+		t.Fatalf("unexpected body: %#v", got["responseBodyHtml"])
+	}
+
+	decline, got = "", nil
+	_ = captureStdout(t, func() {
+		err := Execute([]string{"--json", "--account", "a@b.com", "gmail", "settings", "vacation", "sync-from-calendar"})
+		if err == nil || !strings.Contains(err.Error(), "no decline message") {
+			t.Fatalf("expected missing decline message error, got %v", err)
+		}
+	})
+	if got != nil {
+		t.Fatalf("must not enable an empty auto-reply: %#v", got)
+	}
+}
@@ -103,3 +103,24 @@ func TestVacationCommandExists(t *testing.T) {
 	_ = GmailVacationGetCmd{}
 	_ = GmailVacationUpdateCmd{}
 }
+
+func TestParseVacationTimeMillis(t *testing.T) {
+	now := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+	start, err := parseVacationTimeMillis("2026-01-10", false, now, time.UTC)
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if want := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC).UnixMilli(); start != want {
+		t.Fatalf("start = %d, want %d", start, want)
+	}
+	end, err := parseVacationTimeMillis("2026-01-10", true, now, time.UTC)
+	if err != nil {
+		t.Fatalf("end: %v", err)
+	}
+	if want := time.Date(2026, 1, 10, 23, 59, 59, 0, time.UTC).UnixMilli(); end < want {
+		t.Fatalf("end = %d, want end of day", end)
+	}
+	if got, err := parseVacationTimeMillis("", false, now, time.UTC); err != nil || got != 0 {
+		t.Fatalf("empty = %d, %v", got, err)
+	}
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	mdHTMLLinkPattern    = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	mdHTMLCodePattern    = regexp.MustCompile("`([^`]+)`")
+	mdHTMLBoldPattern    = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	mdHTMLItalicPattern  = regexp.MustCompile(`\*([^*]+)\*`)
+	mdHTMLOrderedPattern = regexp.MustCompile(`^\d+\.\s+(.+)$`)
+	mdHTMLBulletPrefixes = []string{"- ", "* ", "+ "}
+)
+
+// markdownToHTML converts the Markdown subset used for email bodies
+// (headings, paragraphs, lists, quotes, code, links, bold/italic) to HTML.
+func markdownToHTML(md string) string {
+	lines := strings.Split(strings.ReplaceAll(md, "\r\n", "\n"), "\n")
+	var b strings.Builder
+	var para []string
+	listTag := ""
+
+	flushPara := func() {
+		if len(para) == 0 {
+			return
+		}
+		b.WriteString("<p>" + strings.Join(para, "<br>\n") + "</p>\n")
+		para = nil
+	}
+	closeList := func() {
+		if listTag != "" {
+			b.WriteString("</" + listTag + ">\n")
+			listTag = ""
+		}
+	}
+	openList := func(tag string) {
+		if listTag == tag {
+			return
+		}
+		closeList()
+		b.WriteString("<" + tag + ">\n")
+		listTag = tag
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") {
+			flushPara()
+			closeList()
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, lines[i])
+			}
+			b.WriteString("<pre><code>" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+			continue
+		}
+		if trimmed == "" {
+			flushPara()
+			closeList()
+			continue
+		}
+		if isHorizontalRule(trimmed) {
+			flushPara()
+			closeList()
+			b.WriteString("<hr>\n")
+			continue
+		}
+		if level, content := parseHeading(trimmed); level > 0 {
+			flushPara()
+			closeList()
+			tag := fmt.Sprintf("h%d", level)
+			b.WriteString("<" + tag + ">" + markdownInlineToHTML(content) + "</" + tag + ">\n")
+			continue
+		}
+		if item, ok := markdownBulletItem(trimmed); ok {
+			flushPara()
+			openList("ul")
+			b.WriteString("<li>" + markdownInlineToHTML(item) + "</li>\n")
+			continue
+		}
+		if m := mdHTMLOrderedPattern.FindStringSubmatch(trimmed); m != nil {
+			flushPara()
+			openList("ol")
+			b.WriteString("<li>" + markdownInlineToHTML(m[1]) + "</li>\n")
+			continue
+		}
+		if strings.HasPrefix(trimmed, ">") {
+			flushPara()
+			closeList()
+			var quote []string
+			for ; i < len(lines); i++ {
+				q := strings.TrimSpace(lines[i])
+				if !strings.HasPrefix(q, ">") {
+					i--
+					break
+				}
+				quote = append(quote, markdownInlineToHTML(strings.TrimSpace(strings.TrimPrefix(q, ">"))))
+			}
+			b.WriteString("<blockquote>" + strings.Join(quote, "<br>\n") + "</blockquote>\n")
+			continue
+		}
+
+		closeList()
+		para = append(para, markdownInlineToHTML(trimmed))
+	}
+	flushPara()
+	closeList()
+	return strings.TrimSpace(b.String())
+}
+
+func markdownBulletItem(line string) (string, bool) {
+	for _, prefix := range mdHTMLBulletPrefixes {
+		if strings.HasPrefix(line, prefix) {
+			return strings.TrimSpace(strings.TrimPrefix(line, prefix)), true
+		}
+	}
+	return "", false
+}
+
+func markdownInlineToHTML(text string) string {
+	text = html.EscapeString(text)
+	text = mdHTMLLinkPattern.ReplaceAllString(text, `<a href="$2">$1</a>`)
+	text = mdHTMLCodePattern.ReplaceAllString(text, "<code>$1</code>")
+	text = mdHTMLBoldPattern.ReplaceAllString(text, "<strong>$1</strong>")
+	text = mdHTMLItalicPattern.ReplaceAllString(text, "<em>$1</em>")
+	return text
+}
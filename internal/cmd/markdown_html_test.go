@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMarkdownToHTML(t *testing.T) {
+	md := "# Away\n\nI'm out **until Monday**.\nSee [docs](https://example.com) & `notes`.\n\n- one\n- two\n\n1. first\n\n> quoted\n\n```\n<raw>\n```"
+	got := markdownToHTML(md)
+	for _, want := range []string{
+		"<h1>Away</h1>",
+		"<p>I&#39;m out <strong>until Monday</strong>.<br>\nSee <a href=\"https://example.com\">docs</a> &amp; <code>notes</code>.</p>",
+		"<ul>\n<li>one</li>\n<li>two</li>\n</ul>",
+		"<ol>\n<li>first</li>\n</ol>",
+		"<blockquote>quoted</blockquote>",
+		"<pre><code>&lt;raw&gt;</code></pre>",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("missing %q in:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Shared by the calendar watch and drive changes watch commands.
+const (
+	defaultWatchPollInterval = time.Minute
+	watchStatusHTTPError     = "http_error"
+	watchStatusExecError     = "exec_error"
+)
+
+// watchHook is where changes are delivered: an HTTP webhook, a shell command
+// that receives the payload on stdin, or both.
+type watchHook struct {
+	URL   string `json:"url,omitempty"`
+	Token string `json:"token,omitempty"`
+	Exec  string `json:"exec,omitempty"`
+}
+
+// watchHookFromFlags builds a hook from the --hook-* flags; nil means none
+// were given.
+func watchHookFromFlags(hookURL, token, execCmd string) (*watchHook, error) {
+	hookURL = strings.TrimSpace(hookURL)
+	execCmd = strings.TrimSpace(execCmd)
+	if hookURL == "" && token != "" {
+		return nil, usage("--hook-url required when using --hook-token")
+	}
+	if hookURL == "" && execCmd == "" {
+		return nil, nil
+	}
+	return &watchHook{URL: hookURL, Token: token, Exec: execCmd}, nil
+}
+
+// runWatchHookExec runs an exec hook through the shell with the payload on
+// stdin. Hook output goes to stderr so stdout stays machine-readable.
+var runWatchHookExec = func(ctx context.Context, command string, env []string, payload []byte) error {
+	var c *exec.Cmd
+	if runtime.GOOS == "windows" {
+		c = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		c = exec.CommandContext(ctx, "/bin/sh", "-c", command)
+	}
+	c.Env = append(os.Environ(), env...)
+	c.Stdin = bytes.NewReader(payload)
+	c.Stdout = os.Stderr
+	c.Stderr = os.Stderr
+	return c.Run()
+}
+
+// postWatchHook POSTs a JSON payload to hook.URL with the optional bearer token.
+func postWatchHook(ctx context.Context, client *http.Client, hook *watchHook, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if hook.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+hook.Token)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -135,6 +135,33 @@ func GmailWatchDir() (string, error) {
 	return filepath.Join(dir, "state", "gmail-watch"), nil
 }
 
+func CalendarWatchDir() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "state", "calendar-watch"), nil
+}
+
+func DriveUploadsDir() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "state", "drive-uploads"), nil
+}
+
+func DriveChangesDir() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "state", "drive-changes"), nil
+}
+
 func KeepServiceAccountPath(email string) (string, error) {
 	dir, err := Dir()
 	if err != nil {
@@ -241,6 +268,45 @@ func EnsureGmailWatchDir() (string, error) {
 	return dir, nil
 }
 
+func EnsureCalendarWatchDir() (string, error) {
+	dir, err := CalendarWatchDir()
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("ensure calendar watch dir: %w", err)
+	}
+
+	return dir, nil
+}
+
+func EnsureDriveUploadsDir() (string, error) {
+	dir, err := DriveUploadsDir()
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("ensure drive uploads dir: %w", err)
+	}
+
+	return dir, nil
+}
+
+func EnsureDriveChangesDir() (string, error) {
+	dir, err := DriveChangesDir()
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("ensure drive changes dir: %w", err)
+	}
+
+	return dir, nil
+}
+
 // ExpandPath expands ~ at the beginning of a path to the user's home directory.
 // This is needed because ~ is a shell feature and is not expanded when paths
 // are quoted (e.g., --out "~/Downloads/file.pdf").
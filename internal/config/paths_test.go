@@ -61,6 +61,15 @@ func TestPaths_CreateDirs(t *testing.T) {
 		t.Fatalf("expected watch dir: %v", statErr)
 	}
 
+	calendarWatchDir, err := EnsureCalendarWatchDir()
+	if err != nil {
+		t.Fatalf("EnsureCalendarWatchDir: %v", err)
+	}
+
+	if _, statErr := os.Stat(calendarWatchDir); statErr != nil {
+		t.Fatalf("expected calendar watch dir: %v", statErr)
+	}
+
 	credsPath, err := ClientCredentialsPath()
 	if err != nil {
 		t.Fatalf("ClientCredentialsPath: %v", err)
@@ -95,6 +95,19 @@ func optionsForAccount(ctx context.Context, service googleauth.Service, email st
 func optionsForAccountScopes(ctx context.Context, serviceLabel string, email string, scopes []string) ([]option.ClientOption, error) {
 	slog.Debug("creating client options with custom scopes", "serviceLabel", serviceLabel, "email", email)
 
+	c, err := httpClientForAccountScopes(ctx, serviceLabel, email, scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("client options with custom scopes created successfully", "serviceLabel", serviceLabel, "email", email)
+
+	return []option.ClientOption{option.WithHTTPClient(c)}, nil
+}
+
+// httpClientForAccountScopes builds the authorized, retrying HTTP client
+// shared by all services.
+func httpClientForAccountScopes(ctx context.Context, serviceLabel string, email string, scopes []string) (*http.Client, error) {
 	var creds config.ClientCredentials
 
 	var ts oauth2.TokenSource
@@ -128,14 +141,10 @@ func optionsForAccountScopes(ctx context.Context, serviceLabel string, email str
 		Source: ts,
 		Base:   baseTransport,
 	})
-	c := &http.Client{
+	return &http.Client{
 		Transport: retryTransport,
 		Timeout:   defaultHTTPTimeout,
-	}
-
-	slog.Debug("client options with custom scopes created successfully", "serviceLabel", serviceLabel, "email", email)
-
-	return []option.ClientOption{option.WithHTTPClient(c)}, nil
+	}, nil
 }
 
 func newBaseTransport() *http.Transport {
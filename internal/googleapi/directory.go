@@ -0,0 +1,22 @@
+package googleapi
+
+import (
+	"context"
+	"fmt"
+
+	admin "google.golang.org/api/admin/directory/v1"
+
+	"github.com/steipete/gogcli/internal/googleauth"
+)
+
+// NewAdminDirectoryResources creates an Admin SDK Directory service for
+// reading calendar resources (rooms, buildings, features).
+func NewAdminDirectoryResources(ctx context.Context, email string) (*admin.Service, error) {
+	if opts, err := optionsForAccount(ctx, googleauth.ServiceResources, email); err != nil {
+		return nil, fmt.Errorf("admin directory options: %w", err)
+	} else if svc, err := admin.NewService(ctx, opts...); err != nil {
+		return nil, fmt.Errorf("create admin directory service: %w", err)
+	} else {
+		return svc, nil
+	}
+}
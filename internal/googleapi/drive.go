@@ -3,6 +3,7 @@ package googleapi
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"google.golang.org/api/drive/v3"
 
@@ -18,3 +19,20 @@ func NewDrive(ctx context.Context, email string) (*drive.Service, error) {
 		return svc, nil
 	}
 }
+
+// NewDriveHTTPClient returns the authorized Drive HTTP client for raw
+// requests the generated client cannot make (resumable upload sessions,
+// revision export links). It has no overall timeout because a single
+// transfer can take longer than a regular API call.
+func NewDriveHTTPClient(ctx context.Context, email string) (*http.Client, error) {
+	scopes, err := googleauth.Scopes(googleauth.ServiceDrive)
+	if err != nil {
+		return nil, fmt.Errorf("resolve scopes: %w", err)
+	}
+	c, err := httpClientForAccountScopes(ctx, string(googleauth.ServiceDrive), email, scopes)
+	if err != nil {
+		return nil, fmt.Errorf("drive http client: %w", err)
+	}
+	c.Timeout = 0
+	return c, nil
+}
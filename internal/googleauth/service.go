@@ -25,6 +25,7 @@ const (
 	ServiceAppScript Service = "appscript"
 	ServiceGroups    Service = "groups"
 	ServiceKeep      Service = "keep"
+	ServiceResources Service = "resources"
 )
 
 const (
@@ -74,6 +75,7 @@ var serviceOrder = []Service{
 	ServiceAppScript,
 	ServiceGroups,
 	ServiceKeep,
+	ServiceResources,
 }
 
 var serviceInfoByService = map[Service]serviceInfo{
@@ -203,6 +205,12 @@ var serviceInfoByService = map[Service]serviceInfo{
 		apis:   []string{"Keep API"},
 		note:   "Workspace only; service account (domain-wide delegation)",
 	},
+	ServiceResources: {
+		scopes: []string{"https://www.googleapis.com/auth/admin.directory.resource.calendar.readonly"},
+		user:   false,
+		apis:   []string{"Admin SDK API"},
+		note:   "Workspace only; meeting rooms and buildings",
+	},
 }
 
 func ParseService(s string) (Service, error) {
@@ -522,6 +530,8 @@ func scopesForServiceWithOptions(service Service, opts ScopeOptions) ([]string,
 		return Scopes(service)
 	case ServiceKeep:
 		return Scopes(service)
+	case ServiceResources:
+		return Scopes(service)
 	default:
 		return nil, errUnknownService
 	}
@@ -0,0 +1,330 @@
+// Package ics reads and writes iCalendar (RFC 5545) content lines and
+// components. It is intentionally schema-agnostic: callers map components
+// to their own types.
+package ics
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	ErrUnexpectedEnd = errors.New("unexpected END")
+	ErrUnclosed      = errors.New("unclosed component")
+	ErrInvalidLine   = errors.New("invalid content line")
+)
+
+const maxLineOctets = 75
+
+// Param is a single property parameter (e.g. TZID=Europe/Berlin).
+type Param struct {
+	Name  string
+	Value string
+}
+
+// Property is a content line: NAME;PARAM=VALUE:value.
+type Property struct {
+	Name   string
+	Params []Param
+	Value  string
+}
+
+// Param returns the first parameter value with the given name.
+func (p Property) Param(name string) string {
+	for _, param := range p.Params {
+		if strings.EqualFold(param.Name, name) {
+			return param.Value
+		}
+	}
+	return ""
+}
+
+// SetParam replaces or appends a parameter.
+func (p *Property) SetParam(name, value string) {
+	for i, param := range p.Params {
+		if strings.EqualFold(param.Name, name) {
+			p.Params[i].Value = value
+			return
+		}
+	}
+	p.Params = append(p.Params, Param{Name: name, Value: value})
+}
+
+// String renders the property as an unfolded content line.
+func (p Property) String() string {
+	var b strings.Builder
+	b.WriteString(p.Name)
+	for _, param := range p.Params {
+		b.WriteByte(';')
+		b.WriteString(param.Name)
+		b.WriteByte('=')
+		b.WriteString(quoteParam(param.Value))
+	}
+	b.WriteByte(':')
+	b.WriteString(p.Value)
+	return b.String()
+}
+
+// Component is a BEGIN/END block such as VCALENDAR, VEVENT or VALARM.
+type Component struct {
+	Name       string
+	Properties []Property
+	Children   []*Component
+}
+
+// New returns an empty component.
+func New(name string) *Component {
+	return &Component{Name: name}
+}
+
+// Add appends a property with a raw (already escaped) value.
+func (c *Component) Add(name, value string, params ...Param) {
+	c.Properties = append(c.Properties, Property{Name: name, Params: params, Value: value})
+}
+
+// AddText appends a TEXT property, escaping the value. Empty values are skipped.
+func (c *Component) AddText(name, value string, params ...Param) {
+	if value == "" {
+		return
+	}
+	c.Add(name, EscapeText(value), params...)
+}
+
+// AddChild appends a nested component.
+func (c *Component) AddChild(child *Component) {
+	c.Children = append(c.Children, child)
+}
+
+// Prop returns the first property with the given name.
+func (c *Component) Prop(name string) (Property, bool) {
+	for _, p := range c.Properties {
+		if strings.EqualFold(p.Name, name) {
+			return p, true
+		}
+	}
+	return Property{}, false
+}
+
+// Value returns the raw value of the first property with the given name.
+func (c *Component) Value(name string) string {
+	p, _ := c.Prop(name)
+	return p.Value
+}
+
+// Text returns the unescaped value of the first property with the given name.
+func (c *Component) Text(name string) string {
+	return UnescapeText(c.Value(name))
+}
+
+// Props returns all properties with the given name.
+func (c *Component) Props(name string) []Property {
+	var out []Property
+	for _, p := range c.Properties {
+		if strings.EqualFold(p.Name, name) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// Components returns direct children with the given name.
+func (c *Component) Components(name string) []*Component {
+	var out []*Component
+	for _, child := range c.Children {
+		if strings.EqualFold(child.Name, name) {
+			out = append(out, child)
+		}
+	}
+	return out
+}
+
+// Parse reads all top-level components (usually a single VCALENDAR).
+func Parse(r io.Reader) ([]*Component, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var roots []*Component
+	var stack []*Component
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		prop, err := ParseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		switch strings.ToUpper(prop.Name) {
+		case "BEGIN":
+			comp := New(strings.ToUpper(prop.Value))
+			if len(stack) > 0 {
+				stack[len(stack)-1].AddChild(comp)
+			} else {
+				roots = append(roots, comp)
+			}
+			stack = append(stack, comp)
+		case "END":
+			if len(stack) == 0 || !strings.EqualFold(stack[len(stack)-1].Name, prop.Value) {
+				return nil, fmt.Errorf("line %d: %w: %s", i+1, ErrUnexpectedEnd, prop.Value)
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("line %d: %w: property outside component", i+1, ErrInvalidLine)
+			}
+			prop.Name = strings.ToUpper(prop.Name)
+			stack[len(stack)-1].Properties = append(stack[len(stack)-1].Properties, prop)
+		}
+	}
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnclosed, stack[len(stack)-1].Name)
+	}
+	return roots, nil
+}
+
+// ParseLine parses a single unfolded content line.
+func ParseLine(line string) (Property, error) {
+	var prop Property
+	i := strings.IndexAny(line, ";:")
+	if i <= 0 {
+		return prop, fmt.Errorf("%w: %q", ErrInvalidLine, line)
+	}
+	prop.Name = line[:i]
+	rest := line[i:]
+	for strings.HasPrefix(rest, ";") {
+		rest = rest[1:]
+		eq := strings.IndexByte(rest, '=')
+		if eq <= 0 {
+			return prop, fmt.Errorf("%w: %q", ErrInvalidLine, line)
+		}
+		name := rest[:eq]
+		rest = rest[eq+1:]
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.IndexByte(rest[1:], '"')
+			if end < 0 {
+				return prop, fmt.Errorf("%w: %q", ErrInvalidLine, line)
+			}
+			value = rest[1 : end+1]
+			rest = rest[end+2:]
+		} else {
+			end := strings.IndexAny(rest, ";:")
+			if end < 0 {
+				return prop, fmt.Errorf("%w: %q", ErrInvalidLine, line)
+			}
+			value = rest[:end]
+			rest = rest[end:]
+		}
+		prop.Params = append(prop.Params, Param{Name: strings.ToUpper(name), Value: value})
+	}
+	if !strings.HasPrefix(rest, ":") {
+		return prop, fmt.Errorf("%w: %q", ErrInvalidLine, line)
+	}
+	prop.Value = rest[1:]
+	return prop, nil
+}
+
+// Encode writes a component tree with CRLF line endings and 75-octet folding.
+func Encode(w io.Writer, c *Component) error {
+	bw := bufio.NewWriter(w)
+	if err := encode(bw, c); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+func encode(w *bufio.Writer, c *Component) error {
+	if err := writeFolded(w, "BEGIN:"+c.Name); err != nil {
+		return err
+	}
+	for _, p := range c.Properties {
+		if err := writeFolded(w, p.String()); err != nil {
+			return err
+		}
+	}
+	for _, child := range c.Children {
+		if err := encode(w, child); err != nil {
+			return err
+		}
+	}
+	return writeFolded(w, "END:"+c.Name)
+}
+
+func writeFolded(w *bufio.Writer, line string) error {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		if _, err := w.WriteString(line[:cut] + "\r\n "); err != nil {
+			return err
+		}
+		line = line[cut:]
+		// Continuation lines start with a space, which counts toward the limit.
+		limit = maxLineOctets - 1
+	}
+	_, err := w.WriteString(line + "\r\n")
+	return err
+}
+
+func unfold(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(lines) > 0 {
+		lines[0] = strings.TrimPrefix(lines[0], "\ufeff")
+	}
+	return lines, nil
+}
+
+// EscapeText escapes a TEXT value.
+func EscapeText(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return r.Replace(s)
+}
+
+// UnescapeText reverses EscapeText.
+func UnescapeText(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+func quoteParam(v string) string {
+	if strings.ContainsAny(v, ":;,") {
+		return `"` + strings.ReplaceAll(v, `"`, "") + `"`
+	}
+	return v
+}
@@ -0,0 +1,85 @@
+package ics
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseUnfoldsAndNests(t *testing.T) {
+	src := "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nBEGIN:VEVENT\r\nUID:abc\r\nSUMMARY:Long\r\n  title\\, here\r\n" +
+		"DTSTART;TZID=\"Europe/Berlin\":20260105T100000\r\nATTENDEE;CN=\"Doe, Jane\";ROLE=REQ-PARTICIPANT:mailto:jane@example.com\r\n" +
+		"BEGIN:VALARM\r\nTRIGGER:-PT15M\r\nEND:VALARM\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+	roots, err := Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(roots) != 1 || roots[0].Name != "VCALENDAR" {
+		t.Fatalf("unexpected roots: %+v", roots)
+	}
+	events := roots[0].Components("VEVENT")
+	if len(events) != 1 {
+		t.Fatalf("expected one VEVENT")
+	}
+	ev := events[0]
+	if got := ev.Text("SUMMARY"); got != "Long title, here" {
+		t.Fatalf("summary = %q", got)
+	}
+	start, _ := ev.Prop("DTSTART")
+	if start.Param("TZID") != "Europe/Berlin" || start.Value != "20260105T100000" {
+		t.Fatalf("unexpected DTSTART: %+v", start)
+	}
+	att, _ := ev.Prop("ATTENDEE")
+	if att.Param("cn") != "Doe, Jane" || att.Value != "mailto:jane@example.com" {
+		t.Fatalf("unexpected ATTENDEE: %+v", att)
+	}
+	if alarms := ev.Components("VALARM"); len(alarms) != 1 || alarms[0].Value("TRIGGER") != "-PT15M" {
+		t.Fatalf("unexpected alarms: %+v", alarms)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	if _, err := Parse(strings.NewReader("BEGIN:VCALENDAR\nEND:VEVENT\n")); !errors.Is(err, ErrUnexpectedEnd) {
+		t.Fatalf("expected ErrUnexpectedEnd, got %v", err)
+	}
+	if _, err := Parse(strings.NewReader("BEGIN:VCALENDAR\n")); !errors.Is(err, ErrUnclosed) {
+		t.Fatalf("expected ErrUnclosed, got %v", err)
+	}
+	if _, err := Parse(strings.NewReader("BEGIN:VCALENDAR\nnocolon\nEND:VCALENDAR\n")); !errors.Is(err, ErrInvalidLine) {
+		t.Fatalf("expected ErrInvalidLine, got %v", err)
+	}
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	cal := New("VCALENDAR")
+	cal.Add("VERSION", "2.0")
+	ev := New("VEVENT")
+	desc := strings.Repeat("Grüße; line,\n", 12)
+	ev.AddText("DESCRIPTION", desc)
+	ev.Add("ORGANIZER", "mailto:a@example.com", Param{Name: "CN", Value: "A: B"})
+	cal.AddChild(ev)
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, cal); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Fatalf("line exceeds %d octets: %q", maxLineOctets, line)
+		}
+	}
+
+	roots, err := Parse(&buf)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	got := roots[0].Components("VEVENT")[0]
+	if got.Text("DESCRIPTION") != desc {
+		t.Fatalf("description did not round-trip: %q", got.Text("DESCRIPTION"))
+	}
+	org, _ := got.Prop("ORGANIZER")
+	if org.Param("CN") != "A: B" {
+		t.Fatalf("quoted param did not round-trip: %+v", org)
+	}
+}
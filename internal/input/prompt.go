@@ -6,6 +6,8 @@ import (
 	"io"
 	"os"
 
+	"golang.org/x/term"
+
 	"github.com/steipete/gogcli/internal/ui"
 )
 
@@ -22,3 +24,33 @@ func PromptLineFrom(ctx context.Context, prompt string, r io.Reader) (string, er
 
 	return ReadLine(r)
 }
+
+// PromptPassword prompts on stderr and reads a secret from stdin without echo
+// when stdin is a terminal. Piped input is read as a single line.
+func PromptPassword(ctx context.Context, prompt string) (string, error) {
+	return PromptPasswordFrom(ctx, prompt, os.Stdin)
+}
+
+func PromptPasswordFrom(ctx context.Context, prompt string, f *os.File) (string, error) {
+	if !term.IsTerminal(int(f.Fd())) {
+		return PromptLineFrom(ctx, prompt, f)
+	}
+
+	u := ui.FromContext(ctx)
+	if u != nil {
+		u.Err().Print(prompt)
+	} else {
+		_, _ = fmt.Fprint(os.Stderr, prompt)
+	}
+
+	b, err := term.ReadPassword(int(f.Fd()))
+	if u != nil {
+		u.Err().Println("")
+	} else {
+		_, _ = fmt.Fprintln(os.Stderr)
+	}
+	if err != nil {
+		return "", fmt.Errorf("read password: %w", err)
+	}
+	return string(b), nil
+}
@@ -62,3 +62,33 @@ func TestPromptLine(t *testing.T) {
 		t.Fatalf("unexpected line: %q", line)
 	}
 }
+
+func TestPromptPasswordFrom_Pipe(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	defer r.Close()
+
+	if _, err := w.WriteString("s3cret\n"); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	_ = w.Close()
+
+	var stderr bytes.Buffer
+	u, err := ui.New(ui.Options{Stdout: &stderr, Stderr: &stderr, Color: "never"})
+	if err != nil {
+		t.Fatalf("ui.New: %v", err)
+	}
+
+	got, err := PromptPasswordFrom(ui.WithUI(context.Background(), u), "Password: ", r)
+	if err != nil {
+		t.Fatalf("PromptPasswordFrom: %v", err)
+	}
+	if got != "s3cret" {
+		t.Fatalf("unexpected password: %q", got)
+	}
+	if !strings.Contains(stderr.String(), "Password: ") {
+		t.Fatalf("expected prompt in stderr: %q", stderr.String())
+	}
+}
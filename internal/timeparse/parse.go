@@ -3,6 +3,8 @@ package timeparse
 import (
 	"errors"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -91,9 +93,16 @@ func ParseDateTimeOrDate(value string, loc *time.Location) (ParsedDateTime, erro
 	return ParsedDateTime{}, fmt.Errorf("%w: %q", ErrInvalidDateTime, value)
 }
 
+var (
+	offsetUnitRe  = regexp.MustCompile(`^(\d+)\s*([a-z]+)$`)
+	clockSuffixRe = regexp.MustCompile(`^(.*?)\s*(?:\bat\s+)?(\d{1,2})(?::(\d{2}))?\s*(am|pm)?$`)
+)
+
 // ParseRangeExpr parses calendar range expressions.
-// Supported: absolute datetime/date forms from ParseDateTimeOrDate plus
-// relative forms (now/today/tomorrow/yesterday/monday/next monday).
+// Supported: absolute datetime/date forms from ParseDateTimeOrDate, relative
+// forms (now/today/tomorrow/yesterday/monday/next monday), offsets from now
+// ("in 2 hours", "+45m", "-1d", "3 days ago") and a day with a clock time
+// ("next tuesday 3pm", "tomorrow at 9:30", "2026-01-05 noon", "15:30").
 func ParseRangeExpr(expr string, now time.Time, loc *time.Location) (time.Time, error) {
 	expr = strings.TrimSpace(expr)
 	if expr == "" {
@@ -121,7 +130,159 @@ func ParseRangeExpr(expr string, now time.Time, loc *time.Location) (time.Time,
 		return parsed.Time, nil
 	}
 
-	return time.Time{}, fmt.Errorf("%w: %q (try: 2026-01-05, today, tomorrow, monday)", ErrInvalidTimeExpr, expr)
+	if t, ok := parseOffset(exprLower, now); ok {
+		return t, nil
+	}
+
+	if t, ok := parseDayWithClock(exprLower, now, loc); ok {
+		return t, nil
+	}
+
+	return time.Time{}, fmt.Errorf("%w: %q (try: 2026-01-05, tomorrow, next tuesday 3pm, in 2 hours, +45m)", ErrInvalidTimeExpr, expr)
+}
+
+// parseOffset handles "+45m", "-1h30m", "+2d", "in 3 hours" and "2 days ago".
+func parseOffset(expr string, now time.Time) (time.Time, bool) {
+	sign := 1
+	var rest string
+
+	switch {
+	case strings.HasPrefix(expr, "+"), strings.HasPrefix(expr, "-"):
+		if expr[0] == '-' {
+			sign = -1
+		}
+
+		rest = strings.TrimSpace(expr[1:])
+		if d, err := time.ParseDuration(rest); err == nil && d >= 0 {
+			return now.Add(time.Duration(sign) * d), true
+		}
+	case strings.HasPrefix(expr, "in "):
+		rest = strings.TrimSpace(strings.TrimPrefix(expr, "in "))
+	case strings.HasSuffix(expr, " ago"):
+		sign = -1
+		rest = strings.TrimSpace(strings.TrimSuffix(expr, " ago"))
+	default:
+		return time.Time{}, false
+	}
+
+	m := offsetUnitRe.FindStringSubmatch(rest)
+	if m == nil {
+		if d, err := time.ParseDuration(rest); err == nil && d >= 0 {
+			return now.Add(time.Duration(sign) * d), true
+		}
+
+		return time.Time{}, false
+	}
+
+	n, err := strconv.Atoi(m[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	n *= sign
+
+	switch m[2] {
+	case "m", "min", "mins", "minute", "minutes":
+		return now.Add(time.Duration(n) * time.Minute), true
+	case "h", "hr", "hrs", "hour", "hours":
+		return now.Add(time.Duration(n) * time.Hour), true
+	case "d", "day", "days":
+		return now.AddDate(0, 0, n), true
+	case "w", "wk", "wks", "week", "weeks":
+		return now.AddDate(0, 0, 7*n), true
+	}
+
+	return time.Time{}, false
+}
+
+// parseDayWithClock handles a day expression followed by a clock time. A bare
+// clock time means today. Clock times need minutes or am/pm ("3pm", "15:30")
+// so plain numbers are not mistaken for hours.
+func parseDayWithClock(expr string, now time.Time, loc *time.Location) (time.Time, bool) {
+	dayExpr, hour, minute, ok := splitClock(expr)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	day, ok := parseDay(dayExpr, now, loc)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, day.Location()), true
+}
+
+func splitClock(expr string) (string, int, int, bool) {
+	for word, hour := range map[string]int{"noon": 12, "midnight": 0} {
+		if expr == word || strings.HasSuffix(expr, " "+word) {
+			dayExpr := strings.TrimSpace(strings.TrimSuffix(expr, word))
+			dayExpr = strings.TrimSpace(strings.TrimSuffix(dayExpr, " at"))
+
+			return dayExpr, hour, 0, true
+		}
+	}
+
+	m := clockSuffixRe.FindStringSubmatch(expr)
+	if m == nil || (m[3] == "" && m[4] == "") {
+		return "", 0, 0, false
+	}
+
+	hour, _ := strconv.Atoi(m[2])
+	minute := 0
+
+	if m[3] != "" {
+		minute, _ = strconv.Atoi(m[3])
+	}
+
+	switch m[4] {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return "", 0, 0, false
+		}
+
+		if hour == 12 {
+			hour = 0
+		}
+
+		if m[4] == "pm" {
+			hour += 12
+		}
+	default:
+		if hour > 23 {
+			return "", 0, 0, false
+		}
+	}
+
+	if minute > 59 {
+		return "", 0, 0, false
+	}
+
+	return strings.TrimSpace(m[1]), hour, minute, true
+}
+
+func parseDay(expr string, now time.Time, loc *time.Location) (time.Time, bool) {
+	switch expr {
+	case "", "today":
+		return startOfDay(now), true
+	case "tomorrow":
+		return startOfDay(now.AddDate(0, 0, 1)), true
+	case "yesterday":
+		return startOfDay(now.AddDate(0, 0, -1)), true
+	}
+
+	if t, ok := parseWeekday(expr, now); ok {
+		return t, true
+	}
+
+	if loc == nil {
+		loc = now.Location()
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", expr, loc); err == nil {
+		return t, true
+	}
+
+	return time.Time{}, false
 }
 
 // ParseSince parses --since values for tracking style queries.
@@ -136,6 +136,55 @@ func TestParseRangeExpr(t *testing.T) {
 	}
 }
 
+//nolint:wsl_v5
+func TestParseRangeExprNatural(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 2, 13, 15, 45, 0, 0, time.UTC) // Friday
+	testCases := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "+45m", want: now.Add(45 * time.Minute)},
+		{value: "-1h30m", want: now.Add(-90 * time.Minute)},
+		{value: "+2d", want: now.AddDate(0, 0, 2)},
+		{value: "in 2 hours", want: now.Add(2 * time.Hour)},
+		{value: "in 90m", want: now.Add(90 * time.Minute)},
+		{value: "in 1 week", want: now.AddDate(0, 0, 7)},
+		{value: "3 days ago", want: now.AddDate(0, 0, -3)},
+		{value: "next tuesday 3pm", want: time.Date(2026, 2, 17, 15, 0, 0, 0, time.UTC)},
+		{value: "Tomorrow at 9:30", want: time.Date(2026, 2, 14, 9, 30, 0, 0, time.UTC)},
+		{value: "today 12am", want: time.Date(2026, 2, 13, 0, 0, 0, 0, time.UTC)},
+		{value: "friday noon", want: time.Date(2026, 2, 13, 12, 0, 0, 0, time.UTC)},
+		{value: "2026-03-01 7:15pm", want: time.Date(2026, 3, 1, 19, 15, 0, 0, time.UTC)},
+		{value: "16:30", want: time.Date(2026, 2, 13, 16, 30, 0, 0, time.UTC)},
+		{value: "in 2 fortnights", wantErr: true},
+		{value: "tomorrow 15", wantErr: true},
+		{value: "13pm", wantErr: true},
+		{value: "someday 3pm", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseRangeExpr(tc.value, now, time.UTC)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRangeExpr: %v", err)
+			}
+			if !got.Equal(tc.want) {
+				t.Fatalf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
 //nolint:wsl_v5
 func TestParseSince(t *testing.T) {
 	t.Parallel()
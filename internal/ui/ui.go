@@ -115,6 +115,34 @@ func (p *Printer) Error(msg string) {
 	p.line(msg)
 }
 
+// Colorize applies hex foreground/background colors (either may be empty).
+// It returns s unchanged when color is disabled.
+func (p *Printer) Colorize(s, fg, bg string) string {
+	if !p.ColorEnabled() || (fg == "" && bg == "") {
+		return s
+	}
+
+	styled := termenv.String(s)
+	if fg != "" {
+		styled = styled.Foreground(p.profile.Color(fg))
+	}
+
+	if bg != "" {
+		styled = styled.Background(p.profile.Color(bg))
+	}
+
+	return styled.String()
+}
+
+// Faint renders s dimmed when color is enabled.
+func (p *Printer) Faint(s string) string {
+	if !p.ColorEnabled() {
+		return s
+	}
+
+	return termenv.String(s).Faint().String()
+}
+
 func (p *Printer) Errorf(format string, args ...any) { p.Error(fmt.Sprintf(format, args...)) }
 func (p *Printer) Printf(format string, args ...any) { p.printf(format, args...) }
 func (p *Printer) Println(msg string)                { p.line(msg) }
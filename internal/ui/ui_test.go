@@ -92,6 +92,35 @@ func TestPrinter_Print(t *testing.T) {
 	}
 }
 
+func TestPrinter_ColorizeAndFaint(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	plain := newPrinter(termenv.NewOutput(&buf, termenv.WithProfile(termenv.Ascii)), termenv.Ascii)
+
+	if got := plain.Colorize("x", "#ffffff", "#000000"); got != "x" {
+		t.Fatalf("expected plain text without color, got: %q", got)
+	}
+
+	if got := plain.Faint("x"); got != "x" {
+		t.Fatalf("expected plain faint text without color, got: %q", got)
+	}
+
+	colored := newPrinter(termenv.NewOutput(&buf, termenv.WithProfile(termenv.TrueColor)), termenv.TrueColor)
+
+	if got := colored.Colorize("x", "", "#039be5"); !strings.Contains(got, "\x1b[") || !strings.Contains(got, "x") {
+		t.Fatalf("expected ANSI background, got: %q", got)
+	}
+
+	if got := colored.Colorize("x", "", ""); got != "x" {
+		t.Fatalf("expected unchanged text without colors, got: %q", got)
+	}
+
+	if got := colored.Faint("x"); !strings.Contains(got, "\x1b[2m") {
+		t.Fatalf("expected faint escape, got: %q", got)
+	}
+}
+
 func TestChooseProfile_NoColorEnv(t *testing.T) {
 	t.Setenv("NO_COLOR", "1")
 